@@ -7,8 +7,11 @@
 package booking
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +24,224 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SortOrder orders a list by its natural key: booking time for receipts,
+// seat position for seats. The default is ascending.
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_DEFAULT    SortOrder = 0
+	SortOrder_SORT_ORDER_ASCENDING  SortOrder = 1
+	SortOrder_SORT_ORDER_DESCENDING SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_DEFAULT",
+		1: "SORT_ORDER_ASCENDING",
+		2: "SORT_ORDER_DESCENDING",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_DEFAULT":    0,
+		"SORT_ORDER_ASCENDING":  1,
+		"SORT_ORDER_DESCENDING": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_booking_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_proto_booking_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{0}
+}
+
+// SeatView controls how much of the occupants' personal data a seat manifest
+// shows. The default is FULL for staff and MASKED for customers.
+type SeatView int32
+
+const (
+	SeatView_SEAT_VIEW_DEFAULT SeatView = 0
+	// FULL shows every occupant's name and email, staff only.
+	SeatView_SEAT_VIEW_FULL SeatView = 1
+	// MASKED shows initials and a masked email, except for the caller's own seats.
+	SeatView_SEAT_VIEW_MASKED SeatView = 2
+	// OCCUPIED shows no occupants at all, only which seats are taken.
+	SeatView_SEAT_VIEW_OCCUPIED SeatView = 3
+)
+
+// Enum value maps for SeatView.
+var (
+	SeatView_name = map[int32]string{
+		0: "SEAT_VIEW_DEFAULT",
+		1: "SEAT_VIEW_FULL",
+		2: "SEAT_VIEW_MASKED",
+		3: "SEAT_VIEW_OCCUPIED",
+	}
+	SeatView_value = map[string]int32{
+		"SEAT_VIEW_DEFAULT":  0,
+		"SEAT_VIEW_FULL":     1,
+		"SEAT_VIEW_MASKED":   2,
+		"SEAT_VIEW_OCCUPIED": 3,
+	}
+)
+
+func (x SeatView) Enum() *SeatView {
+	p := new(SeatView)
+	*p = x
+	return p
+}
+
+func (x SeatView) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatView) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_booking_proto_enumTypes[1].Descriptor()
+}
+
+func (SeatView) Type() protoreflect.EnumType {
+	return &file_proto_booking_proto_enumTypes[1]
+}
+
+func (x SeatView) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatView.Descriptor instead.
+func (SeatView) EnumDescriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{1}
+}
+
+// SeatAvailability filters seats by whether they are taken.
+type SeatAvailability int32
+
+const (
+	SeatAvailability_SEAT_AVAILABILITY_ANY       SeatAvailability = 0
+	SeatAvailability_SEAT_AVAILABILITY_AVAILABLE SeatAvailability = 1
+	SeatAvailability_SEAT_AVAILABILITY_OCCUPIED  SeatAvailability = 2
+)
+
+// Enum value maps for SeatAvailability.
+var (
+	SeatAvailability_name = map[int32]string{
+		0: "SEAT_AVAILABILITY_ANY",
+		1: "SEAT_AVAILABILITY_AVAILABLE",
+		2: "SEAT_AVAILABILITY_OCCUPIED",
+	}
+	SeatAvailability_value = map[string]int32{
+		"SEAT_AVAILABILITY_ANY":       0,
+		"SEAT_AVAILABILITY_AVAILABLE": 1,
+		"SEAT_AVAILABILITY_OCCUPIED":  2,
+	}
+)
+
+func (x SeatAvailability) Enum() *SeatAvailability {
+	p := new(SeatAvailability)
+	*p = x
+	return p
+}
+
+func (x SeatAvailability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatAvailability) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_booking_proto_enumTypes[2].Descriptor()
+}
+
+func (SeatAvailability) Type() protoreflect.EnumType {
+	return &file_proto_booking_proto_enumTypes[2]
+}
+
+func (x SeatAvailability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatAvailability.Descriptor instead.
+func (SeatAvailability) EnumDescriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{2}
+}
+
+type SeatChange int32
+
+const (
+	SeatChange_SEAT_CHANGE_UNSPECIFIED SeatChange = 0
+	// BOOKED: the seat was taken by a purchase.
+	SeatChange_SEAT_CHANGE_BOOKED SeatChange = 1
+	// RELEASED: the seat was freed by a cancellation, or the hold of a
+	// booking session ended.
+	SeatChange_SEAT_CHANGE_RELEASED SeatChange = 2
+	// HELD: the seat was selected in a booking session.
+	SeatChange_SEAT_CHANGE_HELD SeatChange = 3
+	// MOVED: a booking moved from previousSeat, now free, to seat.
+	SeatChange_SEAT_CHANGE_MOVED SeatChange = 4
+)
+
+// Enum value maps for SeatChange.
+var (
+	SeatChange_name = map[int32]string{
+		0: "SEAT_CHANGE_UNSPECIFIED",
+		1: "SEAT_CHANGE_BOOKED",
+		2: "SEAT_CHANGE_RELEASED",
+		3: "SEAT_CHANGE_HELD",
+		4: "SEAT_CHANGE_MOVED",
+	}
+	SeatChange_value = map[string]int32{
+		"SEAT_CHANGE_UNSPECIFIED": 0,
+		"SEAT_CHANGE_BOOKED":      1,
+		"SEAT_CHANGE_RELEASED":    2,
+		"SEAT_CHANGE_HELD":        3,
+		"SEAT_CHANGE_MOVED":       4,
+	}
+)
+
+func (x SeatChange) Enum() *SeatChange {
+	p := new(SeatChange)
+	*p = x
+	return p
+}
+
+func (x SeatChange) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatChange) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_booking_proto_enumTypes[3].Descriptor()
+}
+
+func (SeatChange) Type() protoreflect.EnumType {
+	return &file_proto_booking_proto_enumTypes[3]
+}
+
+func (x SeatChange) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatChange.Descriptor instead.
+func (SeatChange) EnumDescriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{3}
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
@@ -96,8 +317,18 @@ type PurchaseBookingRequest struct {
 	User           *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	PricePaid      float32                `protobuf:"fixed32,4,opt,name=PricePaid,proto3" json:"PricePaid,omitempty"`
 	DisocuntCoupon string                 `protobuf:"bytes,5,opt,name=disocuntCoupon,proto3" json:"disocuntCoupon,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	IdempotencyKey string                 `protobuf:"bytes,6,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	// price is the exact form of PricePaid. It is used instead of
+	// PricePaid when set. Either is the fare the client expects to pay
+	// before the discount and must be the fare of the train; the fare is
+	// charged when neither is set.
+	Price *Money `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	// currency is the currency the booking is charged in, the currency of
+	// the train when empty. The price is converted at the current exchange
+	// rate.
+	Currency      string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PurchaseBookingRequest) Reset() {
@@ -165,6 +396,82 @@ func (x *PurchaseBookingRequest) GetDisocuntCoupon() string {
 	return ""
 }
 
+func (x *PurchaseBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PurchaseBookingRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PurchaseBookingRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Money is an amount in the minor units of its currency, e.g. 2050 for
+// USD 20.50.
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// currencyCode is the ISO 4217 code of the currency, e.g. USD.
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currencyCode,proto3" json:"currencyCode,omitempty"`
+	MinorUnits    int64  `protobuf:"varint,2,opt,name=minorUnits,proto3" json:"minorUnits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_booking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetMinorUnits() int64 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
 type Receipt struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReceiptId     string                 `protobuf:"bytes,1,opt,name=ReceiptId,proto3" json:"ReceiptId,omitempty"`
@@ -175,13 +482,31 @@ type Receipt struct {
 	Section       string                 `protobuf:"bytes,6,opt,name=Section,proto3" json:"Section,omitempty"`
 	Seat          string                 `protobuf:"bytes,7,opt,name=Seat,proto3" json:"Seat,omitempty"`
 	BookingStatus string                 `protobuf:"bytes,8,opt,name=BookingStatus,proto3" json:"BookingStatus,omitempty"`
+	Version       int64                  `protobuf:"varint,9,opt,name=Version,proto3" json:"Version,omitempty"`
+	// bookedAt is the time of the purchase, RFC 3339. It is empty for
+	// bookings made before it was recorded.
+	BookedAt string `protobuf:"bytes,10,opt,name=bookedAt,proto3" json:"bookedAt,omitempty"`
+	// reference is the booking reference, six letters and digits.
+	Reference string `protobuf:"bytes,11,opt,name=reference,proto3" json:"reference,omitempty"`
+	// travelClass is Standard or First.
+	TravelClass string `protobuf:"bytes,12,opt,name=travelClass,proto3" json:"travelClass,omitempty"`
+	// addOns are the extras booked with the seat: Meal, WiFi, Luggage or
+	// Bicycle.
+	AddOns []string `protobuf:"bytes,13,rep,name=addOns,proto3" json:"addOns,omitempty"`
+	// price is the exact form of PricePaid.
+	Price *Money `protobuf:"bytes,14,opt,name=price,proto3" json:"price,omitempty"`
+	// charged is price in the currency the booking was charged in.
+	Charged *Money `protobuf:"bytes,15,opt,name=charged,proto3" json:"charged,omitempty"`
+	// exchangeRate is the rate price was converted to charged with. It is
+	// not set when the booking was charged in the currency of the train.
+	ExchangeRate  *ExchangeRate `protobuf:"bytes,16,opt,name=exchangeRate,proto3" json:"exchangeRate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Receipt) Reset() {
 	*x = Receipt{}
-	mi := &file_proto_booking_proto_msgTypes[2]
+	mi := &file_proto_booking_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -193,7 +518,7 @@ func (x *Receipt) String() string {
 func (*Receipt) ProtoMessage() {}
 
 func (x *Receipt) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[2]
+	mi := &file_proto_booking_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +531,7 @@ func (x *Receipt) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
 func (*Receipt) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{2}
+	return file_proto_booking_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Receipt) GetReceiptId() string {
@@ -265,71 +590,91 @@ func (x *Receipt) GetBookingStatus() string {
 	return ""
 }
 
-type PurchaseBookingResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Receipt) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (x *PurchaseBookingResponse) Reset() {
-	*x = PurchaseBookingResponse{}
-	mi := &file_proto_booking_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Receipt) GetBookedAt() string {
+	if x != nil {
+		return x.BookedAt
+	}
+	return ""
 }
 
-func (x *PurchaseBookingResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Receipt) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
 }
 
-func (*PurchaseBookingResponse) ProtoMessage() {}
+func (x *Receipt) GetTravelClass() string {
+	if x != nil {
+		return x.TravelClass
+	}
+	return ""
+}
 
-func (x *PurchaseBookingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[3]
+func (x *Receipt) GetAddOns() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AddOns
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use PurchaseBookingResponse.ProtoReflect.Descriptor instead.
-func (*PurchaseBookingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{3}
+func (x *Receipt) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
 }
 
-func (x *PurchaseBookingResponse) GetReceipt() *Receipt {
+func (x *Receipt) GetCharged() *Money {
 	if x != nil {
-		return x.Receipt
+		return x.Charged
 	}
 	return nil
 }
 
-type ShowReceiptRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+func (x *Receipt) GetExchangeRate() *ExchangeRate {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return nil
+}
+
+// ExchangeRate is a rate of the exchange-rate table in effect at the time
+// of the booking.
+type ExchangeRate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version is the version of the exchange-rate table.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	From    string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// rate is the decimal number of units of to per unit of from, e.g.
+	// "0.92".
+	Rate          string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShowReceiptRequest) Reset() {
-	*x = ShowReceiptRequest{}
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
 	mi := &file_proto_booking_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowReceiptRequest) String() string {
+func (x *ExchangeRate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowReceiptRequest) ProtoMessage() {}
+func (*ExchangeRate) ProtoMessage() {}
 
-func (x *ShowReceiptRequest) ProtoReflect() protoreflect.Message {
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_booking_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -341,39 +686,60 @@ func (x *ShowReceiptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowReceiptRequest.ProtoReflect.Descriptor instead.
-func (*ShowReceiptRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
 	return file_proto_booking_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ShowReceiptRequest) GetUserId() string {
+func (x *ExchangeRate) GetVersion() string {
 	if x != nil {
-		return x.UserId
+		return x.Version
 	}
 	return ""
 }
 
-type ShowReceiptResponse struct {
+func (x *ExchangeRate) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type GetReceiptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Receipt       []*Receipt             `protobuf:"bytes,1,rep,name=receipt,proto3" json:"receipt,omitempty"`
+	ReceiptId     string                 `protobuf:"bytes,1,opt,name=receiptId,proto3" json:"receiptId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShowReceiptResponse) Reset() {
-	*x = ShowReceiptResponse{}
+func (x *GetReceiptRequest) Reset() {
+	*x = GetReceiptRequest{}
 	mi := &file_proto_booking_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowReceiptResponse) String() string {
+func (x *GetReceiptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowReceiptResponse) ProtoMessage() {}
+func (*GetReceiptRequest) ProtoMessage() {}
 
-func (x *ShowReceiptResponse) ProtoReflect() protoreflect.Message {
+func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_booking_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -385,39 +751,39 @@ func (x *ShowReceiptResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowReceiptResponse.ProtoReflect.Descriptor instead.
-func (*ShowReceiptResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
 	return file_proto_booking_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ShowReceiptResponse) GetReceipt() []*Receipt {
+func (x *GetReceiptRequest) GetReceiptId() string {
 	if x != nil {
-		return x.Receipt
+		return x.ReceiptId
 	}
-	return nil
+	return ""
 }
 
-type GetSectionBookingDetailsRequest struct {
+type GetReceiptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SectionId     string                 `protobuf:"bytes,1,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSectionBookingDetailsRequest) Reset() {
-	*x = GetSectionBookingDetailsRequest{}
+func (x *GetReceiptResponse) Reset() {
+	*x = GetReceiptResponse{}
 	mi := &file_proto_booking_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSectionBookingDetailsRequest) String() string {
+func (x *GetReceiptResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSectionBookingDetailsRequest) ProtoMessage() {}
+func (*GetReceiptResponse) ProtoMessage() {}
 
-func (x *GetSectionBookingDetailsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_booking_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -429,45 +795,2675 @@ func (x *GetSectionBookingDetailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSectionBookingDetailsRequest.ProtoReflect.Descriptor instead.
-func (*GetSectionBookingDetailsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptResponse) Descriptor() ([]byte, []int) {
 	return file_proto_booking_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetSectionBookingDetailsRequest) GetSectionId() string {
+func (x *GetReceiptResponse) GetReceipt() *Receipt {
 	if x != nil {
-		return x.SectionId
+		return x.Receipt
 	}
-	return ""
+	return nil
 }
 
-type SeatBooking struct {
+// BookingReference identifies a booking to its passenger: the booking
+// reference printed on the receipt and the passenger's last name. Case,
+// spaces and dashes do not matter.
+type BookingReference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SeatId        string                 `protobuf:"bytes,1,opt,name=seatId,proto3" json:"seatId,omitempty"`
-	SeatNumber    string                 `protobuf:"bytes,2,opt,name=SeatNumber,proto3" json:"SeatNumber,omitempty"`
-	SectionId     string                 `protobuf:"bytes,3,opt,name=SectionId,proto3" json:"SectionId,omitempty"`
-	SectionName   string                 `protobuf:"bytes,4,opt,name=SectionName,proto3" json:"SectionName,omitempty"`
-	User          *User                  `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
-	SeatAvailable bool                   `protobuf:"varint,6,opt,name=SeatAvailable,proto3" json:"SeatAvailable,omitempty"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=lastName,proto3" json:"lastName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SeatBooking) Reset() {
-	*x = SeatBooking{}
+func (x *BookingReference) Reset() {
+	*x = BookingReference{}
+	mi := &file_proto_booking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingReference) ProtoMessage() {}
+
+func (x *BookingReference) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_booking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingReference.ProtoReflect.Descriptor instead.
+func (*BookingReference) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BookingReference) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *BookingReference) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+type GetBookingByReferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Booking       *BookingReference      `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingByReferenceRequest) Reset() {
+	*x = GetBookingByReferenceRequest{}
+	mi := &file_proto_booking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingByReferenceRequest) ProtoMessage() {}
+
+func (x *GetBookingByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetBookingByReferenceRequest) GetBooking() *BookingReference {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+type CancelBookingByReferenceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Booking         *BookingReference      `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,3,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelBookingByReferenceRequest) Reset() {
+	*x = CancelBookingByReferenceRequest{}
+	mi := &file_proto_booking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingByReferenceRequest) ProtoMessage() {}
+
+func (x *CancelBookingByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelBookingByReferenceRequest) GetBooking() *BookingReference {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+func (x *CancelBookingByReferenceRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *CancelBookingByReferenceRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ChangeSeatByReferenceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Booking         *BookingReference      `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	NewSeatId       string                 `protobuf:"bytes,2,opt,name=newSeatId,proto3" json:"newSeatId,omitempty"`
+	NewSectionId    string                 `protobuf:"bytes,3,opt,name=newSectionId,proto3" json:"newSectionId,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,4,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,5,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangeSeatByReferenceRequest) Reset() {
+	*x = ChangeSeatByReferenceRequest{}
+	mi := &file_proto_booking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSeatByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSeatByReferenceRequest) ProtoMessage() {}
+
+func (x *ChangeSeatByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSeatByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*ChangeSeatByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChangeSeatByReferenceRequest) GetBooking() *BookingReference {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+func (x *ChangeSeatByReferenceRequest) GetNewSeatId() string {
+	if x != nil {
+		return x.NewSeatId
+	}
+	return ""
+}
+
+func (x *ChangeSeatByReferenceRequest) GetNewSectionId() string {
+	if x != nil {
+		return x.NewSectionId
+	}
+	return ""
+}
+
+func (x *ChangeSeatByReferenceRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *ChangeSeatByReferenceRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type PurchaseBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseBookingResponse) Reset() {
+	*x = PurchaseBookingResponse{}
+	mi := &file_proto_booking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseBookingResponse) ProtoMessage() {}
+
+func (x *PurchaseBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseBookingResponse.ProtoReflect.Descriptor instead.
+func (*PurchaseBookingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PurchaseBookingResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// BookingFilter selects receipts. Every field that is set must match.
+type BookingFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status is "Confirmed" or "Cancelled".
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// bookedAfter and bookedBefore bound the booking time, RFC 3339. The
+	// range includes bookedAfter and excludes bookedBefore.
+	BookedAfter   string `protobuf:"bytes,2,opt,name=bookedAfter,proto3" json:"bookedAfter,omitempty"`
+	BookedBefore  string `protobuf:"bytes,3,opt,name=bookedBefore,proto3" json:"bookedBefore,omitempty"`
+	TrainId       string `protobuf:"bytes,4,opt,name=trainId,proto3" json:"trainId,omitempty"`
+	SectionId     string `protobuf:"bytes,5,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingFilter) Reset() {
+	*x = BookingFilter{}
+	mi := &file_proto_booking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingFilter) ProtoMessage() {}
+
+func (x *BookingFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingFilter.ProtoReflect.Descriptor instead.
+func (*BookingFilter) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BookingFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BookingFilter) GetBookedAfter() string {
+	if x != nil {
+		return x.BookedAfter
+	}
+	return ""
+}
+
+func (x *BookingFilter) GetBookedBefore() string {
+	if x != nil {
+		return x.BookedBefore
+	}
+	return ""
+}
+
+func (x *BookingFilter) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *BookingFilter) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+type ShowReceiptRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Filter    *BookingFilter         `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Order     SortOrder              `protobuf:"varint,3,opt,name=order,proto3,enum=booking.SortOrder" json:"order,omitempty"`
+	PageSize  int32                  `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string                 `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// readMask names the receipt fields to return, all fields when empty.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=readMask,proto3" json:"readMask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowReceiptRequest) Reset() {
+	*x = ShowReceiptRequest{}
+	mi := &file_proto_booking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowReceiptRequest) ProtoMessage() {}
+
+func (x *ShowReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowReceiptRequest.ProtoReflect.Descriptor instead.
+func (*ShowReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShowReceiptRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShowReceiptRequest) GetFilter() *BookingFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ShowReceiptRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+func (x *ShowReceiptRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ShowReceiptRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ShowReceiptRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ShowReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       []*Receipt             `protobuf:"bytes,1,rep,name=receipt,proto3" json:"receipt,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// totalSize is the number of receipts matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowReceiptResponse) Reset() {
+	*x = ShowReceiptResponse{}
+	mi := &file_proto_booking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowReceiptResponse) ProtoMessage() {}
+
+func (x *ShowReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowReceiptResponse.ProtoReflect.Descriptor instead.
+func (*ShowReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ShowReceiptResponse) GetReceipt() []*Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *ShowReceiptResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ShowReceiptResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type ListBookingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userId limits the list to one user when set.
+	UserId        string         `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Filter        *BookingFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Order         SortOrder      `protobuf:"varint,3,opt,name=order,proto3,enum=booking.SortOrder" json:"order,omitempty"`
+	PageSize      int32          `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string         `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookingsRequest) Reset() {
+	*x = ListBookingsRequest{}
+	mi := &file_proto_booking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookingsRequest) ProtoMessage() {}
+
+func (x *ListBookingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookingsRequest.ProtoReflect.Descriptor instead.
+func (*ListBookingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListBookingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBookingsRequest) GetFilter() *BookingFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListBookingsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+func (x *ListBookingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBookingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListBookingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*Receipt             `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	TotalSize     int32                  `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookingsResponse) Reset() {
+	*x = ListBookingsResponse{}
+	mi := &file_proto_booking_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookingsResponse) ProtoMessage() {}
+
+func (x *ListBookingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookingsResponse.ProtoReflect.Descriptor instead.
+func (*ListBookingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListBookingsResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+func (x *ListBookingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBookingsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type GetSectionBookingDetailsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SectionId string                 `protobuf:"bytes,1,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	View      SeatView               `protobuf:"varint,2,opt,name=view,proto3,enum=booking.SeatView" json:"view,omitempty"`
+	// trainId must be the ID of the train when set.
+	TrainId      string           `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`
+	Availability SeatAvailability `protobuf:"varint,4,opt,name=availability,proto3,enum=booking.SeatAvailability" json:"availability,omitempty"`
+	Order        SortOrder        `protobuf:"varint,5,opt,name=order,proto3,enum=booking.SortOrder" json:"order,omitempty"`
+	PageSize     int32            `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken    string           `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// readMask names the seat booking fields to return, all fields when
+	// empty.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=readMask,proto3" json:"readMask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSectionBookingDetailsRequest) Reset() {
+	*x = GetSectionBookingDetailsRequest{}
+	mi := &file_proto_booking_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSectionBookingDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSectionBookingDetailsRequest) ProtoMessage() {}
+
+func (x *GetSectionBookingDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSectionBookingDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetSectionBookingDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSectionBookingDetailsRequest) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *GetSectionBookingDetailsRequest) GetView() SeatView {
+	if x != nil {
+		return x.View
+	}
+	return SeatView_SEAT_VIEW_DEFAULT
+}
+
+func (x *GetSectionBookingDetailsRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *GetSectionBookingDetailsRequest) GetAvailability() SeatAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return SeatAvailability_SEAT_AVAILABILITY_ANY
+}
+
+func (x *GetSectionBookingDetailsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+func (x *GetSectionBookingDetailsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetSectionBookingDetailsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetSectionBookingDetailsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type SeatBooking struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        string                 `protobuf:"bytes,1,opt,name=seatId,proto3" json:"seatId,omitempty"`
+	SeatNumber    string                 `protobuf:"bytes,2,opt,name=SeatNumber,proto3" json:"SeatNumber,omitempty"`
+	SectionId     string                 `protobuf:"bytes,3,opt,name=SectionId,proto3" json:"SectionId,omitempty"`
+	SectionName   string                 `protobuf:"bytes,4,opt,name=SectionName,proto3" json:"SectionName,omitempty"`
+	User          *User                  `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	SeatAvailable bool                   `protobuf:"varint,6,opt,name=SeatAvailable,proto3" json:"SeatAvailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatBooking) Reset() {
+	*x = SeatBooking{}
+	mi := &file_proto_booking_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatBooking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatBooking) ProtoMessage() {}
+
+func (x *SeatBooking) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatBooking.ProtoReflect.Descriptor instead.
+func (*SeatBooking) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SeatBooking) GetSeatId() string {
+	if x != nil {
+		return x.SeatId
+	}
+	return ""
+}
+
+func (x *SeatBooking) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
+func (x *SeatBooking) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *SeatBooking) GetSectionName() string {
+	if x != nil {
+		return x.SectionName
+	}
+	return ""
+}
+
+func (x *SeatBooking) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *SeatBooking) GetSeatAvailable() bool {
+	if x != nil {
+		return x.SeatAvailable
+	}
+	return false
+}
+
+type GetSectionBookingDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatBookings  []*SeatBooking         `protobuf:"bytes,1,rep,name=seatBookings,proto3" json:"seatBookings,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// totalSize is the number of seats matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSectionBookingDetailsResponse) Reset() {
+	*x = GetSectionBookingDetailsResponse{}
+	mi := &file_proto_booking_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSectionBookingDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSectionBookingDetailsResponse) ProtoMessage() {}
+
+func (x *GetSectionBookingDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSectionBookingDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetSectionBookingDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSectionBookingDetailsResponse) GetSeatBookings() []*SeatBooking {
+	if x != nil {
+		return x.SeatBookings
+	}
+	return nil
+}
+
+func (x *GetSectionBookingDetailsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetSectionBookingDetailsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type UpdateSeatBookingRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId       string                 `protobuf:"bytes,1,opt,name=ReceiptId,proto3" json:"ReceiptId,omitempty"`
+	NewSeatId       string                 `protobuf:"bytes,2,opt,name=NewSeatId,proto3" json:"NewSeatId,omitempty"`
+	NewSectionId    string                 `protobuf:"bytes,3,opt,name=NewSectionId,proto3" json:"NewSectionId,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,4,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=ExpectedVersion,proto3" json:"ExpectedVersion,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateSeatBookingRequest) Reset() {
+	*x = UpdateSeatBookingRequest{}
+	mi := &file_proto_booking_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSeatBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSeatBookingRequest) ProtoMessage() {}
+
+func (x *UpdateSeatBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSeatBookingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSeatBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateSeatBookingRequest) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *UpdateSeatBookingRequest) GetNewSeatId() string {
+	if x != nil {
+		return x.NewSeatId
+	}
+	return ""
+}
+
+func (x *UpdateSeatBookingRequest) GetNewSectionId() string {
+	if x != nil {
+		return x.NewSectionId
+	}
+	return ""
+}
+
+func (x *UpdateSeatBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UpdateSeatBookingRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateSeatBookingResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedReceipt *Receipt               `protobuf:"bytes,1,opt,name=UpdatedReceipt,proto3" json:"UpdatedReceipt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateSeatBookingResponse) Reset() {
+	*x = UpdateSeatBookingResponse{}
+	mi := &file_proto_booking_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSeatBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSeatBookingResponse) ProtoMessage() {}
+
+func (x *UpdateSeatBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSeatBookingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSeatBookingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateSeatBookingResponse) GetUpdatedReceipt() *Receipt {
+	if x != nil {
+		return x.UpdatedReceipt
+	}
+	return nil
+}
+
+// BookingUpdate holds the new values of the fields of a booking. The name
+// and email are those of the passenger, the user of the booking.
+type BookingUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Seat          *SeatRef               `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`
+	TravelClass   string                 `protobuf:"bytes,5,opt,name=travelClass,proto3" json:"travelClass,omitempty"`
+	AddOns        []string               `protobuf:"bytes,6,rep,name=addOns,proto3" json:"addOns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingUpdate) Reset() {
+	*x = BookingUpdate{}
+	mi := &file_proto_booking_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingUpdate) ProtoMessage() {}
+
+func (x *BookingUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingUpdate.ProtoReflect.Descriptor instead.
+func (*BookingUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BookingUpdate) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetSeat() *SeatRef {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *BookingUpdate) GetTravelClass() string {
+	if x != nil {
+		return x.TravelClass
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetAddOns() []string {
+	if x != nil {
+		return x.AddOns
+	}
+	return nil
+}
+
+type UpdateBookingRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId string                 `protobuf:"bytes,1,opt,name=receiptId,proto3" json:"receiptId,omitempty"`
+	Booking   *BookingUpdate         `protobuf:"bytes,2,opt,name=booking,proto3" json:"booking,omitempty"`
+	// updateMask names the fields of booking to change; other fields are
+	// ignored.
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,4,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,5,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateBookingRequest) Reset() {
+	*x = UpdateBookingRequest{}
+	mi := &file_proto_booking_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookingRequest) ProtoMessage() {}
+
+func (x *UpdateBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateBookingRequest) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *UpdateBookingRequest) GetBooking() *BookingUpdate {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+func (x *UpdateBookingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateBookingRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type UpdateBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookingResponse) Reset() {
+	*x = UpdateBookingResponse{}
+	mi := &file_proto_booking_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookingResponse) ProtoMessage() {}
+
+func (x *UpdateBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBookingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateBookingResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type DeleteBookingRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId       string                 `protobuf:"bytes,1,opt,name=ReceiptId,proto3" json:"ReceiptId,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,2,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=ExpectedVersion,proto3" json:"ExpectedVersion,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteBookingRequest) Reset() {
+	*x = DeleteBookingRequest{}
+	mi := &file_proto_booking_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookingRequest) ProtoMessage() {}
+
+func (x *DeleteBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteBookingRequest) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *DeleteBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeleteBookingRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteBookingResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeleteStatus bool                   `protobuf:"varint,1,opt,name=DeleteStatus,proto3" json:"DeleteStatus,omitempty"`
+	Version      int64                  `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// receipt is the booking as cancelled.
+	Receipt       *Receipt `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookingResponse) Reset() {
+	*x = DeleteBookingResponse{}
+	mi := &file_proto_booking_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookingResponse) ProtoMessage() {}
+
+func (x *DeleteBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBookingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteBookingResponse) GetDeleteStatus() bool {
+	if x != nil {
+		return x.DeleteStatus
+	}
+	return false
+}
+
+func (x *DeleteBookingResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeleteBookingResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type WatchAvailabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// trainId must be the ID of the train when set.
+	TrainId string `protobuf:"bytes,1,opt,name=trainId,proto3" json:"trainId,omitempty"`
+	// sectionId limits the stream to one section, all sections are watched
+	// when it is empty.
+	SectionId string `protobuf:"bytes,2,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	// resumeToken is the token of the last event received. The stream then
+	// continues after that event, without a snapshot. A fresh snapshot is
+	// sent when the events since the token are no longer available.
+	ResumeToken   string `protobuf:"bytes,3,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAvailabilityRequest) Reset() {
+	*x = WatchAvailabilityRequest{}
+	mi := &file_proto_booking_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAvailabilityRequest) ProtoMessage() {}
+
+func (x *WatchAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*WatchAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchAvailabilityRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type SeatState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        string                 `protobuf:"bytes,1,opt,name=seatId,proto3" json:"seatId,omitempty"`
+	SeatNumber    string                 `protobuf:"bytes,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
+	SectionId     string                 `protobuf:"bytes,3,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	SectionName   string                 `protobuf:"bytes,4,opt,name=sectionName,proto3" json:"sectionName,omitempty"`
+	SeatAvailable bool                   `protobuf:"varint,5,opt,name=seatAvailable,proto3" json:"seatAvailable,omitempty"`
+	// held is set while a booking session holds the seat. A held seat is
+	// available but cannot be booked by anybody else.
+	Held          bool `protobuf:"varint,6,opt,name=held,proto3" json:"held,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatState) Reset() {
+	*x = SeatState{}
+	mi := &file_proto_booking_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatState) ProtoMessage() {}
+
+func (x *SeatState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatState.ProtoReflect.Descriptor instead.
+func (*SeatState) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SeatState) GetSeatId() string {
+	if x != nil {
+		return x.SeatId
+	}
+	return ""
+}
+
+func (x *SeatState) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
+func (x *SeatState) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *SeatState) GetSectionName() string {
+	if x != nil {
+		return x.SectionName
+	}
+	return ""
+}
+
+func (x *SeatState) GetSeatAvailable() bool {
+	if x != nil {
+		return x.SeatAvailable
+	}
+	return false
+}
+
+func (x *SeatState) GetHeld() bool {
+	if x != nil {
+		return x.Held
+	}
+	return false
+}
+
+type SeatChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Change        SeatChange             `protobuf:"varint,1,opt,name=change,proto3,enum=booking.SeatChange" json:"change,omitempty"`
+	Seat          *SeatState             `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"`
+	PreviousSeat  *SeatState             `protobuf:"bytes,3,opt,name=previousSeat,proto3" json:"previousSeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatChangeEvent) Reset() {
+	*x = SeatChangeEvent{}
+	mi := &file_proto_booking_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatChangeEvent) ProtoMessage() {}
+
+func (x *SeatChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatChangeEvent.ProtoReflect.Descriptor instead.
+func (*SeatChangeEvent) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SeatChangeEvent) GetChange() SeatChange {
+	if x != nil {
+		return x.Change
+	}
+	return SeatChange_SEAT_CHANGE_UNSPECIFIED
+}
+
+func (x *SeatChangeEvent) GetSeat() *SeatState {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *SeatChangeEvent) GetPreviousSeat() *SeatState {
+	if x != nil {
+		return x.PreviousSeat
+	}
+	return nil
+}
+
+type AvailabilitySnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrainId       string                 `protobuf:"bytes,1,opt,name=trainId,proto3" json:"trainId,omitempty"`
+	Seats         []*SeatState           `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilitySnapshot) Reset() {
+	*x = AvailabilitySnapshot{}
+	mi := &file_proto_booking_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilitySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilitySnapshot) ProtoMessage() {}
+
+func (x *AvailabilitySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilitySnapshot.ProtoReflect.Descriptor instead.
+func (*AvailabilitySnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AvailabilitySnapshot) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *AvailabilitySnapshot) GetSeats() []*SeatState {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+type AvailabilityEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*AvailabilityEvent_Snapshot
+	//	*AvailabilityEvent_Change
+	Event isAvailabilityEvent_Event `protobuf_oneof:"event"`
+	// resumeToken resumes the stream after this event.
+	ResumeToken   string `protobuf:"bytes,3,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityEvent) Reset() {
+	*x = AvailabilityEvent{}
+	mi := &file_proto_booking_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityEvent) ProtoMessage() {}
+
+func (x *AvailabilityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityEvent.ProtoReflect.Descriptor instead.
+func (*AvailabilityEvent) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AvailabilityEvent) GetEvent() isAvailabilityEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *AvailabilityEvent) GetSnapshot() *AvailabilitySnapshot {
+	if x != nil {
+		if x, ok := x.Event.(*AvailabilityEvent_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *AvailabilityEvent) GetChange() *SeatChangeEvent {
+	if x != nil {
+		if x, ok := x.Event.(*AvailabilityEvent_Change); ok {
+			return x.Change
+		}
+	}
+	return nil
+}
+
+func (x *AvailabilityEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type isAvailabilityEvent_Event interface {
+	isAvailabilityEvent_Event()
+}
+
+type AvailabilityEvent_Snapshot struct {
+	// snapshot replaces everything the client knows about the seats.
+	Snapshot *AvailabilitySnapshot `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type AvailabilityEvent_Change struct {
+	Change *SeatChangeEvent `protobuf:"bytes,2,opt,name=change,proto3,oneof"`
+}
+
+func (*AvailabilityEvent_Snapshot) isAvailabilityEvent_Event() {}
+
+func (*AvailabilityEvent_Change) isAvailabilityEvent_Event() {}
+
+type SeatRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        string                 `protobuf:"bytes,1,opt,name=seatId,proto3" json:"seatId,omitempty"`
+	SectionId     string                 `protobuf:"bytes,2,opt,name=sectionId,proto3" json:"sectionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatRef) Reset() {
+	*x = SeatRef{}
+	mi := &file_proto_booking_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatRef) ProtoMessage() {}
+
+func (x *SeatRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatRef.ProtoReflect.Descriptor instead.
+func (*SeatRef) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SeatRef) GetSeatId() string {
+	if x != nil {
+		return x.SeatId
+	}
+	return ""
+}
+
+func (x *SeatRef) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+// SessionConfirm books the selected seats for user, one receipt per seat.
+type SessionConfirm struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	User           *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	From           string                 `protobuf:"bytes,2,opt,name=From,proto3" json:"From,omitempty"`
+	To             string                 `protobuf:"bytes,3,opt,name=To,proto3" json:"To,omitempty"`
+	PricePaid      float32                `protobuf:"fixed32,4,opt,name=PricePaid,proto3" json:"PricePaid,omitempty"`
+	DisocuntCoupon string                 `protobuf:"bytes,5,opt,name=disocuntCoupon,proto3" json:"disocuntCoupon,omitempty"`
+	// currency is the currency the bookings are charged in, as in
+	// PurchaseBookingRequest.
+	Currency      string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionConfirm) Reset() {
+	*x = SessionConfirm{}
+	mi := &file_proto_booking_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionConfirm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionConfirm) ProtoMessage() {}
+
+func (x *SessionConfirm) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionConfirm.ProtoReflect.Descriptor instead.
+func (*SessionConfirm) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SessionConfirm) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *SessionConfirm) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SessionConfirm) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SessionConfirm) GetPricePaid() float32 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *SessionConfirm) GetDisocuntCoupon() string {
+	if x != nil {
+		return x.DisocuntCoupon
+	}
+	return ""
+}
+
+func (x *SessionConfirm) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type BookingSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*BookingSessionRequest_Hover
+	//	*BookingSessionRequest_Select
+	//	*BookingSessionRequest_Deselect
+	//	*BookingSessionRequest_Confirm
+	Action        isBookingSessionRequest_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingSessionRequest) Reset() {
+	*x = BookingSessionRequest{}
+	mi := &file_proto_booking_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingSessionRequest) ProtoMessage() {}
+
+func (x *BookingSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingSessionRequest.ProtoReflect.Descriptor instead.
+func (*BookingSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BookingSessionRequest) GetAction() isBookingSessionRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *BookingSessionRequest) GetHover() *SeatRef {
+	if x != nil {
+		if x, ok := x.Action.(*BookingSessionRequest_Hover); ok {
+			return x.Hover
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionRequest) GetSelect() *SeatRef {
+	if x != nil {
+		if x, ok := x.Action.(*BookingSessionRequest_Select); ok {
+			return x.Select
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionRequest) GetDeselect() *SeatRef {
+	if x != nil {
+		if x, ok := x.Action.(*BookingSessionRequest_Deselect); ok {
+			return x.Deselect
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionRequest) GetConfirm() *SessionConfirm {
+	if x != nil {
+		if x, ok := x.Action.(*BookingSessionRequest_Confirm); ok {
+			return x.Confirm
+		}
+	}
+	return nil
+}
+
+type isBookingSessionRequest_Action interface {
+	isBookingSessionRequest_Action()
+}
+
+type BookingSessionRequest_Hover struct {
+	// hover asks for the state of a seat. The session is told when the
+	// hovered seat is taken by somebody else.
+	Hover *SeatRef `protobuf:"bytes,1,opt,name=hover,proto3,oneof"`
+}
+
+type BookingSessionRequest_Select struct {
+	// select holds a seat for the session.
+	Select *SeatRef `protobuf:"bytes,2,opt,name=select,proto3,oneof"`
+}
+
+type BookingSessionRequest_Deselect struct {
+	// deselect releases a held seat.
+	Deselect *SeatRef `protobuf:"bytes,3,opt,name=deselect,proto3,oneof"`
+}
+
+type BookingSessionRequest_Confirm struct {
+	Confirm *SessionConfirm `protobuf:"bytes,4,opt,name=confirm,proto3,oneof"`
+}
+
+func (*BookingSessionRequest_Hover) isBookingSessionRequest_Action() {}
+
+func (*BookingSessionRequest_Select) isBookingSessionRequest_Action() {}
+
+func (*BookingSessionRequest_Deselect) isBookingSessionRequest_Action() {}
+
+func (*BookingSessionRequest_Confirm) isBookingSessionRequest_Action() {}
+
+// SeatConflict tells that a seat was taken by somebody else, either when
+// it was selected or while it was hovered.
+type SeatConflict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          *SeatState             `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatConflict) Reset() {
+	*x = SeatConflict{}
+	mi := &file_proto_booking_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatConflict) ProtoMessage() {}
+
+func (x *SeatConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatConflict.ProtoReflect.Descriptor instead.
+func (*SeatConflict) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SeatConflict) GetSeat() *SeatState {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *SeatConflict) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SessionConfirmed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*Receipt             `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionConfirmed) Reset() {
+	*x = SessionConfirmed{}
+	mi := &file_proto_booking_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionConfirmed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionConfirmed) ProtoMessage() {}
+
+func (x *SessionConfirmed) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionConfirmed.ProtoReflect.Descriptor instead.
+func (*SessionConfirmed) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SessionConfirmed) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+// SessionError is an action that failed. The session goes on.
+type SessionError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status is the gRPC status code name, e.g. "NOT_FOUND".
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionError) Reset() {
+	*x = SessionError{}
+	mi := &file_proto_booking_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionError) ProtoMessage() {}
+
+func (x *SessionError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionError.ProtoReflect.Descriptor instead.
+func (*SessionError) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SessionError) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SessionError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BookingSessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*BookingSessionResponse_Seat
+	//	*BookingSessionResponse_Selected
+	//	*BookingSessionResponse_Deselected
+	//	*BookingSessionResponse_Conflict
+	//	*BookingSessionResponse_Confirmed
+	//	*BookingSessionResponse_Error
+	Event         isBookingSessionResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingSessionResponse) Reset() {
+	*x = BookingSessionResponse{}
+	mi := &file_proto_booking_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingSessionResponse) ProtoMessage() {}
+
+func (x *BookingSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingSessionResponse.ProtoReflect.Descriptor instead.
+func (*BookingSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BookingSessionResponse) GetEvent() isBookingSessionResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetSeat() *SeatState {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Seat); ok {
+			return x.Seat
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetSelected() *SeatState {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Selected); ok {
+			return x.Selected
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetDeselected() *SeatState {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Deselected); ok {
+			return x.Deselected
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetConflict() *SeatConflict {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Conflict); ok {
+			return x.Conflict
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetConfirmed() *SessionConfirmed {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Confirmed); ok {
+			return x.Confirmed
+		}
+	}
+	return nil
+}
+
+func (x *BookingSessionResponse) GetError() *SessionError {
+	if x != nil {
+		if x, ok := x.Event.(*BookingSessionResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBookingSessionResponse_Event interface {
+	isBookingSessionResponse_Event()
+}
+
+type BookingSessionResponse_Seat struct {
+	// seat answers hover.
+	Seat *SeatState `protobuf:"bytes,1,opt,name=seat,proto3,oneof"`
+}
+
+type BookingSessionResponse_Selected struct {
+	Selected *SeatState `protobuf:"bytes,2,opt,name=selected,proto3,oneof"`
+}
+
+type BookingSessionResponse_Deselected struct {
+	Deselected *SeatState `protobuf:"bytes,3,opt,name=deselected,proto3,oneof"`
+}
+
+type BookingSessionResponse_Conflict struct {
+	Conflict *SeatConflict `protobuf:"bytes,4,opt,name=conflict,proto3,oneof"`
+}
+
+type BookingSessionResponse_Confirmed struct {
+	Confirmed *SessionConfirmed `protobuf:"bytes,5,opt,name=confirmed,proto3,oneof"`
+}
+
+type BookingSessionResponse_Error struct {
+	Error *SessionError `protobuf:"bytes,6,opt,name=error,proto3,oneof"`
+}
+
+func (*BookingSessionResponse_Seat) isBookingSessionResponse_Event() {}
+
+func (*BookingSessionResponse_Selected) isBookingSessionResponse_Event() {}
+
+func (*BookingSessionResponse_Deselected) isBookingSessionResponse_Event() {}
+
+func (*BookingSessionResponse_Conflict) isBookingSessionResponse_Event() {}
+
+func (*BookingSessionResponse_Confirmed) isBookingSessionResponse_Event() {}
+
+func (*BookingSessionResponse_Error) isBookingSessionResponse_Event() {}
+
+type CheckStoreInvariantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repair        bool                   `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStoreInvariantsRequest) Reset() {
+	*x = CheckStoreInvariantsRequest{}
+	mi := &file_proto_booking_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStoreInvariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStoreInvariantsRequest) ProtoMessage() {}
+
+func (x *CheckStoreInvariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStoreInvariantsRequest.ProtoReflect.Descriptor instead.
+func (*CheckStoreInvariantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CheckStoreInvariantsRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+type InvariantViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	Repaired      bool                   `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvariantViolation) Reset() {
+	*x = InvariantViolation{}
+	mi := &file_proto_booking_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvariantViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvariantViolation) ProtoMessage() {}
+
+func (x *InvariantViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvariantViolation.ProtoReflect.Descriptor instead.
+func (*InvariantViolation) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *InvariantViolation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InvariantViolation) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *InvariantViolation) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *InvariantViolation) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+type CheckStoreInvariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*InvariantViolation  `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStoreInvariantsResponse) Reset() {
+	*x = CheckStoreInvariantsResponse{}
+	mi := &file_proto_booking_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStoreInvariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStoreInvariantsResponse) ProtoMessage() {}
+
+func (x *CheckStoreInvariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStoreInvariantsResponse.ProtoReflect.Descriptor instead.
+func (*CheckStoreInvariantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckStoreInvariantsResponse) GetViolations() []*InvariantViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_proto_booking_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	mi := &file_proto_booking_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_proto_booking_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_proto_booking_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_proto_booking_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_proto_booking_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SeatBooking) String() string {
+func (x *UpdateUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SeatBooking) ProtoMessage() {}
+func (*UpdateUserResponse) ProtoMessage() {}
 
-func (x *SeatBooking) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[7]
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,75 +3474,173 @@ func (x *SeatBooking) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SeatBooking.ProtoReflect.Descriptor instead.
-func (*SeatBooking) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *SeatBooking) GetSeatId() string {
+func (x *UpdateUserResponse) GetUser() *User {
 	if x != nil {
-		return x.SeatId
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-func (x *SeatBooking) GetSeatNumber() string {
+type FindUserByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserByEmailRequest) Reset() {
+	*x = FindUserByEmailRequest{}
+	mi := &file_proto_booking_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserByEmailRequest) ProtoMessage() {}
+
+func (x *FindUserByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[48]
 	if x != nil {
-		return x.SeatNumber
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SeatBooking) GetSectionId() string {
+// Deprecated: Use FindUserByEmailRequest.ProtoReflect.Descriptor instead.
+func (*FindUserByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FindUserByEmailRequest) GetEmail() string {
 	if x != nil {
-		return x.SectionId
+		return x.Email
 	}
 	return ""
 }
 
-func (x *SeatBooking) GetSectionName() string {
+type FindUserByEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserByEmailResponse) Reset() {
+	*x = FindUserByEmailResponse{}
+	mi := &file_proto_booking_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserByEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserByEmailResponse) ProtoMessage() {}
+
+func (x *FindUserByEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[49]
 	if x != nil {
-		return x.SectionName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SeatBooking) GetUser() *User {
+// Deprecated: Use FindUserByEmailResponse.ProtoReflect.Descriptor instead.
+func (*FindUserByEmailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FindUserByEmailResponse) GetUser() *User {
 	if x != nil {
 		return x.User
 	}
 	return nil
 }
 
-func (x *SeatBooking) GetSeatAvailable() bool {
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_booking_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[50]
 	if x != nil {
-		return x.SeatAvailable
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-type GetSectionBookingDetailsResponse struct {
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SeatBookings  []*SeatBooking         `protobuf:"bytes,1,rep,name=seatBookings,proto3" json:"seatBookings,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Receipts      []*Receipt             `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSectionBookingDetailsResponse) Reset() {
-	*x = GetSectionBookingDetailsResponse{}
-	mi := &file_proto_booking_proto_msgTypes[8]
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_proto_booking_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSectionBookingDetailsResponse) String() string {
+func (x *ExportUserDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSectionBookingDetailsResponse) ProtoMessage() {}
+func (*ExportUserDataResponse) ProtoMessage() {}
 
-func (x *GetSectionBookingDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[8]
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,42 +3651,47 @@ func (x *GetSectionBookingDetailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSectionBookingDetailsResponse.ProtoReflect.Descriptor instead.
-func (*GetSectionBookingDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *GetSectionBookingDetailsResponse) GetSeatBookings() []*SeatBooking {
+func (x *ExportUserDataResponse) GetUser() *User {
 	if x != nil {
-		return x.SeatBookings
+		return x.User
 	}
 	return nil
 }
 
-type UpdateSeatBookingRequest struct {
+func (x *ExportUserDataResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type EraseUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReceiptId     string                 `protobuf:"bytes,1,opt,name=ReceiptId,proto3" json:"ReceiptId,omitempty"`
-	NewSeatId     string                 `protobuf:"bytes,2,opt,name=NewSeatId,proto3" json:"NewSeatId,omitempty"`
-	NewSectionId  string                 `protobuf:"bytes,3,opt,name=NewSectionId,proto3" json:"NewSectionId,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSeatBookingRequest) Reset() {
-	*x = UpdateSeatBookingRequest{}
-	mi := &file_proto_booking_proto_msgTypes[9]
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_proto_booking_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSeatBookingRequest) String() string {
+func (x *EraseUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSeatBookingRequest) ProtoMessage() {}
+func (*EraseUserRequest) ProtoMessage() {}
 
-func (x *UpdateSeatBookingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[9]
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,54 +3702,90 @@ func (x *UpdateSeatBookingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSeatBookingRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSeatBookingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *UpdateSeatBookingRequest) GetReceiptId() string {
+func (x *EraseUserRequest) GetUserId() string {
 	if x != nil {
-		return x.ReceiptId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdateSeatBookingRequest) GetNewSeatId() string {
+type EraseUserResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AnonymizedReceipts int32                  `protobuf:"varint,1,opt,name=anonymizedReceipts,proto3" json:"anonymizedReceipts,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EraseUserResponse) Reset() {
+	*x = EraseUserResponse{}
+	mi := &file_proto_booking_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserResponse) ProtoMessage() {}
+
+func (x *EraseUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[53]
 	if x != nil {
-		return x.NewSeatId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateSeatBookingRequest) GetNewSectionId() string {
+// Deprecated: Use EraseUserResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *EraseUserResponse) GetAnonymizedReceipts() int32 {
 	if x != nil {
-		return x.NewSectionId
+		return x.AnonymizedReceipts
 	}
-	return ""
+	return 0
 }
 
-type UpdateSeatBookingResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	UpdatedReceipt *Receipt               `protobuf:"bytes,1,opt,name=UpdatedReceipt,proto3" json:"UpdatedReceipt,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Error is the JSON body of a failed REST gateway call.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is the HTTP status code.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// status is the name of the gRPC status code, e.g. NOT_FOUND.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	RequestId     string `protobuf:"bytes,4,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSeatBookingResponse) Reset() {
-	*x = UpdateSeatBookingResponse{}
-	mi := &file_proto_booking_proto_msgTypes[10]
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_proto_booking_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSeatBookingResponse) String() string {
+func (x *Error) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSeatBookingResponse) ProtoMessage() {}
+func (*Error) ProtoMessage() {}
 
-func (x *UpdateSeatBookingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[10]
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,40 +3796,62 @@ func (x *UpdateSeatBookingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSeatBookingResponse.ProtoReflect.Descriptor instead.
-func (*UpdateSeatBookingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *UpdateSeatBookingResponse) GetUpdatedReceipt() *Receipt {
+func (x *Error) GetCode() int32 {
 	if x != nil {
-		return x.UpdatedReceipt
+		return x.Code
 	}
-	return nil
+	return 0
 }
 
-type DeleteBookingRequest struct {
+func (x *Error) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReceiptId     string                 `protobuf:"bytes,1,opt,name=ReceiptId,proto3" json:"ReceiptId,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBookingRequest) Reset() {
-	*x = DeleteBookingRequest{}
-	mi := &file_proto_booking_proto_msgTypes[11]
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_proto_booking_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBookingRequest) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBookingRequest) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *DeleteBookingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[11]
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,40 +3862,49 @@ func (x *DeleteBookingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBookingRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBookingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeleteBookingRequest) GetReceiptId() string {
+func (x *LoginRequest) GetEmail() string {
 	if x != nil {
-		return x.ReceiptId
+		return x.Email
 	}
 	return ""
 }
 
-type DeleteBookingResponse struct {
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeleteStatus  bool                   `protobuf:"varint,1,opt,name=DeleteStatus,proto3" json:"DeleteStatus,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	TokenType     string                 `protobuf:"bytes,2,opt,name=tokenType,proto3" json:"tokenType,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBookingResponse) Reset() {
-	*x = DeleteBookingResponse{}
-	mi := &file_proto_booking_proto_msgTypes[12]
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_proto_booking_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBookingResponse) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBookingResponse) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *DeleteBookingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_booking_proto_msgTypes[12]
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_booking_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -749,34 +3915,56 @@ func (x *DeleteBookingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBookingResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBookingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_booking_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_booking_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *DeleteBookingResponse) GetDeleteStatus() bool {
+func (x *LoginResponse) GetAccessToken() string {
 	if x != nil {
-		return x.DeleteStatus
+		return x.AccessToken
 	}
-	return false
+	return ""
+}
+
+func (x *LoginResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
 }
 
 var File_proto_booking_proto protoreflect.FileDescriptor
 
 const file_proto_booking_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/booking.proto\x12\abooking\"n\n" +
+	"\x13proto/booking.proto\x12\abooking\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"n\n" +
 	"\x04User\x12\x16\n" +
 	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
 	"\tfirstName\x18\x02 \x01(\tR\tfirstName\x12\x1a\n" +
 	"\blastName\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x04 \x01(\tR\x05email\"\xa5\x01\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\"\x8f\x02\n" +
 	"\x16PurchaseBookingRequest\x12\x12\n" +
 	"\x04From\x18\x01 \x01(\tR\x04From\x12\x0e\n" +
 	"\x02To\x18\x02 \x01(\tR\x02To\x12!\n" +
 	"\x04user\x18\x03 \x01(\v2\r.booking.UserR\x04user\x12\x1c\n" +
 	"\tPricePaid\x18\x04 \x01(\x02R\tPricePaid\x12&\n" +
-	"\x0edisocuntCoupon\x18\x05 \x01(\tR\x0edisocuntCoupon\"\xe0\x01\n" +
+	"\x0edisocuntCoupon\x18\x05 \x01(\tR\x0edisocuntCoupon\x12&\n" +
+	"\x0eidempotencyKey\x18\x06 \x01(\tR\x0eidempotencyKey\x12$\n" +
+	"\x05price\x18\a \x01(\v2\x0e.booking.MoneyR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\"K\n" +
+	"\x05Money\x12\"\n" +
+	"\fcurrencyCode\x18\x01 \x01(\tR\fcurrencyCode\x12\x1e\n" +
+	"\n" +
+	"minorUnits\x18\x02 \x01(\x03R\n" +
+	"minorUnits\"\xf9\x03\n" +
 	"\aReceipt\x12\x1c\n" +
 	"\tReceiptId\x18\x01 \x01(\tR\tReceiptId\x12\x12\n" +
 	"\x04From\x18\x02 \x01(\tR\x04From\x12\x0e\n" +
@@ -785,15 +3973,78 @@ const file_proto_booking_proto_rawDesc = "" +
 	"\tPricePaid\x18\x05 \x01(\x02R\tPricePaid\x12\x18\n" +
 	"\aSection\x18\x06 \x01(\tR\aSection\x12\x12\n" +
 	"\x04Seat\x18\a \x01(\tR\x04Seat\x12$\n" +
-	"\rBookingStatus\x18\b \x01(\tR\rBookingStatus\"E\n" +
+	"\rBookingStatus\x18\b \x01(\tR\rBookingStatus\x12\x18\n" +
+	"\aVersion\x18\t \x01(\x03R\aVersion\x12\x1a\n" +
+	"\bbookedAt\x18\n" +
+	" \x01(\tR\bbookedAt\x12\x1c\n" +
+	"\treference\x18\v \x01(\tR\treference\x12 \n" +
+	"\vtravelClass\x18\f \x01(\tR\vtravelClass\x12\x16\n" +
+	"\x06addOns\x18\r \x03(\tR\x06addOns\x12$\n" +
+	"\x05price\x18\x0e \x01(\v2\x0e.booking.MoneyR\x05price\x12(\n" +
+	"\acharged\x18\x0f \x01(\v2\x0e.booking.MoneyR\acharged\x129\n" +
+	"\fexchangeRate\x18\x10 \x01(\v2\x15.booking.ExchangeRateR\fexchangeRate\"`\n" +
+	"\fExchangeRate\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\"1\n" +
+	"\x11GetReceiptRequest\x12\x1c\n" +
+	"\treceiptId\x18\x01 \x01(\tR\treceiptId\"@\n" +
+	"\x12GetReceiptResponse\x12*\n" +
+	"\areceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\areceipt\"L\n" +
+	"\x10BookingReference\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12\x1a\n" +
+	"\blastName\x18\x02 \x01(\tR\blastName\"S\n" +
+	"\x1cGetBookingByReferenceRequest\x123\n" +
+	"\abooking\x18\x01 \x01(\v2\x19.booking.BookingReferenceR\abooking\"\xa8\x01\n" +
+	"\x1fCancelBookingByReferenceRequest\x123\n" +
+	"\abooking\x18\x01 \x01(\v2\x19.booking.BookingReferenceR\abooking\x12(\n" +
+	"\x0fexpectedVersion\x18\x02 \x01(\x03R\x0fexpectedVersion\x12&\n" +
+	"\x0eidempotencyKey\x18\x03 \x01(\tR\x0eidempotencyKey\"\xe7\x01\n" +
+	"\x1cChangeSeatByReferenceRequest\x123\n" +
+	"\abooking\x18\x01 \x01(\v2\x19.booking.BookingReferenceR\abooking\x12\x1c\n" +
+	"\tnewSeatId\x18\x02 \x01(\tR\tnewSeatId\x12\"\n" +
+	"\fnewSectionId\x18\x03 \x01(\tR\fnewSectionId\x12(\n" +
+	"\x0fexpectedVersion\x18\x04 \x01(\x03R\x0fexpectedVersion\x12&\n" +
+	"\x0eidempotencyKey\x18\x05 \x01(\tR\x0eidempotencyKey\"E\n" +
 	"\x17PurchaseBookingResponse\x12*\n" +
-	"\areceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\areceipt\",\n" +
+	"\areceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\areceipt\"\xa5\x01\n" +
+	"\rBookingFilter\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12 \n" +
+	"\vbookedAfter\x18\x02 \x01(\tR\vbookedAfter\x12\"\n" +
+	"\fbookedBefore\x18\x03 \x01(\tR\fbookedBefore\x12\x18\n" +
+	"\atrainId\x18\x04 \x01(\tR\atrainId\x12\x1c\n" +
+	"\tsectionId\x18\x05 \x01(\tR\tsectionId\"\xf8\x01\n" +
 	"\x12ShowReceiptRequest\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\tR\x06userId\"A\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.booking.BookingFilterR\x06filter\x12(\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x12.booking.SortOrderR\x05order\x12\x1a\n" +
+	"\bpageSize\x18\x04 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x05 \x01(\tR\tpageToken\x126\n" +
+	"\breadMask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x85\x01\n" +
 	"\x13ShowReceiptResponse\x12*\n" +
-	"\areceipt\x18\x01 \x03(\v2\x10.booking.ReceiptR\areceipt\"?\n" +
+	"\areceipt\x18\x01 \x03(\v2\x10.booking.ReceiptR\areceipt\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1c\n" +
+	"\ttotalSize\x18\x03 \x01(\x05R\ttotalSize\"\xc1\x01\n" +
+	"\x13ListBookingsRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.booking.BookingFilterR\x06filter\x12(\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x12.booking.SortOrderR\x05order\x12\x1a\n" +
+	"\bpageSize\x18\x04 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x05 \x01(\tR\tpageToken\"\x88\x01\n" +
+	"\x14ListBookingsResponse\x12,\n" +
+	"\breceipts\x18\x01 \x03(\v2\x10.booking.ReceiptR\breceipts\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1c\n" +
+	"\ttotalSize\x18\x03 \x01(\x05R\ttotalSize\"\xdb\x02\n" +
 	"\x1fGetSectionBookingDetailsRequest\x12\x1c\n" +
-	"\tsectionId\x18\x01 \x01(\tR\tsectionId\"\xce\x01\n" +
+	"\tsectionId\x18\x01 \x01(\tR\tsectionId\x12%\n" +
+	"\x04view\x18\x02 \x01(\x0e2\x11.booking.SeatViewR\x04view\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\x12=\n" +
+	"\favailability\x18\x04 \x01(\x0e2\x19.booking.SeatAvailabilityR\favailability\x12(\n" +
+	"\x05order\x18\x05 \x01(\x0e2\x12.booking.SortOrderR\x05order\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\a \x01(\tR\tpageToken\x126\n" +
+	"\breadMask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xce\x01\n" +
 	"\vSeatBooking\x12\x16\n" +
 	"\x06seatId\x18\x01 \x01(\tR\x06seatId\x12\x1e\n" +
 	"\n" +
@@ -802,25 +4053,209 @@ const file_proto_booking_proto_rawDesc = "" +
 	"\tSectionId\x18\x03 \x01(\tR\tSectionId\x12 \n" +
 	"\vSectionName\x18\x04 \x01(\tR\vSectionName\x12!\n" +
 	"\x04user\x18\x05 \x01(\v2\r.booking.UserR\x04user\x12$\n" +
-	"\rSeatAvailable\x18\x06 \x01(\bR\rSeatAvailable\"\\\n" +
+	"\rSeatAvailable\x18\x06 \x01(\bR\rSeatAvailable\"\xa0\x01\n" +
 	" GetSectionBookingDetailsResponse\x128\n" +
-	"\fseatBookings\x18\x01 \x03(\v2\x14.booking.SeatBookingR\fseatBookings\"z\n" +
+	"\fseatBookings\x18\x01 \x03(\v2\x14.booking.SeatBookingR\fseatBookings\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1c\n" +
+	"\ttotalSize\x18\x03 \x01(\x05R\ttotalSize\"\xcc\x01\n" +
 	"\x18UpdateSeatBookingRequest\x12\x1c\n" +
 	"\tReceiptId\x18\x01 \x01(\tR\tReceiptId\x12\x1c\n" +
 	"\tNewSeatId\x18\x02 \x01(\tR\tNewSeatId\x12\"\n" +
-	"\fNewSectionId\x18\x03 \x01(\tR\fNewSectionId\"U\n" +
+	"\fNewSectionId\x18\x03 \x01(\tR\fNewSectionId\x12&\n" +
+	"\x0eidempotencyKey\x18\x04 \x01(\tR\x0eidempotencyKey\x12(\n" +
+	"\x0fExpectedVersion\x18\x05 \x01(\x03R\x0fExpectedVersion\"U\n" +
 	"\x19UpdateSeatBookingResponse\x128\n" +
-	"\x0eUpdatedReceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\x0eUpdatedReceipt\"4\n" +
+	"\x0eUpdatedReceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\x0eUpdatedReceipt\"\xbf\x01\n" +
+	"\rBookingUpdate\x12\x1c\n" +
+	"\tfirstName\x18\x01 \x01(\tR\tfirstName\x12\x1a\n" +
+	"\blastName\x18\x02 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12$\n" +
+	"\x04seat\x18\x04 \x01(\v2\x10.booking.SeatRefR\x04seat\x12 \n" +
+	"\vtravelClass\x18\x05 \x01(\tR\vtravelClass\x12\x16\n" +
+	"\x06addOns\x18\x06 \x03(\tR\x06addOns\"\xf4\x01\n" +
+	"\x14UpdateBookingRequest\x12\x1c\n" +
+	"\treceiptId\x18\x01 \x01(\tR\treceiptId\x120\n" +
+	"\abooking\x18\x02 \x01(\v2\x16.booking.BookingUpdateR\abooking\x12:\n" +
+	"\n" +
+	"updateMask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12(\n" +
+	"\x0fexpectedVersion\x18\x04 \x01(\x03R\x0fexpectedVersion\x12&\n" +
+	"\x0eidempotencyKey\x18\x05 \x01(\tR\x0eidempotencyKey\"C\n" +
+	"\x15UpdateBookingResponse\x12*\n" +
+	"\areceipt\x18\x01 \x01(\v2\x10.booking.ReceiptR\areceipt\"\x86\x01\n" +
 	"\x14DeleteBookingRequest\x12\x1c\n" +
-	"\tReceiptId\x18\x01 \x01(\tR\tReceiptId\";\n" +
+	"\tReceiptId\x18\x01 \x01(\tR\tReceiptId\x12&\n" +
+	"\x0eidempotencyKey\x18\x02 \x01(\tR\x0eidempotencyKey\x12(\n" +
+	"\x0fExpectedVersion\x18\x03 \x01(\x03R\x0fExpectedVersion\"\x81\x01\n" +
 	"\x15DeleteBookingResponse\x12\"\n" +
-	"\fDeleteStatus\x18\x01 \x01(\bR\fDeleteStatus2\xcd\x03\n" +
-	"\x0eBookingService\x12T\n" +
-	"\x0fPurchaseBooking\x12\x1f.booking.PurchaseBookingRequest\x1a .booking.PurchaseBookingResponse\x12H\n" +
-	"\vShowReceipt\x12\x1b.booking.ShowReceiptRequest\x1a\x1c.booking.ShowReceiptResponse\x12o\n" +
-	"\x18GetSectionBookingDetails\x12(.booking.GetSectionBookingDetailsRequest\x1a).booking.GetSectionBookingDetailsResponse\x12Z\n" +
-	"\x11UpdateSeatBooking\x12!.booking.UpdateSeatBookingRequest\x1a\".booking.UpdateSeatBookingResponse\x12N\n" +
-	"\rDeleteBooking\x12\x1d.booking.DeleteBookingRequest\x1a\x1e.booking.DeleteBookingResponseB\x16Z\x14grpc-project/bookingb\x06proto3"
+	"\fDeleteStatus\x18\x01 \x01(\bR\fDeleteStatus\x12\x18\n" +
+	"\aVersion\x18\x02 \x01(\x03R\aVersion\x12*\n" +
+	"\areceipt\x18\x03 \x01(\v2\x10.booking.ReceiptR\areceipt\"t\n" +
+	"\x18WatchAvailabilityRequest\x12\x18\n" +
+	"\atrainId\x18\x01 \x01(\tR\atrainId\x12\x1c\n" +
+	"\tsectionId\x18\x02 \x01(\tR\tsectionId\x12 \n" +
+	"\vresumeToken\x18\x03 \x01(\tR\vresumeToken\"\xbd\x01\n" +
+	"\tSeatState\x12\x16\n" +
+	"\x06seatId\x18\x01 \x01(\tR\x06seatId\x12\x1e\n" +
+	"\n" +
+	"seatNumber\x18\x02 \x01(\tR\n" +
+	"seatNumber\x12\x1c\n" +
+	"\tsectionId\x18\x03 \x01(\tR\tsectionId\x12 \n" +
+	"\vsectionName\x18\x04 \x01(\tR\vsectionName\x12$\n" +
+	"\rseatAvailable\x18\x05 \x01(\bR\rseatAvailable\x12\x12\n" +
+	"\x04held\x18\x06 \x01(\bR\x04held\"\x9e\x01\n" +
+	"\x0fSeatChangeEvent\x12+\n" +
+	"\x06change\x18\x01 \x01(\x0e2\x13.booking.SeatChangeR\x06change\x12&\n" +
+	"\x04seat\x18\x02 \x01(\v2\x12.booking.SeatStateR\x04seat\x126\n" +
+	"\fpreviousSeat\x18\x03 \x01(\v2\x12.booking.SeatStateR\fpreviousSeat\"Z\n" +
+	"\x14AvailabilitySnapshot\x12\x18\n" +
+	"\atrainId\x18\x01 \x01(\tR\atrainId\x12(\n" +
+	"\x05seats\x18\x02 \x03(\v2\x12.booking.SeatStateR\x05seats\"\xaf\x01\n" +
+	"\x11AvailabilityEvent\x12;\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1d.booking.AvailabilitySnapshotH\x00R\bsnapshot\x122\n" +
+	"\x06change\x18\x02 \x01(\v2\x18.booking.SeatChangeEventH\x00R\x06change\x12 \n" +
+	"\vresumeToken\x18\x03 \x01(\tR\vresumeTokenB\a\n" +
+	"\x05event\"?\n" +
+	"\aSeatRef\x12\x16\n" +
+	"\x06seatId\x18\x01 \x01(\tR\x06seatId\x12\x1c\n" +
+	"\tsectionId\x18\x02 \x01(\tR\tsectionId\"\xb9\x01\n" +
+	"\x0eSessionConfirm\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\x12\x12\n" +
+	"\x04From\x18\x02 \x01(\tR\x04From\x12\x0e\n" +
+	"\x02To\x18\x03 \x01(\tR\x02To\x12\x1c\n" +
+	"\tPricePaid\x18\x04 \x01(\x02R\tPricePaid\x12&\n" +
+	"\x0edisocuntCoupon\x18\x05 \x01(\tR\x0edisocuntCoupon\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"\xdc\x01\n" +
+	"\x15BookingSessionRequest\x12(\n" +
+	"\x05hover\x18\x01 \x01(\v2\x10.booking.SeatRefH\x00R\x05hover\x12*\n" +
+	"\x06select\x18\x02 \x01(\v2\x10.booking.SeatRefH\x00R\x06select\x12.\n" +
+	"\bdeselect\x18\x03 \x01(\v2\x10.booking.SeatRefH\x00R\bdeselect\x123\n" +
+	"\aconfirm\x18\x04 \x01(\v2\x17.booking.SessionConfirmH\x00R\aconfirmB\b\n" +
+	"\x06action\"N\n" +
+	"\fSeatConflict\x12&\n" +
+	"\x04seat\x18\x01 \x01(\v2\x12.booking.SeatStateR\x04seat\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"@\n" +
+	"\x10SessionConfirmed\x12,\n" +
+	"\breceipts\x18\x01 \x03(\v2\x10.booking.ReceiptR\breceipts\"@\n" +
+	"\fSessionError\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd2\x02\n" +
+	"\x16BookingSessionResponse\x12(\n" +
+	"\x04seat\x18\x01 \x01(\v2\x12.booking.SeatStateH\x00R\x04seat\x120\n" +
+	"\bselected\x18\x02 \x01(\v2\x12.booking.SeatStateH\x00R\bselected\x124\n" +
+	"\n" +
+	"deselected\x18\x03 \x01(\v2\x12.booking.SeatStateH\x00R\n" +
+	"deselected\x123\n" +
+	"\bconflict\x18\x04 \x01(\v2\x15.booking.SeatConflictH\x00R\bconflict\x129\n" +
+	"\tconfirmed\x18\x05 \x01(\v2\x19.booking.SessionConfirmedH\x00R\tconfirmed\x12-\n" +
+	"\x05error\x18\x06 \x01(\v2\x15.booking.SessionErrorH\x00R\x05errorB\a\n" +
+	"\x05event\"5\n" +
+	"\x1bCheckStoreInvariantsRequest\x12\x16\n" +
+	"\x06repair\x18\x01 \x01(\bR\x06repair\"v\n" +
+	"\x12InvariantViolation\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\x12\x1a\n" +
+	"\brepaired\x18\x04 \x01(\bR\brepaired\"[\n" +
+	"\x1cCheckStoreInvariantsResponse\x12;\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2\x1b.booking.InvariantViolationR\n" +
+	"violations\"R\n" +
+	"\x11CreateUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"7\n" +
+	"\x12CreateUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\"(\n" +
+	"\x0eGetUserRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"4\n" +
+	"\x0fGetUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\"6\n" +
+	"\x11UpdateUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\"7\n" +
+	"\x12UpdateUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\".\n" +
+	"\x16FindUserByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"<\n" +
+	"\x17FindUserByEmailResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\"/\n" +
+	"\x15ExportUserDataRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"i\n" +
+	"\x16ExportUserDataResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.booking.UserR\x04user\x12,\n" +
+	"\breceipts\x18\x02 \x03(\v2\x10.booking.ReceiptR\breceipts\"*\n" +
+	"\x10EraseUserRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"C\n" +
+	"\x11EraseUserResponse\x12.\n" +
+	"\x12anonymizedReceipts\x18\x01 \x01(\x05R\x12anonymizedReceipts\"k\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1c\n" +
+	"\trequestId\x18\x04 \x01(\tR\trequestId\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"m\n" +
+	"\rLoginResponse\x12 \n" +
+	"\vaccessToken\x18\x01 \x01(\tR\vaccessToken\x12\x1c\n" +
+	"\ttokenType\x18\x02 \x01(\tR\ttokenType\x12\x1c\n" +
+	"\texpiresAt\x18\x03 \x01(\x03R\texpiresAt*X\n" +
+	"\tSortOrder\x12\x16\n" +
+	"\x12SORT_ORDER_DEFAULT\x10\x00\x12\x18\n" +
+	"\x14SORT_ORDER_ASCENDING\x10\x01\x12\x19\n" +
+	"\x15SORT_ORDER_DESCENDING\x10\x02*c\n" +
+	"\bSeatView\x12\x15\n" +
+	"\x11SEAT_VIEW_DEFAULT\x10\x00\x12\x12\n" +
+	"\x0eSEAT_VIEW_FULL\x10\x01\x12\x14\n" +
+	"\x10SEAT_VIEW_MASKED\x10\x02\x12\x16\n" +
+	"\x12SEAT_VIEW_OCCUPIED\x10\x03*n\n" +
+	"\x10SeatAvailability\x12\x19\n" +
+	"\x15SEAT_AVAILABILITY_ANY\x10\x00\x12\x1f\n" +
+	"\x1bSEAT_AVAILABILITY_AVAILABLE\x10\x01\x12\x1e\n" +
+	"\x1aSEAT_AVAILABILITY_OCCUPIED\x10\x02*\x88\x01\n" +
+	"\n" +
+	"SeatChange\x12\x1b\n" +
+	"\x17SEAT_CHANGE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SEAT_CHANGE_BOOKED\x10\x01\x12\x18\n" +
+	"\x14SEAT_CHANGE_RELEASED\x10\x02\x12\x14\n" +
+	"\x10SEAT_CHANGE_HELD\x10\x03\x12\x15\n" +
+	"\x11SEAT_CHANGE_MOVED\x10\x042\xce\f\n" +
+	"\x0eBookingService\x12m\n" +
+	"\x0fPurchaseBooking\x12\x1f.booking.PurchaseBookingRequest\x1a .booking.PurchaseBookingResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/bookings\x12g\n" +
+	"\n" +
+	"GetReceipt\x12\x1a.booking.GetReceiptRequest\x1a\x1b.booking.GetReceiptResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/receipts/{receiptId}\x12\x80\x01\n" +
+	"\x15GetBookingByReference\x12%.booking.GetBookingByReferenceRequest\x1a\x1b.booking.GetReceiptResponse\"#\x92A\x02b\x00\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/bookings:lookup\x12\x94\x01\n" +
+	"\x18CancelBookingByReference\x12(.booking.CancelBookingByReferenceRequest\x1a\x1e.booking.DeleteBookingResponse\".\x92A\x02b\x00\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/bookings:cancelByReference\x12\x96\x01\n" +
+	"\x15ChangeSeatByReference\x12%.booking.ChangeSeatByReferenceRequest\x1a\".booking.UpdateSeatBookingResponse\"2\x92A\x02b\x00\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/bookings:changeSeatByReference\x12m\n" +
+	"\vShowReceipt\x12\x1b.booking.ShowReceiptRequest\x1a\x1c.booking.ShowReceiptResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/users/{userId}/receipts\x12\x97\x01\n" +
+	"\x18GetSectionBookingDetails\x12(.booking.GetSectionBookingDetailsRequest\x1a).booking.GetSectionBookingDetailsResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/sections/{sectionId}/seats\x12a\n" +
+	"\fListBookings\x12\x1c.booking.ListBookingsRequest\x1a\x1d.booking.ListBookingsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/bookings\x12\x8a\x01\n" +
+	"\x11UpdateSeatBooking\x12!.booking.UpdateSeatBookingRequest\x1a\".booking.UpdateSeatBookingResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/bookings/{ReceiptId}:changeSeat\x12y\n" +
+	"\rUpdateBooking\x12\x1d.booking.UpdateBookingRequest\x1a\x1e.booking.UpdateBookingResponse\")\x82\xd3\xe4\x93\x02#:\abooking2\x18/v1/bookings/{receiptId}\x12p\n" +
+	"\rDeleteBooking\x12\x1d.booking.DeleteBookingRequest\x1a\x1e.booking.DeleteBookingResponse\" \x82\xd3\xe4\x93\x02\x1a*\x18/v1/bookings/{ReceiptId}\x12t\n" +
+	"\x11WatchAvailability\x12!.booking.WatchAvailabilityRequest\x1a\x1a.booking.AvailabilityEvent\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/availability:watch0\x01\x12U\n" +
+	"\x0eBookingSession\x12\x1e.booking.BookingSessionRequest\x1a\x1f.booking.BookingSessionResponse(\x010\x012\xc6\x03\n" +
+	"\vUserService\x12E\n" +
+	"\n" +
+	"CreateUser\x12\x1a.booking.CreateUserRequest\x1a\x1b.booking.CreateUserResponse\x12<\n" +
+	"\aGetUser\x12\x17.booking.GetUserRequest\x1a\x18.booking.GetUserResponse\x12E\n" +
+	"\n" +
+	"UpdateUser\x12\x1a.booking.UpdateUserRequest\x1a\x1b.booking.UpdateUserResponse\x12T\n" +
+	"\x0fFindUserByEmail\x12\x1f.booking.FindUserByEmailRequest\x1a .booking.FindUserByEmailResponse\x12Q\n" +
+	"\x0eExportUserData\x12\x1e.booking.ExportUserDataRequest\x1a\x1f.booking.ExportUserDataResponse\x12B\n" +
+	"\tEraseUser\x12\x19.booking.EraseUserRequest\x1a\x1a.booking.EraseUserResponse2e\n" +
+	"\vAuthService\x12V\n" +
+	"\x05Login\x12\x15.booking.LoginRequest\x1a\x16.booking.LoginResponse\"\x1e\x92A\x02b\x00\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/auth/login2s\n" +
+	"\fAdminService\x12c\n" +
+	"\x14CheckStoreInvariants\x12$.booking.CheckStoreInvariantsRequest\x1a%.booking.CheckStoreInvariantsResponseB\xd1\x01\x92A\xb7\x01\x12\x18\n" +
+	"\x11Train Booking API2\x031.0R1\n" +
+	"\adefault\x12&\n" +
+	"\x10The call failed.\x12\x12\n" +
+	"\x10\x1a\x0e.booking.ErrorZZ\n" +
+	"X\n" +
+	"\x06bearer\x12N\b\x02\x129An access token from /v1/auth/login, as \"Bearer <token>\".\x1a\rAuthorization \x02b\f\n" +
+	"\n" +
+	"\n" +
+	"\x06bearer\x12\x00Z\x14grpc-project/bookingb\x06proto3"
 
 var (
 	file_proto_booking_proto_rawDescOnce sync.Once
@@ -834,45 +4269,178 @@ func file_proto_booking_proto_rawDescGZIP() []byte {
 	return file_proto_booking_proto_rawDescData
 }
 
-var file_proto_booking_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_booking_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_booking_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_proto_booking_proto_goTypes = []any{
-	(*User)(nil),                             // 0: booking.User
-	(*PurchaseBookingRequest)(nil),           // 1: booking.PurchaseBookingRequest
-	(*Receipt)(nil),                          // 2: booking.Receipt
-	(*PurchaseBookingResponse)(nil),          // 3: booking.PurchaseBookingResponse
-	(*ShowReceiptRequest)(nil),               // 4: booking.ShowReceiptRequest
-	(*ShowReceiptResponse)(nil),              // 5: booking.ShowReceiptResponse
-	(*GetSectionBookingDetailsRequest)(nil),  // 6: booking.GetSectionBookingDetailsRequest
-	(*SeatBooking)(nil),                      // 7: booking.SeatBooking
-	(*GetSectionBookingDetailsResponse)(nil), // 8: booking.GetSectionBookingDetailsResponse
-	(*UpdateSeatBookingRequest)(nil),         // 9: booking.UpdateSeatBookingRequest
-	(*UpdateSeatBookingResponse)(nil),        // 10: booking.UpdateSeatBookingResponse
-	(*DeleteBookingRequest)(nil),             // 11: booking.DeleteBookingRequest
-	(*DeleteBookingResponse)(nil),            // 12: booking.DeleteBookingResponse
+	(SortOrder)(0),                           // 0: booking.SortOrder
+	(SeatView)(0),                            // 1: booking.SeatView
+	(SeatAvailability)(0),                    // 2: booking.SeatAvailability
+	(SeatChange)(0),                          // 3: booking.SeatChange
+	(*User)(nil),                             // 4: booking.User
+	(*PurchaseBookingRequest)(nil),           // 5: booking.PurchaseBookingRequest
+	(*Money)(nil),                            // 6: booking.Money
+	(*Receipt)(nil),                          // 7: booking.Receipt
+	(*ExchangeRate)(nil),                     // 8: booking.ExchangeRate
+	(*GetReceiptRequest)(nil),                // 9: booking.GetReceiptRequest
+	(*GetReceiptResponse)(nil),               // 10: booking.GetReceiptResponse
+	(*BookingReference)(nil),                 // 11: booking.BookingReference
+	(*GetBookingByReferenceRequest)(nil),     // 12: booking.GetBookingByReferenceRequest
+	(*CancelBookingByReferenceRequest)(nil),  // 13: booking.CancelBookingByReferenceRequest
+	(*ChangeSeatByReferenceRequest)(nil),     // 14: booking.ChangeSeatByReferenceRequest
+	(*PurchaseBookingResponse)(nil),          // 15: booking.PurchaseBookingResponse
+	(*BookingFilter)(nil),                    // 16: booking.BookingFilter
+	(*ShowReceiptRequest)(nil),               // 17: booking.ShowReceiptRequest
+	(*ShowReceiptResponse)(nil),              // 18: booking.ShowReceiptResponse
+	(*ListBookingsRequest)(nil),              // 19: booking.ListBookingsRequest
+	(*ListBookingsResponse)(nil),             // 20: booking.ListBookingsResponse
+	(*GetSectionBookingDetailsRequest)(nil),  // 21: booking.GetSectionBookingDetailsRequest
+	(*SeatBooking)(nil),                      // 22: booking.SeatBooking
+	(*GetSectionBookingDetailsResponse)(nil), // 23: booking.GetSectionBookingDetailsResponse
+	(*UpdateSeatBookingRequest)(nil),         // 24: booking.UpdateSeatBookingRequest
+	(*UpdateSeatBookingResponse)(nil),        // 25: booking.UpdateSeatBookingResponse
+	(*BookingUpdate)(nil),                    // 26: booking.BookingUpdate
+	(*UpdateBookingRequest)(nil),             // 27: booking.UpdateBookingRequest
+	(*UpdateBookingResponse)(nil),            // 28: booking.UpdateBookingResponse
+	(*DeleteBookingRequest)(nil),             // 29: booking.DeleteBookingRequest
+	(*DeleteBookingResponse)(nil),            // 30: booking.DeleteBookingResponse
+	(*WatchAvailabilityRequest)(nil),         // 31: booking.WatchAvailabilityRequest
+	(*SeatState)(nil),                        // 32: booking.SeatState
+	(*SeatChangeEvent)(nil),                  // 33: booking.SeatChangeEvent
+	(*AvailabilitySnapshot)(nil),             // 34: booking.AvailabilitySnapshot
+	(*AvailabilityEvent)(nil),                // 35: booking.AvailabilityEvent
+	(*SeatRef)(nil),                          // 36: booking.SeatRef
+	(*SessionConfirm)(nil),                   // 37: booking.SessionConfirm
+	(*BookingSessionRequest)(nil),            // 38: booking.BookingSessionRequest
+	(*SeatConflict)(nil),                     // 39: booking.SeatConflict
+	(*SessionConfirmed)(nil),                 // 40: booking.SessionConfirmed
+	(*SessionError)(nil),                     // 41: booking.SessionError
+	(*BookingSessionResponse)(nil),           // 42: booking.BookingSessionResponse
+	(*CheckStoreInvariantsRequest)(nil),      // 43: booking.CheckStoreInvariantsRequest
+	(*InvariantViolation)(nil),               // 44: booking.InvariantViolation
+	(*CheckStoreInvariantsResponse)(nil),     // 45: booking.CheckStoreInvariantsResponse
+	(*CreateUserRequest)(nil),                // 46: booking.CreateUserRequest
+	(*CreateUserResponse)(nil),               // 47: booking.CreateUserResponse
+	(*GetUserRequest)(nil),                   // 48: booking.GetUserRequest
+	(*GetUserResponse)(nil),                  // 49: booking.GetUserResponse
+	(*UpdateUserRequest)(nil),                // 50: booking.UpdateUserRequest
+	(*UpdateUserResponse)(nil),               // 51: booking.UpdateUserResponse
+	(*FindUserByEmailRequest)(nil),           // 52: booking.FindUserByEmailRequest
+	(*FindUserByEmailResponse)(nil),          // 53: booking.FindUserByEmailResponse
+	(*ExportUserDataRequest)(nil),            // 54: booking.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),           // 55: booking.ExportUserDataResponse
+	(*EraseUserRequest)(nil),                 // 56: booking.EraseUserRequest
+	(*EraseUserResponse)(nil),                // 57: booking.EraseUserResponse
+	(*Error)(nil),                            // 58: booking.Error
+	(*LoginRequest)(nil),                     // 59: booking.LoginRequest
+	(*LoginResponse)(nil),                    // 60: booking.LoginResponse
+	(*fieldmaskpb.FieldMask)(nil),            // 61: google.protobuf.FieldMask
 }
 var file_proto_booking_proto_depIdxs = []int32{
-	0,  // 0: booking.PurchaseBookingRequest.user:type_name -> booking.User
-	0,  // 1: booking.Receipt.user:type_name -> booking.User
-	2,  // 2: booking.PurchaseBookingResponse.receipt:type_name -> booking.Receipt
-	2,  // 3: booking.ShowReceiptResponse.receipt:type_name -> booking.Receipt
-	0,  // 4: booking.SeatBooking.user:type_name -> booking.User
-	7,  // 5: booking.GetSectionBookingDetailsResponse.seatBookings:type_name -> booking.SeatBooking
-	2,  // 6: booking.UpdateSeatBookingResponse.UpdatedReceipt:type_name -> booking.Receipt
-	1,  // 7: booking.BookingService.PurchaseBooking:input_type -> booking.PurchaseBookingRequest
-	4,  // 8: booking.BookingService.ShowReceipt:input_type -> booking.ShowReceiptRequest
-	6,  // 9: booking.BookingService.GetSectionBookingDetails:input_type -> booking.GetSectionBookingDetailsRequest
-	9,  // 10: booking.BookingService.UpdateSeatBooking:input_type -> booking.UpdateSeatBookingRequest
-	11, // 11: booking.BookingService.DeleteBooking:input_type -> booking.DeleteBookingRequest
-	3,  // 12: booking.BookingService.PurchaseBooking:output_type -> booking.PurchaseBookingResponse
-	5,  // 13: booking.BookingService.ShowReceipt:output_type -> booking.ShowReceiptResponse
-	8,  // 14: booking.BookingService.GetSectionBookingDetails:output_type -> booking.GetSectionBookingDetailsResponse
-	10, // 15: booking.BookingService.UpdateSeatBooking:output_type -> booking.UpdateSeatBookingResponse
-	12, // 16: booking.BookingService.DeleteBooking:output_type -> booking.DeleteBookingResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	4,  // 0: booking.PurchaseBookingRequest.user:type_name -> booking.User
+	6,  // 1: booking.PurchaseBookingRequest.price:type_name -> booking.Money
+	4,  // 2: booking.Receipt.user:type_name -> booking.User
+	6,  // 3: booking.Receipt.price:type_name -> booking.Money
+	6,  // 4: booking.Receipt.charged:type_name -> booking.Money
+	8,  // 5: booking.Receipt.exchangeRate:type_name -> booking.ExchangeRate
+	7,  // 6: booking.GetReceiptResponse.receipt:type_name -> booking.Receipt
+	11, // 7: booking.GetBookingByReferenceRequest.booking:type_name -> booking.BookingReference
+	11, // 8: booking.CancelBookingByReferenceRequest.booking:type_name -> booking.BookingReference
+	11, // 9: booking.ChangeSeatByReferenceRequest.booking:type_name -> booking.BookingReference
+	7,  // 10: booking.PurchaseBookingResponse.receipt:type_name -> booking.Receipt
+	16, // 11: booking.ShowReceiptRequest.filter:type_name -> booking.BookingFilter
+	0,  // 12: booking.ShowReceiptRequest.order:type_name -> booking.SortOrder
+	61, // 13: booking.ShowReceiptRequest.readMask:type_name -> google.protobuf.FieldMask
+	7,  // 14: booking.ShowReceiptResponse.receipt:type_name -> booking.Receipt
+	16, // 15: booking.ListBookingsRequest.filter:type_name -> booking.BookingFilter
+	0,  // 16: booking.ListBookingsRequest.order:type_name -> booking.SortOrder
+	7,  // 17: booking.ListBookingsResponse.receipts:type_name -> booking.Receipt
+	1,  // 18: booking.GetSectionBookingDetailsRequest.view:type_name -> booking.SeatView
+	2,  // 19: booking.GetSectionBookingDetailsRequest.availability:type_name -> booking.SeatAvailability
+	0,  // 20: booking.GetSectionBookingDetailsRequest.order:type_name -> booking.SortOrder
+	61, // 21: booking.GetSectionBookingDetailsRequest.readMask:type_name -> google.protobuf.FieldMask
+	4,  // 22: booking.SeatBooking.user:type_name -> booking.User
+	22, // 23: booking.GetSectionBookingDetailsResponse.seatBookings:type_name -> booking.SeatBooking
+	7,  // 24: booking.UpdateSeatBookingResponse.UpdatedReceipt:type_name -> booking.Receipt
+	36, // 25: booking.BookingUpdate.seat:type_name -> booking.SeatRef
+	26, // 26: booking.UpdateBookingRequest.booking:type_name -> booking.BookingUpdate
+	61, // 27: booking.UpdateBookingRequest.updateMask:type_name -> google.protobuf.FieldMask
+	7,  // 28: booking.UpdateBookingResponse.receipt:type_name -> booking.Receipt
+	7,  // 29: booking.DeleteBookingResponse.receipt:type_name -> booking.Receipt
+	3,  // 30: booking.SeatChangeEvent.change:type_name -> booking.SeatChange
+	32, // 31: booking.SeatChangeEvent.seat:type_name -> booking.SeatState
+	32, // 32: booking.SeatChangeEvent.previousSeat:type_name -> booking.SeatState
+	32, // 33: booking.AvailabilitySnapshot.seats:type_name -> booking.SeatState
+	34, // 34: booking.AvailabilityEvent.snapshot:type_name -> booking.AvailabilitySnapshot
+	33, // 35: booking.AvailabilityEvent.change:type_name -> booking.SeatChangeEvent
+	4,  // 36: booking.SessionConfirm.user:type_name -> booking.User
+	36, // 37: booking.BookingSessionRequest.hover:type_name -> booking.SeatRef
+	36, // 38: booking.BookingSessionRequest.select:type_name -> booking.SeatRef
+	36, // 39: booking.BookingSessionRequest.deselect:type_name -> booking.SeatRef
+	37, // 40: booking.BookingSessionRequest.confirm:type_name -> booking.SessionConfirm
+	32, // 41: booking.SeatConflict.seat:type_name -> booking.SeatState
+	7,  // 42: booking.SessionConfirmed.receipts:type_name -> booking.Receipt
+	32, // 43: booking.BookingSessionResponse.seat:type_name -> booking.SeatState
+	32, // 44: booking.BookingSessionResponse.selected:type_name -> booking.SeatState
+	32, // 45: booking.BookingSessionResponse.deselected:type_name -> booking.SeatState
+	39, // 46: booking.BookingSessionResponse.conflict:type_name -> booking.SeatConflict
+	40, // 47: booking.BookingSessionResponse.confirmed:type_name -> booking.SessionConfirmed
+	41, // 48: booking.BookingSessionResponse.error:type_name -> booking.SessionError
+	44, // 49: booking.CheckStoreInvariantsResponse.violations:type_name -> booking.InvariantViolation
+	4,  // 50: booking.CreateUserRequest.user:type_name -> booking.User
+	4,  // 51: booking.CreateUserResponse.user:type_name -> booking.User
+	4,  // 52: booking.GetUserResponse.user:type_name -> booking.User
+	4,  // 53: booking.UpdateUserRequest.user:type_name -> booking.User
+	4,  // 54: booking.UpdateUserResponse.user:type_name -> booking.User
+	4,  // 55: booking.FindUserByEmailResponse.user:type_name -> booking.User
+	4,  // 56: booking.ExportUserDataResponse.user:type_name -> booking.User
+	7,  // 57: booking.ExportUserDataResponse.receipts:type_name -> booking.Receipt
+	5,  // 58: booking.BookingService.PurchaseBooking:input_type -> booking.PurchaseBookingRequest
+	9,  // 59: booking.BookingService.GetReceipt:input_type -> booking.GetReceiptRequest
+	12, // 60: booking.BookingService.GetBookingByReference:input_type -> booking.GetBookingByReferenceRequest
+	13, // 61: booking.BookingService.CancelBookingByReference:input_type -> booking.CancelBookingByReferenceRequest
+	14, // 62: booking.BookingService.ChangeSeatByReference:input_type -> booking.ChangeSeatByReferenceRequest
+	17, // 63: booking.BookingService.ShowReceipt:input_type -> booking.ShowReceiptRequest
+	21, // 64: booking.BookingService.GetSectionBookingDetails:input_type -> booking.GetSectionBookingDetailsRequest
+	19, // 65: booking.BookingService.ListBookings:input_type -> booking.ListBookingsRequest
+	24, // 66: booking.BookingService.UpdateSeatBooking:input_type -> booking.UpdateSeatBookingRequest
+	27, // 67: booking.BookingService.UpdateBooking:input_type -> booking.UpdateBookingRequest
+	29, // 68: booking.BookingService.DeleteBooking:input_type -> booking.DeleteBookingRequest
+	31, // 69: booking.BookingService.WatchAvailability:input_type -> booking.WatchAvailabilityRequest
+	38, // 70: booking.BookingService.BookingSession:input_type -> booking.BookingSessionRequest
+	46, // 71: booking.UserService.CreateUser:input_type -> booking.CreateUserRequest
+	48, // 72: booking.UserService.GetUser:input_type -> booking.GetUserRequest
+	50, // 73: booking.UserService.UpdateUser:input_type -> booking.UpdateUserRequest
+	52, // 74: booking.UserService.FindUserByEmail:input_type -> booking.FindUserByEmailRequest
+	54, // 75: booking.UserService.ExportUserData:input_type -> booking.ExportUserDataRequest
+	56, // 76: booking.UserService.EraseUser:input_type -> booking.EraseUserRequest
+	59, // 77: booking.AuthService.Login:input_type -> booking.LoginRequest
+	43, // 78: booking.AdminService.CheckStoreInvariants:input_type -> booking.CheckStoreInvariantsRequest
+	15, // 79: booking.BookingService.PurchaseBooking:output_type -> booking.PurchaseBookingResponse
+	10, // 80: booking.BookingService.GetReceipt:output_type -> booking.GetReceiptResponse
+	10, // 81: booking.BookingService.GetBookingByReference:output_type -> booking.GetReceiptResponse
+	30, // 82: booking.BookingService.CancelBookingByReference:output_type -> booking.DeleteBookingResponse
+	25, // 83: booking.BookingService.ChangeSeatByReference:output_type -> booking.UpdateSeatBookingResponse
+	18, // 84: booking.BookingService.ShowReceipt:output_type -> booking.ShowReceiptResponse
+	23, // 85: booking.BookingService.GetSectionBookingDetails:output_type -> booking.GetSectionBookingDetailsResponse
+	20, // 86: booking.BookingService.ListBookings:output_type -> booking.ListBookingsResponse
+	25, // 87: booking.BookingService.UpdateSeatBooking:output_type -> booking.UpdateSeatBookingResponse
+	28, // 88: booking.BookingService.UpdateBooking:output_type -> booking.UpdateBookingResponse
+	30, // 89: booking.BookingService.DeleteBooking:output_type -> booking.DeleteBookingResponse
+	35, // 90: booking.BookingService.WatchAvailability:output_type -> booking.AvailabilityEvent
+	42, // 91: booking.BookingService.BookingSession:output_type -> booking.BookingSessionResponse
+	47, // 92: booking.UserService.CreateUser:output_type -> booking.CreateUserResponse
+	49, // 93: booking.UserService.GetUser:output_type -> booking.GetUserResponse
+	51, // 94: booking.UserService.UpdateUser:output_type -> booking.UpdateUserResponse
+	53, // 95: booking.UserService.FindUserByEmail:output_type -> booking.FindUserByEmailResponse
+	55, // 96: booking.UserService.ExportUserData:output_type -> booking.ExportUserDataResponse
+	57, // 97: booking.UserService.EraseUser:output_type -> booking.EraseUserResponse
+	60, // 98: booking.AuthService.Login:output_type -> booking.LoginResponse
+	45, // 99: booking.AdminService.CheckStoreInvariants:output_type -> booking.CheckStoreInvariantsResponse
+	79, // [79:100] is the sub-list for method output_type
+	58, // [58:79] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_proto_booking_proto_init() }
@@ -880,18 +4448,37 @@ func file_proto_booking_proto_init() {
 	if File_proto_booking_proto != nil {
 		return
 	}
+	file_proto_booking_proto_msgTypes[31].OneofWrappers = []any{
+		(*AvailabilityEvent_Snapshot)(nil),
+		(*AvailabilityEvent_Change)(nil),
+	}
+	file_proto_booking_proto_msgTypes[34].OneofWrappers = []any{
+		(*BookingSessionRequest_Hover)(nil),
+		(*BookingSessionRequest_Select)(nil),
+		(*BookingSessionRequest_Deselect)(nil),
+		(*BookingSessionRequest_Confirm)(nil),
+	}
+	file_proto_booking_proto_msgTypes[38].OneofWrappers = []any{
+		(*BookingSessionResponse_Seat)(nil),
+		(*BookingSessionResponse_Selected)(nil),
+		(*BookingSessionResponse_Deselected)(nil),
+		(*BookingSessionResponse_Conflict)(nil),
+		(*BookingSessionResponse_Confirmed)(nil),
+		(*BookingSessionResponse_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_booking_proto_rawDesc), len(file_proto_booking_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      4,
+			NumMessages:   57,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_proto_booking_proto_goTypes,
 		DependencyIndexes: file_proto_booking_proto_depIdxs,
+		EnumInfos:         file_proto_booking_proto_enumTypes,
 		MessageInfos:      file_proto_booking_proto_msgTypes,
 	}.Build()
 	File_proto_booking_proto = out.File
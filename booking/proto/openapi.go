@@ -0,0 +1,9 @@
+package booking
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 document of the REST gateway, generated from
+// proto/booking.proto by protoc-gen-openapiv2.
+//
+//go:embed booking.swagger.json
+var OpenAPI []byte
@@ -0,0 +1,1883 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/v2/booking.proto
+
+package bookingv2
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BookingStatus int32
+
+const (
+	BookingStatus_BOOKING_STATUS_UNSPECIFIED BookingStatus = 0
+	BookingStatus_BOOKING_STATUS_CONFIRMED   BookingStatus = 1
+	BookingStatus_BOOKING_STATUS_CANCELLED   BookingStatus = 2
+)
+
+// Enum value maps for BookingStatus.
+var (
+	BookingStatus_name = map[int32]string{
+		0: "BOOKING_STATUS_UNSPECIFIED",
+		1: "BOOKING_STATUS_CONFIRMED",
+		2: "BOOKING_STATUS_CANCELLED",
+	}
+	BookingStatus_value = map[string]int32{
+		"BOOKING_STATUS_UNSPECIFIED": 0,
+		"BOOKING_STATUS_CONFIRMED":   1,
+		"BOOKING_STATUS_CANCELLED":   2,
+	}
+)
+
+func (x BookingStatus) Enum() *BookingStatus {
+	p := new(BookingStatus)
+	*p = x
+	return p
+}
+
+func (x BookingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BookingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v2_booking_proto_enumTypes[0].Descriptor()
+}
+
+func (BookingStatus) Type() protoreflect.EnumType {
+	return &file_proto_v2_booking_proto_enumTypes[0]
+}
+
+func (x BookingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BookingStatus.Descriptor instead.
+func (BookingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{0}
+}
+
+type TravelClass int32
+
+const (
+	TravelClass_TRAVEL_CLASS_UNSPECIFIED TravelClass = 0
+	TravelClass_TRAVEL_CLASS_STANDARD    TravelClass = 1
+	TravelClass_TRAVEL_CLASS_FIRST       TravelClass = 2
+)
+
+// Enum value maps for TravelClass.
+var (
+	TravelClass_name = map[int32]string{
+		0: "TRAVEL_CLASS_UNSPECIFIED",
+		1: "TRAVEL_CLASS_STANDARD",
+		2: "TRAVEL_CLASS_FIRST",
+	}
+	TravelClass_value = map[string]int32{
+		"TRAVEL_CLASS_UNSPECIFIED": 0,
+		"TRAVEL_CLASS_STANDARD":    1,
+		"TRAVEL_CLASS_FIRST":       2,
+	}
+)
+
+func (x TravelClass) Enum() *TravelClass {
+	p := new(TravelClass)
+	*p = x
+	return p
+}
+
+func (x TravelClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TravelClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v2_booking_proto_enumTypes[1].Descriptor()
+}
+
+func (TravelClass) Type() protoreflect.EnumType {
+	return &file_proto_v2_booking_proto_enumTypes[1]
+}
+
+func (x TravelClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TravelClass.Descriptor instead.
+func (TravelClass) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{1}
+}
+
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASCENDING   SortOrder = 1
+	SortOrder_SORT_ORDER_DESCENDING  SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASCENDING",
+		2: "SORT_ORDER_DESCENDING",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASCENDING":   1,
+		"SORT_ORDER_DESCENDING":  2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v2_booking_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_proto_v2_booking_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{2}
+}
+
+// SeatView selects how much of the passengers a seat list shows.
+type SeatView int32
+
+const (
+	// UNSPECIFIED is FULL for staff and MASKED for everybody else.
+	SeatView_SEAT_VIEW_UNSPECIFIED SeatView = 0
+	// FULL shows every passenger's name and email, staff only.
+	SeatView_SEAT_VIEW_FULL SeatView = 1
+	// MASKED shows initials and a masked email, except for the caller's own
+	// seats.
+	SeatView_SEAT_VIEW_MASKED SeatView = 2
+	// OCCUPIED shows no passengers at all, only which seats are taken.
+	SeatView_SEAT_VIEW_OCCUPIED SeatView = 3
+)
+
+// Enum value maps for SeatView.
+var (
+	SeatView_name = map[int32]string{
+		0: "SEAT_VIEW_UNSPECIFIED",
+		1: "SEAT_VIEW_FULL",
+		2: "SEAT_VIEW_MASKED",
+		3: "SEAT_VIEW_OCCUPIED",
+	}
+	SeatView_value = map[string]int32{
+		"SEAT_VIEW_UNSPECIFIED": 0,
+		"SEAT_VIEW_FULL":        1,
+		"SEAT_VIEW_MASKED":      2,
+		"SEAT_VIEW_OCCUPIED":    3,
+	}
+)
+
+func (x SeatView) Enum() *SeatView {
+	p := new(SeatView)
+	*p = x
+	return p
+}
+
+func (x SeatView) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatView) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v2_booking_proto_enumTypes[3].Descriptor()
+}
+
+func (SeatView) Type() protoreflect.EnumType {
+	return &file_proto_v2_booking_proto_enumTypes[3]
+}
+
+func (x SeatView) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatView.Descriptor instead.
+func (SeatView) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{3}
+}
+
+type SeatAvailability int32
+
+const (
+	SeatAvailability_SEAT_AVAILABILITY_UNSPECIFIED SeatAvailability = 0
+	SeatAvailability_SEAT_AVAILABILITY_AVAILABLE   SeatAvailability = 1
+	SeatAvailability_SEAT_AVAILABILITY_OCCUPIED    SeatAvailability = 2
+)
+
+// Enum value maps for SeatAvailability.
+var (
+	SeatAvailability_name = map[int32]string{
+		0: "SEAT_AVAILABILITY_UNSPECIFIED",
+		1: "SEAT_AVAILABILITY_AVAILABLE",
+		2: "SEAT_AVAILABILITY_OCCUPIED",
+	}
+	SeatAvailability_value = map[string]int32{
+		"SEAT_AVAILABILITY_UNSPECIFIED": 0,
+		"SEAT_AVAILABILITY_AVAILABLE":   1,
+		"SEAT_AVAILABILITY_OCCUPIED":    2,
+	}
+)
+
+func (x SeatAvailability) Enum() *SeatAvailability {
+	p := new(SeatAvailability)
+	*p = x
+	return p
+}
+
+func (x SeatAvailability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatAvailability) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v2_booking_proto_enumTypes[4].Descriptor()
+}
+
+func (SeatAvailability) Type() protoreflect.EnumType {
+	return &file_proto_v2_booking_proto_enumTypes[4]
+}
+
+func (x SeatAvailability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatAvailability.Descriptor instead.
+func (SeatAvailability) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{4}
+}
+
+// Money is an amount in the minor units of its currency, e.g. 2050 for
+// USD 20.50.
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// currency_code is the ISO 4217 code of the currency, e.g. USD.
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	MinorUnits    int64  `protobuf:"varint,2,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_v2_booking_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetMinorUnits() int64 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+// Passenger is the user a booking is for.
+type Passenger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passenger) Reset() {
+	*x = Passenger{}
+	mi := &file_proto_v2_booking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passenger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passenger) ProtoMessage() {}
+
+func (x *Passenger) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passenger.ProtoReflect.Descriptor instead.
+func (*Passenger) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Passenger) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Passenger) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Passenger) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Passenger) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type Booking struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BookingId string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	// reference is the booking reference, six letters and digits.
+	Reference   string     `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	Passenger   *Passenger `protobuf:"bytes,3,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Origin      string     `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination string     `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	SectionName string     `protobuf:"bytes,6,opt,name=section_name,json=sectionName,proto3" json:"section_name,omitempty"`
+	SeatNumber  string     `protobuf:"bytes,7,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	// price_paid is the amount charged, in the charge currency.
+	PricePaid   *Money        `protobuf:"bytes,8,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`
+	Status      BookingStatus `protobuf:"varint,9,opt,name=status,proto3,enum=booking.v2.BookingStatus" json:"status,omitempty"`
+	TravelClass TravelClass   `protobuf:"varint,10,opt,name=travel_class,json=travelClass,proto3,enum=booking.v2.TravelClass" json:"travel_class,omitempty"`
+	// add_ons are the extras booked with the seat: Meal, WiFi, Luggage or
+	// Bicycle.
+	AddOns []string `protobuf:"bytes,11,rep,name=add_ons,json=addOns,proto3" json:"add_ons,omitempty"`
+	// version is incremented by every change of the booking.
+	Version int64 `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	// booked_at is not set for bookings made before it was recorded.
+	BookedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=booked_at,json=bookedAt,proto3" json:"booked_at,omitempty"`
+	// fare is the price after the discount, in the currency of the train.
+	Fare *Money `protobuf:"bytes,14,opt,name=fare,proto3" json:"fare,omitempty"`
+	// exchange_rate is the rate fare was converted to price_paid with. It is
+	// not set when the booking was charged in the currency of the train.
+	ExchangeRate  *ExchangeRate `protobuf:"bytes,15,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Booking) Reset() {
+	*x = Booking{}
+	mi := &file_proto_v2_booking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Booking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Booking) ProtoMessage() {}
+
+func (x *Booking) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Booking) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+func (x *Booking) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Booking) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *Booking) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Booking) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Booking) GetSectionName() string {
+	if x != nil {
+		return x.SectionName
+	}
+	return ""
+}
+
+func (x *Booking) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
+func (x *Booking) GetPricePaid() *Money {
+	if x != nil {
+		return x.PricePaid
+	}
+	return nil
+}
+
+func (x *Booking) GetStatus() BookingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BookingStatus_BOOKING_STATUS_UNSPECIFIED
+}
+
+func (x *Booking) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_UNSPECIFIED
+}
+
+func (x *Booking) GetAddOns() []string {
+	if x != nil {
+		return x.AddOns
+	}
+	return nil
+}
+
+func (x *Booking) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Booking) GetBookedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BookedAt
+	}
+	return nil
+}
+
+func (x *Booking) GetFare() *Money {
+	if x != nil {
+		return x.Fare
+	}
+	return nil
+}
+
+func (x *Booking) GetExchangeRate() *ExchangeRate {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return nil
+}
+
+// ExchangeRate is a rate of the exchange-rate table in effect at the time
+// of the booking.
+type ExchangeRate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version is the version of the exchange-rate table.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	From    string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// rate is the decimal number of units of to per unit of from, e.g.
+	// "0.92".
+	Rate          string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_proto_v2_booking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExchangeRate) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type CreateBookingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// passenger is an existing user, found by user_id or email, or a new
+	// user.
+	Passenger   *Passenger `protobuf:"bytes,1,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Origin      string     `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination string     `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// price is the fare the client expects to pay before the discount of
+	// discount_coupon. It must be the fare of the train; the fare is charged
+	// when it is not set.
+	Price          *Money `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountCoupon string `protobuf:"bytes,5,opt,name=discount_coupon,json=discountCoupon,proto3" json:"discount_coupon,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// charge_currency is the currency the booking is charged in, the
+	// currency of the train when empty. The price is converted at the
+	// current exchange rate.
+	ChargeCurrency string `protobuf:"bytes,7,opt,name=charge_currency,json=chargeCurrency,proto3" json:"charge_currency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateBookingRequest) Reset() {
+	*x = CreateBookingRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookingRequest) ProtoMessage() {}
+
+func (x *CreateBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBookingRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateBookingRequest) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *CreateBookingRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateBookingRequest) GetDiscountCoupon() string {
+	if x != nil {
+		return x.DiscountCoupon
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetChargeCurrency() string {
+	if x != nil {
+		return x.ChargeCurrency
+	}
+	return ""
+}
+
+type GetBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingRequest) Reset() {
+	*x = GetBookingRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingRequest) ProtoMessage() {}
+
+func (x *GetBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+// BookingFilter selects bookings. Every field that is set must match.
+type BookingFilter struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status BookingStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=booking.v2.BookingStatus" json:"status,omitempty"`
+	// booked_after and booked_before bound the booking time. The range
+	// includes booked_after and excludes booked_before.
+	BookedAfter   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=booked_after,json=bookedAfter,proto3" json:"booked_after,omitempty"`
+	BookedBefore  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=booked_before,json=bookedBefore,proto3" json:"booked_before,omitempty"`
+	TrainId       string                 `protobuf:"bytes,4,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	SectionId     string                 `protobuf:"bytes,5,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingFilter) Reset() {
+	*x = BookingFilter{}
+	mi := &file_proto_v2_booking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingFilter) ProtoMessage() {}
+
+func (x *BookingFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingFilter.ProtoReflect.Descriptor instead.
+func (*BookingFilter) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BookingFilter) GetStatus() BookingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BookingStatus_BOOKING_STATUS_UNSPECIFIED
+}
+
+func (x *BookingFilter) GetBookedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BookedAfter
+	}
+	return nil
+}
+
+func (x *BookingFilter) GetBookedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BookedBefore
+	}
+	return nil
+}
+
+func (x *BookingFilter) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *BookingFilter) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+type ListBookingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter        *BookingFilter         `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Order         SortOrder              `protobuf:"varint,3,opt,name=order,proto3,enum=booking.v2.SortOrder" json:"order,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookingsRequest) Reset() {
+	*x = ListBookingsRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookingsRequest) ProtoMessage() {}
+
+func (x *ListBookingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookingsRequest.ProtoReflect.Descriptor instead.
+func (*ListBookingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBookingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBookingsRequest) GetFilter() *BookingFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListBookingsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListBookingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBookingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBookingsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListBookingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bookings      []*Booking             `protobuf:"bytes,1,rep,name=bookings,proto3" json:"bookings,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of bookings matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookingsResponse) Reset() {
+	*x = ListBookingsResponse{}
+	mi := &file_proto_v2_booking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookingsResponse) ProtoMessage() {}
+
+func (x *ListBookingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookingsResponse.ProtoReflect.Descriptor instead.
+func (*ListBookingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListBookingsResponse) GetBookings() []*Booking {
+	if x != nil {
+		return x.Bookings
+	}
+	return nil
+}
+
+func (x *ListBookingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBookingsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type SeatRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        string                 `protobuf:"bytes,1,opt,name=seat_id,json=seatId,proto3" json:"seat_id,omitempty"`
+	SectionId     string                 `protobuf:"bytes,2,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatRef) Reset() {
+	*x = SeatRef{}
+	mi := &file_proto_v2_booking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatRef) ProtoMessage() {}
+
+func (x *SeatRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatRef.ProtoReflect.Descriptor instead.
+func (*SeatRef) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeatRef) GetSeatId() string {
+	if x != nil {
+		return x.SeatId
+	}
+	return ""
+}
+
+func (x *SeatRef) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+// BookingUpdate holds the new values of the fields of a booking. The name
+// and email are those of the passenger.
+type BookingUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Seat          *SeatRef               `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`
+	TravelClass   TravelClass            `protobuf:"varint,5,opt,name=travel_class,json=travelClass,proto3,enum=booking.v2.TravelClass" json:"travel_class,omitempty"`
+	AddOns        []string               `protobuf:"bytes,6,rep,name=add_ons,json=addOns,proto3" json:"add_ons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingUpdate) Reset() {
+	*x = BookingUpdate{}
+	mi := &file_proto_v2_booking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingUpdate) ProtoMessage() {}
+
+func (x *BookingUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingUpdate.ProtoReflect.Descriptor instead.
+func (*BookingUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BookingUpdate) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BookingUpdate) GetSeat() *SeatRef {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *BookingUpdate) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_UNSPECIFIED
+}
+
+func (x *BookingUpdate) GetAddOns() []string {
+	if x != nil {
+		return x.AddOns
+	}
+	return nil
+}
+
+type UpdateBookingRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BookingId string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	Booking   *BookingUpdate         `protobuf:"bytes,2,opt,name=booking,proto3" json:"booking,omitempty"`
+	// update_mask names the fields of booking to change; other fields are
+	// ignored.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version fails the update with ABORTED when the booking is at
+	// another version. 0 skips the check.
+	ExpectedVersion int64  `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	IdempotencyKey  string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateBookingRequest) Reset() {
+	*x = UpdateBookingRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookingRequest) ProtoMessage() {}
+
+func (x *UpdateBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+func (x *UpdateBookingRequest) GetBooking() *BookingUpdate {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+func (x *UpdateBookingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateBookingRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CancelBookingRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BookingId       string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelBookingRequest) Reset() {
+	*x = CancelBookingRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingRequest) ProtoMessage() {}
+
+func (x *CancelBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+func (x *CancelBookingRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *CancelBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type Seat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        string                 `protobuf:"bytes,1,opt,name=seat_id,json=seatId,proto3" json:"seat_id,omitempty"`
+	SeatNumber    string                 `protobuf:"bytes,2,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	SectionId     string                 `protobuf:"bytes,3,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	SectionName   string                 `protobuf:"bytes,4,opt,name=section_name,json=sectionName,proto3" json:"section_name,omitempty"`
+	Available     bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	Passenger     *Passenger             `protobuf:"bytes,6,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seat) Reset() {
+	*x = Seat{}
+	mi := &file_proto_v2_booking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seat) ProtoMessage() {}
+
+func (x *Seat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seat.ProtoReflect.Descriptor instead.
+func (*Seat) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Seat) GetSeatId() string {
+	if x != nil {
+		return x.SeatId
+	}
+	return ""
+}
+
+func (x *Seat) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
+func (x *Seat) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *Seat) GetSectionName() string {
+	if x != nil {
+		return x.SectionName
+	}
+	return ""
+}
+
+func (x *Seat) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *Seat) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+type ListSeatsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SectionId string                 `protobuf:"bytes,1,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	// train_id must be the ID of the train when set.
+	TrainId       string                 `protobuf:"bytes,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	View          SeatView               `protobuf:"varint,3,opt,name=view,proto3,enum=booking.v2.SeatView" json:"view,omitempty"`
+	Availability  SeatAvailability       `protobuf:"varint,4,opt,name=availability,proto3,enum=booking.v2.SeatAvailability" json:"availability,omitempty"`
+	Order         SortOrder              `protobuf:"varint,5,opt,name=order,proto3,enum=booking.v2.SortOrder" json:"order,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeatsRequest) Reset() {
+	*x = ListSeatsRequest{}
+	mi := &file_proto_v2_booking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeatsRequest) ProtoMessage() {}
+
+func (x *ListSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeatsRequest.ProtoReflect.Descriptor instead.
+func (*ListSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListSeatsRequest) GetSectionId() string {
+	if x != nil {
+		return x.SectionId
+	}
+	return ""
+}
+
+func (x *ListSeatsRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *ListSeatsRequest) GetView() SeatView {
+	if x != nil {
+		return x.View
+	}
+	return SeatView_SEAT_VIEW_UNSPECIFIED
+}
+
+func (x *ListSeatsRequest) GetAvailability() SeatAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return SeatAvailability_SEAT_AVAILABILITY_UNSPECIFIED
+}
+
+func (x *ListSeatsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListSeatsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSeatsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSeatsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListSeatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seats         []*Seat                `protobuf:"bytes,1,rep,name=seats,proto3" json:"seats,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of seats matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeatsResponse) Reset() {
+	*x = ListSeatsResponse{}
+	mi := &file_proto_v2_booking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeatsResponse) ProtoMessage() {}
+
+func (x *ListSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeatsResponse.ProtoReflect.Descriptor instead.
+func (*ListSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSeatsResponse) GetSeats() []*Seat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *ListSeatsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListSeatsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// Error is the JSON body of a failed REST gateway call.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is the HTTP status code.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// status is the name of the gRPC status code, e.g. NOT_FOUND.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_proto_v2_booking_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v2_booking_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_proto_v2_booking_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+var File_proto_v2_booking_proto protoreflect.FileDescriptor
+
+const file_proto_v2_booking_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/v2/booking.proto\x12\n" +
+	"booking.v2\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"M\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x1f\n" +
+	"\vminor_units\x18\x02 \x01(\x03R\n" +
+	"minorUnits\"v\n" +
+	"\tPassenger\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\"\xec\x04\n" +
+	"\aBooking\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x123\n" +
+	"\tpassenger\x18\x03 \x01(\v2\x15.booking.v2.PassengerR\tpassenger\x12\x16\n" +
+	"\x06origin\x18\x04 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x05 \x01(\tR\vdestination\x12!\n" +
+	"\fsection_name\x18\x06 \x01(\tR\vsectionName\x12\x1f\n" +
+	"\vseat_number\x18\a \x01(\tR\n" +
+	"seatNumber\x120\n" +
+	"\n" +
+	"price_paid\x18\b \x01(\v2\x11.booking.v2.MoneyR\tpricePaid\x121\n" +
+	"\x06status\x18\t \x01(\x0e2\x19.booking.v2.BookingStatusR\x06status\x12:\n" +
+	"\ftravel_class\x18\n" +
+	" \x01(\x0e2\x17.booking.v2.TravelClassR\vtravelClass\x12\x17\n" +
+	"\aadd_ons\x18\v \x03(\tR\x06addOns\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x127\n" +
+	"\tbooked_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bbookedAt\x12%\n" +
+	"\x04fare\x18\x0e \x01(\v2\x11.booking.v2.MoneyR\x04fare\x12=\n" +
+	"\rexchange_rate\x18\x0f \x01(\v2\x18.booking.v2.ExchangeRateR\fexchangeRate\"`\n" +
+	"\fExchangeRate\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\"\xa9\x02\n" +
+	"\x14CreateBookingRequest\x123\n" +
+	"\tpassenger\x18\x01 \x01(\v2\x15.booking.v2.PassengerR\tpassenger\x12\x16\n" +
+	"\x06origin\x18\x02 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12'\n" +
+	"\x05price\x18\x04 \x01(\v2\x11.booking.v2.MoneyR\x05price\x12'\n" +
+	"\x0fdiscount_coupon\x18\x05 \x01(\tR\x0ediscountCoupon\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\x12'\n" +
+	"\x0fcharge_currency\x18\a \x01(\tR\x0echargeCurrency\"2\n" +
+	"\x11GetBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\"\xfc\x01\n" +
+	"\rBookingFilter\x121\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x19.booking.v2.BookingStatusR\x06status\x12=\n" +
+	"\fbooked_after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vbookedAfter\x12?\n" +
+	"\rbooked_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fbookedBefore\x12\x19\n" +
+	"\btrain_id\x18\x04 \x01(\tR\atrainId\x12\x1d\n" +
+	"\n" +
+	"section_id\x18\x05 \x01(\tR\tsectionId\"\x83\x02\n" +
+	"\x13ListBookingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x06filter\x18\x02 \x01(\v2\x19.booking.v2.BookingFilterR\x06filter\x12+\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x15.booking.v2.SortOrderR\x05order\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x127\n" +
+	"\tread_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x8e\x01\n" +
+	"\x14ListBookingsResponse\x12/\n" +
+	"\bbookings\x18\x01 \x03(\v2\x13.booking.v2.BookingR\bbookings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"A\n" +
+	"\aSeatRef\x12\x17\n" +
+	"\aseat_id\x18\x01 \x01(\tR\x06seatId\x12\x1d\n" +
+	"\n" +
+	"section_id\x18\x02 \x01(\tR\tsectionId\"\xdf\x01\n" +
+	"\rBookingUpdate\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12'\n" +
+	"\x04seat\x18\x04 \x01(\v2\x13.booking.v2.SeatRefR\x04seat\x12:\n" +
+	"\ftravel_class\x18\x05 \x01(\x0e2\x17.booking.v2.TravelClassR\vtravelClass\x12\x17\n" +
+	"\aadd_ons\x18\x06 \x03(\tR\x06addOns\"\xfb\x01\n" +
+	"\x14UpdateBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\x123\n" +
+	"\abooking\x18\x02 \x01(\v2\x19.booking.v2.BookingUpdateR\abooking\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\x03R\x0fexpectedVersion\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\x89\x01\n" +
+	"\x14CancelBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\xd5\x01\n" +
+	"\x04Seat\x12\x17\n" +
+	"\aseat_id\x18\x01 \x01(\tR\x06seatId\x12\x1f\n" +
+	"\vseat_number\x18\x02 \x01(\tR\n" +
+	"seatNumber\x12\x1d\n" +
+	"\n" +
+	"section_id\x18\x03 \x01(\tR\tsectionId\x12!\n" +
+	"\fsection_name\x18\x04 \x01(\tR\vsectionName\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x123\n" +
+	"\tpassenger\x18\x06 \x01(\v2\x15.booking.v2.PassengerR\tpassenger\"\xda\x02\n" +
+	"\x10ListSeatsRequest\x12\x1d\n" +
+	"\n" +
+	"section_id\x18\x01 \x01(\tR\tsectionId\x12\x19\n" +
+	"\btrain_id\x18\x02 \x01(\tR\atrainId\x12(\n" +
+	"\x04view\x18\x03 \x01(\x0e2\x14.booking.v2.SeatViewR\x04view\x12@\n" +
+	"\favailability\x18\x04 \x01(\x0e2\x1c.booking.v2.SeatAvailabilityR\favailability\x12+\n" +
+	"\x05order\x18\x05 \x01(\x0e2\x15.booking.v2.SortOrderR\x05order\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x127\n" +
+	"\tread_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x82\x01\n" +
+	"\x11ListSeatsResponse\x12&\n" +
+	"\x05seats\x18\x01 \x03(\v2\x10.booking.v2.SeatR\x05seats\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"l\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId*k\n" +
+	"\rBookingStatus\x12\x1e\n" +
+	"\x1aBOOKING_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18BOOKING_STATUS_CONFIRMED\x10\x01\x12\x1c\n" +
+	"\x18BOOKING_STATUS_CANCELLED\x10\x02*^\n" +
+	"\vTravelClass\x12\x1c\n" +
+	"\x18TRAVEL_CLASS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TRAVEL_CLASS_STANDARD\x10\x01\x12\x16\n" +
+	"\x12TRAVEL_CLASS_FIRST\x10\x02*\\\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SORT_ORDER_ASCENDING\x10\x01\x12\x19\n" +
+	"\x15SORT_ORDER_DESCENDING\x10\x02*g\n" +
+	"\bSeatView\x12\x19\n" +
+	"\x15SEAT_VIEW_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSEAT_VIEW_FULL\x10\x01\x12\x14\n" +
+	"\x10SEAT_VIEW_MASKED\x10\x02\x12\x16\n" +
+	"\x12SEAT_VIEW_OCCUPIED\x10\x03*v\n" +
+	"\x10SeatAvailability\x12!\n" +
+	"\x1dSEAT_AVAILABILITY_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bSEAT_AVAILABILITY_AVAILABLE\x10\x01\x12\x1e\n" +
+	"\x1aSEAT_AVAILABILITY_OCCUPIED\x10\x022\x9b\x05\n" +
+	"\x0eBookingService\x12_\n" +
+	"\rCreateBooking\x12 .booking.v2.CreateBookingRequest\x1a\x13.booking.v2.Booking\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v2/bookings\x12c\n" +
+	"\n" +
+	"GetBooking\x12\x1d.booking.v2.GetBookingRequest\x1a\x13.booking.v2.Booking\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v2/bookings/{booking_id}\x12g\n" +
+	"\fListBookings\x12\x1f.booking.v2.ListBookingsRequest\x1a .booking.v2.ListBookingsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v2/bookings\x12r\n" +
+	"\rUpdateBooking\x12 .booking.v2.UpdateBookingRequest\x1a\x13.booking.v2.Booking\"*\x82\xd3\xe4\x93\x02$:\abooking2\x19/v2/bookings/{booking_id}\x12s\n" +
+	"\rCancelBooking\x12 .booking.v2.CancelBookingRequest\x1a\x13.booking.v2.Booking\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v2/bookings/{booking_id}:cancel\x12q\n" +
+	"\tListSeats\x12\x1c.booking.v2.ListSeatsRequest\x1a\x1d.booking.v2.ListSeatsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v2/sections/{section_id}/seatsB\xe7\x01\x92A\xba\x01\x12\x18\n" +
+	"\x11Train Booking API2\x032.0R4\n" +
+	"\adefault\x12)\n" +
+	"\x10The call failed.\x12\x15\n" +
+	"\x13\x1a\x11.booking.v2.ErrorZZ\n" +
+	"X\n" +
+	"\x06bearer\x12N\b\x02\x129An access token from /v1/auth/login, as \"Bearer <token>\".\x1a\rAuthorization \x02b\f\n" +
+	"\n" +
+	"\n" +
+	"\x06bearer\x12\x00Z'grpc-project/booking/proto/v2;bookingv2b\x06proto3"
+
+var (
+	file_proto_v2_booking_proto_rawDescOnce sync.Once
+	file_proto_v2_booking_proto_rawDescData []byte
+)
+
+func file_proto_v2_booking_proto_rawDescGZIP() []byte {
+	file_proto_v2_booking_proto_rawDescOnce.Do(func() {
+		file_proto_v2_booking_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_v2_booking_proto_rawDesc), len(file_proto_v2_booking_proto_rawDesc)))
+	})
+	return file_proto_v2_booking_proto_rawDescData
+}
+
+var file_proto_v2_booking_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_v2_booking_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_v2_booking_proto_goTypes = []any{
+	(BookingStatus)(0),            // 0: booking.v2.BookingStatus
+	(TravelClass)(0),              // 1: booking.v2.TravelClass
+	(SortOrder)(0),                // 2: booking.v2.SortOrder
+	(SeatView)(0),                 // 3: booking.v2.SeatView
+	(SeatAvailability)(0),         // 4: booking.v2.SeatAvailability
+	(*Money)(nil),                 // 5: booking.v2.Money
+	(*Passenger)(nil),             // 6: booking.v2.Passenger
+	(*Booking)(nil),               // 7: booking.v2.Booking
+	(*ExchangeRate)(nil),          // 8: booking.v2.ExchangeRate
+	(*CreateBookingRequest)(nil),  // 9: booking.v2.CreateBookingRequest
+	(*GetBookingRequest)(nil),     // 10: booking.v2.GetBookingRequest
+	(*BookingFilter)(nil),         // 11: booking.v2.BookingFilter
+	(*ListBookingsRequest)(nil),   // 12: booking.v2.ListBookingsRequest
+	(*ListBookingsResponse)(nil),  // 13: booking.v2.ListBookingsResponse
+	(*SeatRef)(nil),               // 14: booking.v2.SeatRef
+	(*BookingUpdate)(nil),         // 15: booking.v2.BookingUpdate
+	(*UpdateBookingRequest)(nil),  // 16: booking.v2.UpdateBookingRequest
+	(*CancelBookingRequest)(nil),  // 17: booking.v2.CancelBookingRequest
+	(*Seat)(nil),                  // 18: booking.v2.Seat
+	(*ListSeatsRequest)(nil),      // 19: booking.v2.ListSeatsRequest
+	(*ListSeatsResponse)(nil),     // 20: booking.v2.ListSeatsResponse
+	(*Error)(nil),                 // 21: booking.v2.Error
+	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 23: google.protobuf.FieldMask
+}
+var file_proto_v2_booking_proto_depIdxs = []int32{
+	6,  // 0: booking.v2.Booking.passenger:type_name -> booking.v2.Passenger
+	5,  // 1: booking.v2.Booking.price_paid:type_name -> booking.v2.Money
+	0,  // 2: booking.v2.Booking.status:type_name -> booking.v2.BookingStatus
+	1,  // 3: booking.v2.Booking.travel_class:type_name -> booking.v2.TravelClass
+	22, // 4: booking.v2.Booking.booked_at:type_name -> google.protobuf.Timestamp
+	5,  // 5: booking.v2.Booking.fare:type_name -> booking.v2.Money
+	8,  // 6: booking.v2.Booking.exchange_rate:type_name -> booking.v2.ExchangeRate
+	6,  // 7: booking.v2.CreateBookingRequest.passenger:type_name -> booking.v2.Passenger
+	5,  // 8: booking.v2.CreateBookingRequest.price:type_name -> booking.v2.Money
+	0,  // 9: booking.v2.BookingFilter.status:type_name -> booking.v2.BookingStatus
+	22, // 10: booking.v2.BookingFilter.booked_after:type_name -> google.protobuf.Timestamp
+	22, // 11: booking.v2.BookingFilter.booked_before:type_name -> google.protobuf.Timestamp
+	11, // 12: booking.v2.ListBookingsRequest.filter:type_name -> booking.v2.BookingFilter
+	2,  // 13: booking.v2.ListBookingsRequest.order:type_name -> booking.v2.SortOrder
+	23, // 14: booking.v2.ListBookingsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	7,  // 15: booking.v2.ListBookingsResponse.bookings:type_name -> booking.v2.Booking
+	14, // 16: booking.v2.BookingUpdate.seat:type_name -> booking.v2.SeatRef
+	1,  // 17: booking.v2.BookingUpdate.travel_class:type_name -> booking.v2.TravelClass
+	15, // 18: booking.v2.UpdateBookingRequest.booking:type_name -> booking.v2.BookingUpdate
+	23, // 19: booking.v2.UpdateBookingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	6,  // 20: booking.v2.Seat.passenger:type_name -> booking.v2.Passenger
+	3,  // 21: booking.v2.ListSeatsRequest.view:type_name -> booking.v2.SeatView
+	4,  // 22: booking.v2.ListSeatsRequest.availability:type_name -> booking.v2.SeatAvailability
+	2,  // 23: booking.v2.ListSeatsRequest.order:type_name -> booking.v2.SortOrder
+	23, // 24: booking.v2.ListSeatsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	18, // 25: booking.v2.ListSeatsResponse.seats:type_name -> booking.v2.Seat
+	9,  // 26: booking.v2.BookingService.CreateBooking:input_type -> booking.v2.CreateBookingRequest
+	10, // 27: booking.v2.BookingService.GetBooking:input_type -> booking.v2.GetBookingRequest
+	12, // 28: booking.v2.BookingService.ListBookings:input_type -> booking.v2.ListBookingsRequest
+	16, // 29: booking.v2.BookingService.UpdateBooking:input_type -> booking.v2.UpdateBookingRequest
+	17, // 30: booking.v2.BookingService.CancelBooking:input_type -> booking.v2.CancelBookingRequest
+	19, // 31: booking.v2.BookingService.ListSeats:input_type -> booking.v2.ListSeatsRequest
+	7,  // 32: booking.v2.BookingService.CreateBooking:output_type -> booking.v2.Booking
+	7,  // 33: booking.v2.BookingService.GetBooking:output_type -> booking.v2.Booking
+	13, // 34: booking.v2.BookingService.ListBookings:output_type -> booking.v2.ListBookingsResponse
+	7,  // 35: booking.v2.BookingService.UpdateBooking:output_type -> booking.v2.Booking
+	7,  // 36: booking.v2.BookingService.CancelBooking:output_type -> booking.v2.Booking
+	20, // 37: booking.v2.BookingService.ListSeats:output_type -> booking.v2.ListSeatsResponse
+	32, // [32:38] is the sub-list for method output_type
+	26, // [26:32] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_proto_v2_booking_proto_init() }
+func file_proto_v2_booking_proto_init() {
+	if File_proto_v2_booking_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v2_booking_proto_rawDesc), len(file_proto_v2_booking_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_v2_booking_proto_goTypes,
+		DependencyIndexes: file_proto_v2_booking_proto_depIdxs,
+		EnumInfos:         file_proto_v2_booking_proto_enumTypes,
+		MessageInfos:      file_proto_v2_booking_proto_msgTypes,
+	}.Build()
+	File_proto_v2_booking_proto = out.File
+	file_proto_v2_booking_proto_goTypes = nil
+	file_proto_v2_booking_proto_depIdxs = nil
+}
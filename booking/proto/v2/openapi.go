@@ -0,0 +1,9 @@
+package bookingv2
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 document of the version 2 REST routes,
+// generated from proto/v2/booking.proto by protoc-gen-openapiv2.
+//
+//go:embed booking.swagger.json
+var OpenAPI []byte
@@ -2,15 +2,51 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
+	"os"
 
 	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/tracing"
+	"grpc-project/pkg/transport"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+var (
+	addr          = flag.String("addr", "localhost:8080", "address of the booking server")
+	tlsCA         = flag.String("tls-ca", "", "PEM CA certificates the server certificate is verified against, enables TLS")
+	tlsCert       = flag.String("tls-cert", "", "PEM client certificate for mTLS")
+	tlsKey        = flag.String("tls-key", "", "PEM private key of the client certificate")
+	tlsServerName = flag.String("tls-server-name", "", "server name to verify, defaults to the host of -addr")
+
+	email = flag.String("email", "bobthebuilder@gmail.com", "email of the account the walkthrough logs in as, with the password from $BOOKING_PASSWORD")
+
+	traceExporter = flag.String("trace-exporter", "none", "where spans are sent: none, otlp, stdout or file")
+	traceEndpoint = flag.String("trace-endpoint", "", "host:port of the OTLP gRPC collector, OTEL_EXPORTER_OTLP_* apply when empty")
+	traceInsecure = flag.Bool("trace-insecure", false, "send spans to the OTLP collector without TLS")
+	traceFile     = flag.String("trace-file", "client-traces.json", "file spans are appended to with the file exporter")
+)
+
+func Login(client pb.AuthServiceClient, ctx context.Context, email, password string) string {
+	loginResp, err := client.Login(ctx, &pb.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		log.Fatalf("Login failed: %v", err)
+	}
+	fmt.Printf("Logged in as %s\n", email)
+	return loginResp.AccessToken
+}
+
 func PurchasingTicket(client pb.BookingServiceClient, ctx context.Context) string {
 	purchaseReq := &pb.PurchaseBookingRequest{
 		From: "London",
@@ -123,8 +159,45 @@ func DeleteBooking(client pb.BookingServiceClient, ctx context.Context, receiptI
 }
 
 func main() {
+	flag.Parse()
+
+	// Set up tracing, the trace context is propagated to the server
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: "booking-client",
+		Exporter:    *traceExporter,
+		Endpoint:    *traceEndpoint,
+		Insecure:    *traceInsecure,
+		File:        *traceFile,
+		SampleRatio: 1,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Connect to the gRPC server
-	conn, err := grpc.NewClient("localhost:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	transportCredentials := insecure.NewCredentials()
+	if *tlsCA != "" || *tlsCert != "" {
+		serverName := *tlsServerName
+		if serverName == "" {
+			serverName, _, _ = net.SplitHostPort(*addr)
+		}
+		tlsConfig, err := transport.ClientConfig(transport.ClientOptions{
+			CAFile:        *tlsCA,
+			CertFile:      *tlsCert,
+			KeyFile:       *tlsKey,
+			ServerName:    serverName,
+			CheckInterval: transport.DefaultCheckInterval,
+		})
+		if err != nil {
+			log.Fatalf("Failed to load TLS configuration: %v", err)
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(*addr,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		log.Fatalf("Failed to connect to server: %v", err)
 	}
@@ -132,9 +205,19 @@ func main() {
 
 	// Create BookingService client
 	client := pb.NewBookingServiceClient(conn)
-	fmt.Println("Connected to gRPC server at localhost:8080")
+	fmt.Printf("Connected to gRPC server at %s\n", *addr)
 
-	ctx := context.Background()
+	// Every call of the walkthrough is part of one trace
+	ctx, span := otel.Tracer("grpc-project/cmd/client").Start(context.Background(), "booking-walkthrough")
+	defer span.End()
+
+	// Log in as Bob, every booking call carries his access token. Without a
+	// password the calls are made without a token, for servers run with
+	// -auth=false.
+	if password := os.Getenv("BOOKING_PASSWORD"); password != "" {
+		token := Login(pb.NewAuthServiceClient(conn), ctx, *email, password)
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
 
 	// Step 1: Purchase a ticket for Bob
 	fmt.Println("\n ********* Step 1: Purchasing a ticket for Bob  **********")
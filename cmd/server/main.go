@@ -1,16 +1,46 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	pb "grpc-project/booking/proto"
+	pbv2 "grpc-project/booking/proto/v2"
 	"grpc-project/cmd/server/models"
 	"grpc-project/cmd/server/service"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/gateway"
+	"grpc-project/pkg/health"
+	"grpc-project/pkg/idempotency"
+	"grpc-project/pkg/interceptors"
+	"grpc-project/pkg/money"
+	"grpc-project/pkg/ratelimit"
+	"grpc-project/pkg/rbac"
+	"grpc-project/pkg/store"
+	"grpc-project/pkg/tracing"
+	"grpc-project/pkg/transport"
+	"grpc-project/pkg/web"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -26,7 +56,6 @@ func init() {
 
 	sectionCount := 2
 	seatCount := 20
-	price := 20
 
 	for i := 0; i < sectionCount; i++ {
 		section := &models.Section{
@@ -50,36 +79,417 @@ func init() {
 		Store.Train.Sections = append(Store.Train.Sections, section)
 
 	}
+	Store.Receipts = make(map[string]*models.Receipt)
+}
 
-	//Create User
+// seedDemoUsers adds the demo customers Alice and Bob. Their passwords are
+// public, so they are only created with -seed-demo-users.
+func seedDemoUsers() {
 	alice := &models.User{
-		Id:        "1",
-		FirstName: "Alice",
-		LastName:  "Smith",
-		Email:     "alicewonderland@gmal.com",
-		Receipts:  []*models.Receipt{},
+		Id:           "1",
+		FirstName:    "Alice",
+		LastName:     "Smith",
+		Email:        "alicewonderland@gmal.com",
+		Role:         auth.RoleCustomer,
+		PasswordHash: mustHashPassword("alice-password"),
 	}
 	bob := &models.User{
-		Id:        "2",
-		FirstName: "Bob",
-		LastName:  "Johnson",
-		Email:     "bobthebuilder@gmail.com",
-		Receipts:  []*models.Receipt{},
+		Id:           "2",
+		FirstName:    "Bob",
+		LastName:     "Johnson",
+		Email:        "bobthebuilder@gmail.com",
+		Role:         auth.RoleCustomer,
+		PasswordHash: mustHashPassword("bob-password"),
 	}
 
 	Store.Users = append(Store.Users, alice, bob)
-	Store.Train.Price = float32(price)
-	Store.Receipts = make(map[string]models.Receipt)
+}
+
+// seedPrices sets the fare of the train and the discount codes, in the
+// currency of the train.
+func seedPrices(currency string) error {
+	if !money.ValidCurrency(currency) {
+		return fmt.Errorf("unknown currency %q", currency)
+	}
+	var err error
+	if Store.Train.Price, err = money.FromFloat(20, currency); err != nil {
+		return err
+	}
 	//Adding dicount Codes to store
-	Store.DiscountCodes = make(map[string]float32)
-	Store.DiscountCodes = map[string]float32{
-		"discount1": 10.0,
-		"discount2": 20.0,
-		"discount3": 30.0,
+	Store.DiscountCodes = make(map[string]money.Money)
+	for code, amount := range map[string]float64{"discount1": 10, "discount2": 20, "discount3": 30} {
+		if Store.DiscountCodes[code], err = money.FromFloat(amount, currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mustHashPassword(password string) string {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		log.Fatalf("failed to hash password: %v", err)
+	}
+	return hash
+}
+
+var (
+	invariantInterval = flag.Duration("invariant-interval", time.Minute, "how often the store invariants are checked in the background, never when 0")
+	invariantRepair   = flag.Bool("invariant-repair", false, "repair store invariant violations found by the background check")
+
+	authEnabled   = flag.Bool("auth", true, "require bearer tokens and enforce per-user authorization")
+	authKeyFile   = flag.String("auth-key-file", "", "PEM encoded Ed25519 private key used to sign tokens")
+	authJWKSFile  = flag.String("auth-jwks-file", "", "JWKS file with the Ed25519 token keys, re-read when it changes")
+	authTokenTTL  = flag.Duration("auth-token-ttl", time.Hour, "lifetime of issued access tokens")
+	adminEmail    = flag.String("admin-email", "admin@example.com", "email of the admin account created at startup")
+	adminPassword = flag.String("admin-password", "", "password of the admin account created at startup, no admin is created when empty")
+	demoUsers     = flag.Bool("seed-demo-users", false, "create the demo customers alice and bob with their well-known passwords; never use in production")
+
+	rbacPolicyFile = flag.String("rbac-policy", "", "JSON RBAC policy file, re-read when it changes; the built-in policy is used when empty")
+	rbacDryRun     = flag.Bool("rbac-dry-run", false, "log RBAC denials without enforcing them")
+
+	tlsCert              = flag.String("tls-cert", "", "PEM server certificate, enables TLS; re-read when it changes")
+	tlsKey               = flag.String("tls-key", "", "PEM private key of the server certificate")
+	tlsClientCA          = flag.String("tls-client-ca", "", "PEM CA certificates client certificates are verified against, enables mTLS")
+	tlsRequireClientCert = flag.Bool("tls-require-client-cert", false, "reject clients without a valid client certificate")
+	authClientCerts      = flag.Bool("auth-client-certs", false, "authenticate calls without a token by their mTLS client certificate")
+
+	logFormat = flag.String("log-format", "text", "format of the structured logs: text or json")
+
+	traceExporter    = flag.String("trace-exporter", "none", "where spans are sent: none, otlp, stdout or file")
+	traceEndpoint    = flag.String("trace-endpoint", "", "host:port of the OTLP gRPC collector, OTEL_EXPORTER_OTLP_* apply when empty")
+	traceInsecure    = flag.Bool("trace-insecure", false, "send spans to the OTLP collector without TLS")
+	traceFile        = flag.String("trace-file", "traces.json", "file spans are appended to with the file exporter")
+	traceSampleRatio = flag.Float64("trace-sample-ratio", 1, "share of new traces that are sampled")
+
+	metricsAddr = flag.String("metrics-addr", ":9090", "address of the HTTP server exposing Prometheus metrics on /metrics, disabled when empty")
+
+	rateLimit          = flag.Bool("rate-limit", true, "throttle calls per user, or client IP, and method")
+	rateLimitConfig    = flag.String("rate-limit-config", "", "JSON file with the per-method rate limits; the built-in limits are used when empty")
+	trustedProxies     = flag.String("trusted-proxies", "", "comma separated networks and addresses of proxies, e.g. 127.0.0.1,::1 for the REST gateway, whose X-Forwarded-For header gives the client IP")
+	maxBookingsPerUser = flag.Int("max-bookings-per-user", 4, "most confirmed bookings a user may hold on the train, unlimited when 0")
+	seatHoldTTL        = flag.Duration("seat-hold-ttl", 10*time.Minute, "how long a booking session may hold a seat, until the session ends when 0")
+
+	trainCurrency = flag.String("train-currency", money.USD, "currency of the train fare and the discount codes: USD, EUR, GBP or JPY")
+	exchangeRates = flag.String("exchange-rates", "", "JSON exchange-rate table, re-read when it changes; bookings are only charged in the train currency when empty")
+
+	gatewayAddr    = flag.String("gateway-addr", ":8081", "address of the REST/JSON gateway, disabled when empty")
+	gatewayTLSCA   = flag.String("gateway-tls-ca", "", "PEM CA certificates the gateway verifies the gRPC server with when TLS is enabled; the system roots are used when empty")
+	gatewayTLSCert = flag.String("gateway-tls-cert", "", "PEM client certificate the gateway presents to the gRPC server for mTLS")
+	gatewayTLSKey  = flag.String("gateway-tls-key", "", "PEM private key of the gateway client certificate")
+
+	corsAllowedOrigins = flag.String("cors-allowed-origins", "", "comma separated origins whose browser pages may call the server and the gateway, * for any")
+
+	stateFile       = flag.String("state-file", "", "JSON file the store is loaded from at startup and saved to on shutdown, the store is kept in memory only when empty")
+	shutdownTimeout = flag.Duration("shutdown-timeout", 30*time.Second, "how long pending calls may run after SIGINT or SIGTERM before they are cancelled")
+)
+
+// newLogger returns the structured logger for the given format.
+func newLogger(format string) *slog.Logger {
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+// loadPolicy returns the RBAC policy calls are checked against.
+func loadPolicy() (rbac.Source, error) {
+	if *rbacPolicyFile != "" {
+		return rbac.NewPolicyFile(*rbacPolicyFile, 10*time.Second)
+	}
+	return rbac.DefaultPolicy(), nil
+}
+
+// loadRates returns the exchange rates bookings are charged in other
+// currencies with, nil when there are none.
+func loadRates() (money.RateSource, error) {
+	if *exchangeRates == "" {
+		return nil, nil
+	}
+	return money.NewRatesFile(*exchangeRates, 10*time.Second)
+}
+
+// loadState replaces the seeded store with the saved state, if there is any.
+func loadState() error {
+	if *stateFile == "" {
+		return nil
+	}
+	Store.Mu.Lock()
+	defer Store.Mu.Unlock()
+	err := store.LoadSnapshot(Store, *stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("no saved state in %s, starting with the seeded store", *stateFile)
+		return nil
+	}
+	return err
+}
+
+// createAdmin creates the admin account unless the saved state already has it.
+func createAdmin() error {
+	Store.Mu.Lock()
+	defer Store.Mu.Unlock()
+	if store.GetUserByEmail(Store, *adminEmail) != nil {
+		return nil
+	}
+	admin := &models.User{
+		Id:           uuid.New().String(),
+		FirstName:    "Admin",
+		Email:        *adminEmail,
+		Role:         auth.RoleAdmin,
+		PasswordHash: mustHashPassword(*adminPassword),
+	}
+	return store.AddUser(Store, admin)
+}
+
+// saveState writes the store to the state file.
+func saveState() error {
+	if *stateFile == "" {
+		return nil
+	}
+	Store.Mu.Lock()
+	defer Store.Mu.Unlock()
+	return store.SaveSnapshot(Store, *stateFile)
+}
+
+// newGateway returns the HTTP server of the REST/JSON gateway. The gateway
+// calls the gRPC server on grpcAddr over loopback, so every call passes the
+// same interceptors as a direct gRPC call.
+func newGateway(grpcAddr net.Addr, cors web.CORS) (*http.Server, error) {
+	_, port, err := net.SplitHostPort(grpcAddr.String())
+	if err != nil {
+		return nil, err
+	}
+	transportCredentials := insecure.NewCredentials()
+	server := &http.Server{Addr: *gatewayAddr}
+	if *tlsCert != "" {
+		clientConfig, err := transport.ClientConfig(transport.ClientOptions{
+			CAFile:        *gatewayTLSCA,
+			CertFile:      *gatewayTLSCert,
+			KeyFile:       *gatewayTLSKey,
+			ServerName:    "localhost",
+			CheckInterval: transport.DefaultCheckInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+		transportCredentials = credentials.NewTLS(clientConfig)
+		server.TLSConfig, err = transport.ServerConfig(transport.ServerOptions{
+			CertFile:      *tlsCert,
+			KeyFile:       *tlsKey,
+			CheckInterval: transport.DefaultCheckInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	conn, err := grpc.NewClient(net.JoinHostPort("localhost", port),
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	server.RegisterOnShutdown(func() { conn.Close() })
+	handler, err := gateway.New(context.Background(), conn)
+	if err != nil {
+		return nil, err
+	}
+	server.Handler = cors.Handler(handler)
+	return server, nil
+}
+
+// splitList splits a comma separated flag value.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// stopHTTPServer waits a few seconds for the pending requests of server to
+// finish.
+func stopHTTPServer(server *http.Server, name string) {
+	if server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("failed to stop the %s server: %v", name, err)
+	}
+}
+
+// loadRateLimits returns the per-method rate limits.
+func loadRateLimits() (*ratelimit.Config, error) {
+	if *rateLimitConfig != "" {
+		return ratelimit.LoadConfig(*rateLimitConfig)
+	}
+	return ratelimit.DefaultConfig(), nil
+}
+
+// newRateLimiter returns the rate limit interceptor, which takes the client
+// IP of calls from -trusted-proxies from their X-Forwarded-For header.
+func newRateLimiter() (*ratelimit.Interceptor, error) {
+	limits, err := loadRateLimits()
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := ratelimit.ParseNetworks(*trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("-trusted-proxies: %v", err)
+	}
+	return &ratelimit.Interceptor{Limiter: ratelimit.NewLimiter(limits), TrustedProxies: proxies}, nil
+}
+
+// loadKeySet returns the keys tokens are signed and verified with.
+func loadKeySet() (auth.KeySet, error) {
+	switch {
+	case *authJWKSFile != "":
+		return auth.NewJWKSFile(*authJWKSFile, 10*time.Second)
+	case *authKeyFile != "":
+		return auth.LoadKeyFile(*authKeyFile)
+	default:
+		log.Println("no token signing key configured, using a temporary key")
+		return auth.GenerateKeySet()
 	}
 }
 
 func main() {
+	flag.Parse()
+	logger := newLogger(*logFormat)
+	slog.SetDefault(logger)
+	if err := seedPrices(*trainCurrency); err != nil {
+		log.Fatalf("failed to seed prices: %v", err)
+	}
+	if *demoUsers {
+		log.Println("creating the demo customers alice and bob, whose passwords are public")
+		seedDemoUsers()
+	}
+
+	//Set up token authentication
+	keys, err := loadKeySet()
+	if err != nil {
+		log.Fatalf("failed to load token keys: %v", err)
+	}
+	tokens := &auth.Tokens{
+		Keys: keys,
+		TTL:  *authTokenTTL,
+	}
+	//Set up the interceptor chain: request IDs, access logs and panic
+	//recovery apply to every call, the client IP rate limit, authentication,
+	//RBAC and the per-user rate limits follow
+	accessLog := &interceptors.AccessLog{
+		Logger: logger,
+		UserId: func(ctx context.Context) string {
+			if principal := auth.PrincipalFromContext(ctx); principal != nil {
+				return principal.UserId
+			}
+			return ""
+		},
+	}
+	recovery := &interceptors.Recovery{Logger: logger}
+	grpcMetrics := interceptors.NewMetrics(prometheus.DefaultRegisterer)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		interceptors.RequestIdUnary(),
+		grpcMetrics.Unary(),
+		accessLog.Unary(),
+		recovery.Unary(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		interceptors.RequestIdStream(),
+		grpcMetrics.Stream(),
+		accessLog.Stream(),
+		recovery.Stream(),
+	}
+	var serverOptions []grpc.ServerOption
+
+	//Set up tracing, spans of the client's calls continue the client's trace
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: "booking-server",
+		Exporter:    *traceExporter,
+		Endpoint:    *traceEndpoint,
+		Insecure:    *traceInsecure,
+		File:        *traceFile,
+		SampleRatio: *traceSampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("failed to flush traces: %v", err)
+		}
+	}()
+	serverOptions = append(serverOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	//Client IPs are throttled ahead of authentication, users after it
+	var limiter *ratelimit.Interceptor
+	if *rateLimit {
+		limiter, err = newRateLimiter()
+		if err != nil {
+			log.Fatalf("failed to load rate limits: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, limiter.AddressUnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.AddressStreamServerInterceptor())
+	}
+	if *authEnabled {
+		policy, err := loadPolicy()
+		if err != nil {
+			log.Fatalf("failed to load RBAC policy: %v", err)
+		}
+		enforcer := &rbac.Enforcer{
+			Source: policy,
+			DryRun: *rbacDryRun,
+		}
+		authenticator := &auth.Authenticator{
+			Tokens: tokens,
+			PublicMethods: map[string]bool{
+				pb.AuthService_Login_FullMethodName:      true,
+				pb.UserService_CreateUser_FullMethodName: true,
+				//Passengers without an account use their booking reference
+				pb.BookingService_GetBookingByReference_FullMethodName:    true,
+				pb.BookingService_CancelBookingByReference_FullMethodName: true,
+				pb.BookingService_ChangeSeatByReference_FullMethodName:    true,
+				healthpb.Health_Check_FullMethodName:                      true,
+				healthpb.Health_Watch_FullMethodName:                      true,
+			},
+			TrustClientCertificates: *authClientCerts,
+		}
+		unaryInterceptors = append(unaryInterceptors,
+			authenticator.UnaryServerInterceptor(), accessLog.UserUnary(), enforcer.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors,
+			authenticator.StreamServerInterceptor(), accessLog.UserStream(), enforcer.StreamServerInterceptor())
+	}
+	if limiter != nil {
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	serverOptions = append(serverOptions,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	//Set up transport security, TLS is terminated by the HTTP server that
+	//serves gRPC, gRPC-Web and Connect
+	var tlsConfig *tls.Config
+	if *tlsCert != "" {
+		tlsConfig, err = transport.ServerConfig(transport.ServerOptions{
+			CertFile:          *tlsCert,
+			KeyFile:           *tlsKey,
+			ClientCAFile:      *tlsClientCA,
+			RequireClientCert: *tlsRequireClientCert,
+			CheckInterval:     transport.DefaultCheckInterval,
+		})
+		if err != nil {
+			log.Fatalf("failed to load TLS configuration: %v", err)
+		}
+	} else {
+		log.Println("TLS is not configured, serving plaintext")
+	}
 
 	//Listen on port 8080
 	lis, err := net.Listen("tcp", ":8080")
@@ -87,19 +497,146 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	//Load the exchange rates for bookings charged in other currencies
+	rates, err := loadRates()
+	if err != nil {
+		log.Fatalf("failed to load exchange rates: %v", err)
+	}
+
 	//create a new gRPC server
-	s := grpc.NewServer()
+	s := grpc.NewServer(serverOptions...)
 
 	//Register the booking service with the server
+	availability := service.NewAvailabilityFeed(service.DefaultAvailabilityRetain)
+	idempotencyCache := idempotency.New(idempotency.DefaultRetention)
 	bookingService := &service.BookingServer{
-		Store: Store,
+		Store:              Store,
+		Idempotency:        idempotencyCache,
+		RequireAuth:        *authEnabled,
+		Metrics:            service.NewBookingMetrics(Store, prometheus.DefaultRegisterer),
+		MaxBookingsPerUser: *maxBookingsPerUser,
+		Availability:       availability,
+		Rates:              rates,
+		HoldTTL:            *seatHoldTTL,
 	}
 	pb.RegisterBookingServiceServer(s, bookingService)
+	pbv2.RegisterBookingServiceServer(s, &service.BookingServerV2{V1: bookingService})
+	pb.RegisterUserServiceServer(s, &service.UserServer{
+		Store:       Store,
+		RequireAuth: *authEnabled,
+		Idempotency: idempotencyCache,
+	})
+	pb.RegisterAuthServiceServer(s, &service.AuthServer{
+		Store:  Store,
+		Tokens: tokens,
+	})
+	pb.RegisterAdminServiceServer(s, &service.AdminServer{
+		Store:       Store,
+		RequireAuth: *authEnabled,
+		Bookings:    bookingService,
+	})
+
+	//Report every service as not serving until the store is loaded
+	healthServer := health.New(s,
+		pb.BookingService_ServiceDesc.ServiceName,
+		pbv2.BookingService_ServiceDesc.ServiceName,
+		pb.UserService_ServiceDesc.ServiceName,
+		pb.AuthService_ServiceDesc.ServiceName,
+		pb.AdminService_ServiceDesc.ServiceName,
+	)
 
 	reflection.Register(s)
 
-	log.Println("Server is running on port %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
+	//Serve native gRPC, gRPC-Web and Connect on the same listener
+	corsOrigins := web.CORS{AllowedOrigins: splitList(*corsAllowedOrigins)}
+	webServer, err := web.New(s, web.Options{
+		TLSConfig: tlsConfig,
+		CORS:      corsOrigins,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up gRPC-Web and Connect: %v", err)
+	}
+
+	//Expose the Prometheus metrics
+	var metricsServer *http.Server
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer = &http.Server{Addr: *metricsAddr, Handler: mux}
+		go func() {
+			log.Printf("Metrics are served on %s/metrics", *metricsAddr)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("failed to serve metrics: %v", err)
+			}
+		}()
+	}
+
+	//Serve the REST/JSON gateway
+	var gatewayServer *http.Server
+	if *gatewayAddr != "" {
+		gatewayServer, err = newGateway(lis.Addr(), corsOrigins)
+		if err != nil {
+			log.Fatalf("failed to set up the gateway: %v", err)
+		}
+		go func() {
+			log.Printf("REST gateway is served on %s, OpenAPI documents on %s and %s", *gatewayAddr, gateway.OpenAPIPath, gateway.OpenAPIV2Path)
+			var err error
+			if gatewayServer.TLSConfig != nil {
+				err = gatewayServer.ListenAndServeTLS("", "")
+			} else {
+				err = gatewayServer.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("failed to serve the gateway: %v", err)
+			}
+		}()
+	}
+
+	//Load the saved state and create the admin account before serving, so
+	//no call runs against the seeded store the saved state replaces
+	if err := loadState(); err != nil {
+		log.Fatalf("failed to load state from %s: %v", *stateFile, err)
+	}
+	if *adminPassword != "" {
+		if err := createAdmin(); err != nil {
+			log.Fatalf("failed to create admin account: %v", err)
+		}
+	}
+	healthServer.SetReady()
+
+	log.Printf("Server is running on port %v", lis.Addr())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- webServer.Serve(lis)
+	}()
+
+	//Check the store invariants in the background
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	monitor := service.NewInvariantMonitor(Store, *invariantInterval, *invariantRepair, prometheus.DefaultRegisterer)
+	monitor.Bookings = bookingService
+	go monitor.Run(monitorCtx)
+
+	//Run until SIGINT or SIGTERM
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case err := <-serveErr:
 		log.Fatalf("failed to serve: %v", err)
+	case <-signals.Done():
+	}
+
+	//Drain: stop taking calls, let pending calls finish, then save the state
+	log.Printf("Shutting down, waiting up to %v for pending calls", *shutdownTimeout)
+	healthServer.Drain()
+	availability.Close()
+	stopHTTPServer(gatewayServer, "gateway")
+	if !webServer.Shutdown(*shutdownTimeout) {
+		log.Println("pending calls did not finish in time and were cancelled")
+	}
+	stopMonitor()
+	stopHTTPServer(metricsServer, "metrics")
+	if err := saveState(); err != nil {
+		log.Printf("failed to save state to %s: %v", *stateFile, err)
 	}
+	log.Println("Server stopped")
 }
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"grpc-project/pkg/money"
+	"sync"
+	"time"
+)
+
 type Receipt struct {
 	Id            string
 	From          string
@@ -11,15 +17,48 @@ type Receipt struct {
 	SeatId        string
 	UserId        string
 	BookingStatus string
-	Price float32
+	// Price is the fare of the booking after the discount, in the currency
+	// of the train.
+	Price money.Money
+	// Charged is Price in the currency the customer paid in. Receipts from
+	// before charge currencies have none; they were charged Price.
+	Charged money.Money
+	// Rate is the exchange rate Charged was converted with, nil when the
+	// customer paid in the currency of the train.
+	Rate *money.Rate
+	// Version is incremented on every change to the receipt and is used for
+	// optimistic concurrency control of updates and cancellations.
+	Version int64
+	// BookedAt is the time of the purchase, zero for receipts from before
+	// it was recorded.
+	BookedAt time.Time
+	// Reference is the short booking reference passengers use together with
+	// their last name to find the booking without an account.
+	Reference string
+	// Class is the travel class, ClassStandard when empty.
+	Class string
+	// AddOns are the extras booked with the seat, from the AddOns
+	// catalog.
+	AddOns []string
 }
 
+// Travel classes of a booking.
+const (
+	ClassStandard = "Standard"
+	ClassFirst    = "First"
+)
+
+// AddOns is the catalog of extras a booking can include.
+var AddOns = []string{"Meal", "WiFi", "Luggage", "Bicycle"}
+
 type User struct {
 	Id        string
 	FirstName string
 	LastName  string
 	Email     string
-	Receipts  []*Receipt
+	// Role is one of the roles in pkg/auth, an empty role is a customer.
+	Role         string
+	PasswordHash string
 }
 
 type Seat struct {
@@ -43,15 +82,28 @@ type Train struct {
 	From     string
 	To       string
 	Sections []*Section
-	Price    float32
+	Price    money.Money
 }
 
 type Store struct {
-	Train         Train
-	Users         []*User
-	DiscountCodes map[string]float32
-	Receipts      map[string]Receipt
+	// Mu guards every field of the store. Services hold it for the whole
+	// of a request that reads or mutates the store.
+	Mu    sync.RWMutex
+	Train Train
+	Users []*User
+	// DiscountCodes maps coupon codes to the amount they take off a price.
+	DiscountCodes map[string]money.Money
+	// Receipts is the single canonical record of every receipt. It is only
+	// written through the receipt functions of pkg/store, which keep the
+	// user, seat and section indexes below in sync with it.
+	Receipts          map[string]*Receipt
+	ReceiptsByUser    map[string][]string
+	ReceiptsBySeat    map[string][]string
+	ReceiptsBySection map[string][]string
+	// ReceiptsByReference maps booking references to their receipt. Receipts
+	// without a reference are not indexed.
+	ReceiptsByReference map[string][]string
 }
 
-// We want a first class section of the train: section A.  
-// The ticket price would  be $40.
\ No newline at end of file
+// We want a first class section of the train: section A.
+// The ticket price would  be $40.
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	dataStore "grpc-project/pkg/store"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	Store *models.Store
+	// RequireAuth limits the admin RPCs to staff.
+	RequireAuth bool
+	// Bookings publishes the seats that repairs change to its availability
+	// feed. A nil Bookings publishes nothing.
+	Bookings *BookingServer
+}
+
+func (s *AdminServer) CheckStoreInvariants(ctx context.Context, req *pb.CheckStoreInvariantsRequest) (*pb.CheckStoreInvariantsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid Check Store-Invariants Request")
+	}
+	if err := authorizeStaff(ctx, s.RequireAuth); err != nil {
+		return nil, err
+	}
+	s.Store.Mu.Lock()
+	violations := dataStore.CheckInvariants(s.Store, req.Repair)
+	s.Bookings.publishRepairs(violations)
+	s.Store.Mu.Unlock()
+
+	return &pb.CheckStoreInvariantsResponse{
+		Violations: MapViolations(violations),
+	}, nil
+}
+
+// InvariantMonitor periodically checks the store invariants in the
+// background, optionally repairing them, and records the outcome as metrics.
+type InvariantMonitor struct {
+	Store    *models.Store
+	Interval time.Duration
+	Repair   bool
+	// Bookings publishes the seats that repairs change, like
+	// AdminServer.Bookings.
+	Bookings *BookingServer
+
+	checks     prometheus.Counter
+	violations *prometheus.CounterVec
+	repairs    *prometheus.CounterVec
+	lastFound  prometheus.Gauge
+}
+
+func NewInvariantMonitor(store *models.Store, interval time.Duration, repair bool, registerer prometheus.Registerer) *InvariantMonitor {
+	m := &InvariantMonitor{
+		Store:    store,
+		Interval: interval,
+		Repair:   repair,
+		checks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "booking_store_invariant_checks_total",
+			Help: "Number of store invariant checks run by the background monitor.",
+		}),
+		violations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_store_invariant_violations_total",
+			Help: "Number of store invariant violations found, by kind.",
+		}, []string{"kind"}),
+		repairs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_store_invariant_repairs_total",
+			Help: "Number of store invariant violations repaired, by kind.",
+		}, []string{"kind"}),
+		lastFound: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "booking_store_invariant_violations_last_check",
+			Help: "Number of store invariant violations found by the last check.",
+		}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(m.checks, m.violations, m.repairs, m.lastFound)
+	}
+	return m
+}
+
+// Run checks the store every Interval until ctx is cancelled. It returns
+// at once when Interval is not positive, which disables the monitor.
+func (m *InvariantMonitor) Run(ctx context.Context) {
+	if m.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
+
+// Check runs a single invariant check and records its outcome.
+func (m *InvariantMonitor) Check() []dataStore.Violation {
+	m.Store.Mu.Lock()
+	violations := dataStore.CheckInvariants(m.Store, m.Repair)
+	m.Bookings.publishRepairs(violations)
+	m.Store.Mu.Unlock()
+
+	m.checks.Inc()
+	m.lastFound.Set(float64(len(violations)))
+	for _, v := range violations {
+		m.violations.WithLabelValues(v.Kind).Inc()
+		if v.Repaired {
+			m.repairs.WithLabelValues(v.Kind).Inc()
+		}
+		log.Printf("store invariant violated: %s %s: %s (repaired: %v)", v.Kind, v.Subject, v.Detail, v.Repaired)
+	}
+	return violations
+}
+
+// publishRepairs publishes the state of every seat a repair changed, so
+// that watchers of the availability feed see the repaired seat. The caller
+// holds the store lock.
+func (s *BookingServer) publishRepairs(violations []dataStore.Violation) {
+	if s == nil {
+		return
+	}
+	for _, v := range violations {
+		if !v.Repaired || v.Seat == nil {
+			continue
+		}
+		change := pb.SeatChange_SEAT_CHANGE_BOOKED
+		if v.Seat.SeatAvailable {
+			change = pb.SeatChange_SEAT_CHANGE_RELEASED
+		}
+		s.publishSeatChange(change, v.Seat, nil)
+	}
+}
+
+func MapViolations(violations []dataStore.Violation) []*pb.InvariantViolation {
+	var pbViolations []*pb.InvariantViolation
+	for _, v := range violations {
+		pbViolations = append(pbViolations, &pb.InvariantViolation{
+			Kind:     v.Kind,
+			Subject:  v.Subject,
+			Detail:   v.Detail,
+			Repaired: v.Repaired,
+		})
+	}
+	return pbViolations
+}
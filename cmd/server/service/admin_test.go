@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckStoreInvariants(t *testing.T) {
+	store := InitializeStore()
+	adminServer := &AdminServer{
+		Store: store,
+	}
+	ctx := context.Background()
+
+	// The fixture claims 20 free seats per section while only holding five seats.
+	res, err := adminServer.CheckStoreInvariants(ctx, &pb.CheckStoreInvariantsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, res.Violations, 2)
+	for _, v := range res.Violations {
+		assert.Equal(t, dataStore.SectionCounterMismatch, v.Kind)
+		assert.False(t, v.Repaired)
+	}
+
+	res, err = adminServer.CheckStoreInvariants(ctx, &pb.CheckStoreInvariantsRequest{Repair: true})
+	assert.NoError(t, err)
+	assert.Len(t, res.Violations, 2)
+	assert.Equal(t, 4, store.Train.Sections[0].AvailableSeats)
+	assert.Equal(t, 5, store.Train.Sections[1].AvailableSeats)
+
+	res, err = adminServer.CheckStoreInvariants(ctx, &pb.CheckStoreInvariantsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Violations)
+
+	_, err = adminServer.CheckStoreInvariants(ctx, nil)
+	assert.EqualError(t, err, "invalid Check Store-Invariants Request")
+}
+
+func Test_CheckStoreInvariants_PublishesRepairedSeats(t *testing.T) {
+	store := InitializeStore()
+	dataStore.CheckInvariants(store, true)
+	feed := NewAvailabilityFeed(0)
+	adminServer := &AdminServer{Store: store, Bookings: &BookingServer{Store: store, Availability: feed}}
+	w, _, err := feed.subscribe("", "", func() *pb.AvailabilitySnapshot { return &pb.AvailabilitySnapshot{} })
+	assert.NoError(t, err)
+
+	// A free seat taken without a receipt, and the seat of receipt 11 freed.
+	var taken *models.Seat
+	for _, seat := range store.Train.Sections[0].Seats {
+		if seat.SeatAvailable {
+			taken = seat
+			break
+		}
+	}
+	taken.SeatAvailable = false
+	receipt := store.Receipts["11"]
+	freed := dataStore.GetSeat(store, receipt.SeatId, receipt.SectionId)
+	freed.SeatAvailable = true
+	freed.User = nil
+
+	// A check without repair changes nothing to publish.
+	_, err = adminServer.CheckStoreInvariants(context.Background(), &pb.CheckStoreInvariantsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, w.events)
+
+	_, err = adminServer.CheckStoreInvariants(context.Background(), &pb.CheckStoreInvariantsRequest{Repair: true})
+	assert.NoError(t, err)
+	published := map[string]*pb.SeatChangeEvent{}
+	for len(w.events) > 0 {
+		change := (<-w.events).GetChange()
+		published[change.Seat.SeatId] = change
+	}
+	assert.Len(t, published, 2)
+	assert.Equal(t, pb.SeatChange_SEAT_CHANGE_RELEASED, published[taken.Id].GetChange())
+	assert.True(t, published[taken.Id].GetSeat().GetSeatAvailable())
+	assert.Equal(t, pb.SeatChange_SEAT_CHANGE_BOOKED, published[freed.Id].GetChange())
+	assert.False(t, published[freed.Id].GetSeat().GetSeatAvailable())
+}
+
+func Test_BookingFlow_KeepsStoreInvariants(t *testing.T) {
+	store := InitializeStore()
+	dataStore.CheckInvariants(store, true)
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	ctx := context.Background()
+
+	purchase, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	_, err = bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:    purchase.Receipt.ReceiptId,
+		NewSeatId:    store.Train.Sections[1].Seats[0].Id,
+		NewSectionId: store.Train.Sections[1].Id,
+	})
+	assert.NoError(t, err)
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+
+	assert.Empty(t, dataStore.CheckInvariants(store, false))
+}
+
+func Test_InvariantMonitor(t *testing.T) {
+	store := InitializeStore()
+	registry := prometheus.NewRegistry()
+	monitor := NewInvariantMonitor(store, time.Minute, true, registry)
+
+	violations := monitor.Check()
+	assert.Len(t, violations, 2)
+	assert.Empty(t, monitor.Check())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(monitor.checks))
+	assert.Equal(t, 0.0, testutil.ToFloat64(monitor.lastFound))
+	assert.Equal(t, 2.0, testutil.ToFloat64(monitor.violations.WithLabelValues(dataStore.SectionCounterMismatch)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(monitor.repairs.WithLabelValues(dataStore.SectionCounterMismatch)))
+}
+
+func Test_InvariantMonitor_Disabled(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		monitor := NewInvariantMonitor(InitializeStore(), interval, false, prometheus.NewRegistry())
+		// Run returns instead of panicking or checking.
+		monitor.Run(context.Background())
+		assert.Equal(t, 0.0, testutil.ToFloat64(monitor.checks))
+	}
+}
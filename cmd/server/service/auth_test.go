@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Login(t *testing.T) {
+	auth.PasswordCost = bcrypt.MinCost
+	store := InitializeStore()
+	hash, err := auth.HashPassword("bob-password")
+	assert.NoError(t, err)
+	store.Users[1].PasswordHash = hash
+
+	keys, err := auth.GenerateKeySet()
+	assert.NoError(t, err)
+	tokens := &auth.Tokens{Keys: keys, TTL: time.Hour}
+	authServer := &AuthServer{Store: store, Tokens: tokens}
+
+	type test struct {
+		LoginRequest *pb.LoginRequest
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Valid credentials with different email case": {
+			LoginRequest: &pb.LoginRequest{Email: "bobjohnson@GMAIL.com", Password: "bob-password"},
+		},
+		"Sad Path - Wrong password": {
+			LoginRequest: &pb.LoginRequest{Email: "BobJohnson@gmail.com", Password: "alice-password"},
+			ExpectedCode: codes.Unauthenticated,
+		},
+		"Sad Path - User without password": {
+			LoginRequest: &pb.LoginRequest{Email: "AliceSmith@gmaiil.com", Password: "anything"},
+			ExpectedCode: codes.Unauthenticated,
+		},
+		"Sad Path - Unknown user": {
+			LoginRequest: &pb.LoginRequest{Email: "nobody@example.com", Password: "bob-password"},
+			ExpectedCode: codes.Unauthenticated,
+		},
+		"Sad Path - Missing password": {
+			LoginRequest: &pb.LoginRequest{Email: "BobJohnson@gmail.com"},
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := authServer.Login(context.Background(), tc.LoginRequest)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer", res.TokenType)
+			principal, err := tokens.Verify(res.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, &auth.Principal{UserId: "2", Email: "BobJohnson@gmail.com", Role: auth.RoleCustomer}, principal)
+		})
+	}
+}
+
+func Test_Authorization(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, RequireAuth: true}
+	adminServer := &AdminServer{Store: store, RequireAuth: true}
+
+	alice := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "1", Role: auth.RoleCustomer})
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+	agent := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "9", Role: auth.RoleAgent})
+	scopedAgent := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "9", Role: auth.RoleAgent, Scope: auth.ScopeSelf})
+
+	type test struct {
+		Ctx          context.Context
+		Call         func(ctx context.Context) error
+		ExpectedCode codes.Code
+	}
+	showAliceReceipts := func(ctx context.Context) error {
+		_, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "1"})
+		return err
+	}
+	checkInvariants := func(ctx context.Context) error {
+		_, err := adminServer.CheckStoreInvariants(ctx, &pb.CheckStoreInvariantsRequest{})
+		return err
+	}
+	tests := map[string]test{
+		"Happy Path - Customer sees own receipts": {
+			Ctx:  alice,
+			Call: showAliceReceipts,
+		},
+		"Happy Path - Staff sees receipts of a customer": {
+			Ctx:  agent,
+			Call: showAliceReceipts,
+		},
+		"Happy Path - Staff checks store invariants": {
+			Ctx:  agent,
+			Call: checkInvariants,
+		},
+		"Sad Path - Customer sees receipts of another customer": {
+			Ctx:          bob,
+			Call:         showAliceReceipts,
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Customer cancels booking of another customer": {
+			Ctx: bob,
+			Call: func(ctx context.Context) error {
+				_, err := bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+				return err
+			},
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Staff limited to own bookings by the policy": {
+			Ctx:          scopedAgent,
+			Call:         showAliceReceipts,
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Customer checks store invariants": {
+			Ctx:          alice,
+			Call:         checkInvariants,
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Unauthenticated request": {
+			Ctx:          context.Background(),
+			Call:         showAliceReceipts,
+			ExpectedCode: codes.Unauthenticated,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Call(tc.Ctx)
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	dataStore "grpc-project/pkg/store"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultAvailabilityRetain is the number of seat changes an
+// AvailabilityFeed keeps for resuming streams when no other value is given.
+const DefaultAvailabilityRetain = 1024
+
+// watcherBuffer is the number of events a stream may fall behind before it
+// is ended and has to resume.
+const watcherBuffer = 64
+
+// AvailabilityFeed fans the seat changes of the booking service out to the
+// WatchAvailability streams. Every change gets a sequence number and the
+// last changes are kept, so a client that reconnects with the resume token
+// of the last event it saw gets the changes it missed instead of a new
+// snapshot. A nil *AvailabilityFeed publishes nothing.
+type AvailabilityFeed struct {
+	mu sync.Mutex
+	// epoch tells resume tokens of this feed from those of an earlier run of
+	// the server, whose sequence numbers mean nothing to this one.
+	epoch    string
+	seq      uint64
+	retain   int
+	changes  []availabilityChange
+	watchers map[*availabilityWatcher]struct{}
+	closed   bool
+}
+
+type availabilityChange struct {
+	seq    uint64
+	change *pb.SeatChangeEvent
+}
+
+// availabilityWatcher is one WatchAvailability stream or booking session.
+// done is closed with err set when the feed ends the stream.
+type availabilityWatcher struct {
+	sectionId string
+	events    chan *pb.AvailabilityEvent
+	done      chan struct{}
+	err       error
+	// missed is only set for booking sessions, which cannot resume. When a
+	// session falls behind, changes are dropped and missed is signalled
+	// instead of ending the session.
+	missed chan struct{}
+}
+
+// NewAvailabilityFeed returns a feed that keeps the last retain changes for
+// resuming streams.
+func NewAvailabilityFeed(retain int) *AvailabilityFeed {
+	if retain <= 0 {
+		retain = DefaultAvailabilityRetain
+	}
+	epoch := make([]byte, 6)
+	rand.Read(epoch)
+	return &AvailabilityFeed{
+		epoch:    hex.EncodeToString(epoch),
+		retain:   retain,
+		watchers: map[*availabilityWatcher]struct{}{},
+	}
+}
+
+// Close ends all streams, e.g. on shutdown, so clients resume with another
+// server. Later streams are refused.
+func (f *AvailabilityFeed) Close() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for w := range f.watchers {
+		if w.missed != nil {
+			f.end(w, status.Error(codes.Unavailable, "server is shutting down"))
+			continue
+		}
+		f.end(w, status.Error(codes.Unavailable, "server is shutting down, resume the stream"))
+	}
+}
+
+// publish records a seat change and sends it to the streams watching its
+// sections. The caller holds the store's write lock, so changes are
+// published in the order they are applied.
+func (f *AvailabilityFeed) publish(change *pb.SeatChangeEvent) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	f.changes = append(f.changes, availabilityChange{seq: f.seq, change: change})
+	if len(f.changes) > f.retain {
+		f.changes = f.changes[len(f.changes)-f.retain:]
+	}
+	event := f.event(f.seq, change)
+	for w := range f.watchers {
+		if !w.matches(change) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		default:
+			if w.missed != nil {
+				select {
+				case w.missed <- struct{}{}:
+				default:
+				}
+				continue
+			}
+			f.end(w, status.Error(codes.ResourceExhausted, "stream fell behind, resume it with the last resume token"))
+		}
+	}
+}
+
+// subscribe registers a stream and returns the events it starts with: the
+// changes since resumeToken, or a snapshot built by snapshot when the token
+// is empty or the changes are gone. The caller holds the store's read lock
+// so that no change is published between the snapshot and the
+// registration. With a nil snapshot, only later changes are sent.
+func (f *AvailabilityFeed) subscribe(sectionId string, resumeToken string, snapshot func() *pb.AvailabilitySnapshot) (*availabilityWatcher, []*pb.AvailabilityEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	w := newAvailabilityWatcher(sectionId)
+	var initial []*pb.AvailabilityEvent
+	if missed, ok := f.since(resumeToken); ok {
+		for _, c := range missed {
+			if w.matches(c.change) {
+				initial = append(initial, f.event(c.seq, c.change))
+			}
+		}
+	} else if snapshot != nil {
+		initial = []*pb.AvailabilityEvent{{
+			Event:       &pb.AvailabilityEvent_Snapshot{Snapshot: snapshot()},
+			ResumeToken: f.token(f.seq),
+		}}
+	}
+	f.watchers[w] = struct{}{}
+	return w, initial, nil
+}
+
+// subscribeSession registers a booking session for the changes of all
+// sections. Sessions look at the current state of their seats on every
+// change, so when one falls behind it is sent a single signal on missed in
+// place of the dropped changes.
+func (f *AvailabilityFeed) subscribeSession() (*availabilityWatcher, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	w := newAvailabilityWatcher("")
+	w.missed = make(chan struct{}, 1)
+	f.watchers[w] = struct{}{}
+	return w, nil
+}
+
+func newAvailabilityWatcher(sectionId string) *availabilityWatcher {
+	return &availabilityWatcher{
+		sectionId: sectionId,
+		events:    make(chan *pb.AvailabilityEvent, watcherBuffer),
+		done:      make(chan struct{}),
+	}
+}
+
+func (f *AvailabilityFeed) unsubscribe(w *availabilityWatcher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.watchers, w)
+}
+
+// since returns the retained changes after the resume token. It reports
+// false when the token is not from this feed or changes after it were
+// dropped.
+func (f *AvailabilityFeed) since(resumeToken string) ([]availabilityChange, bool) {
+	epoch, seqText, found := strings.Cut(resumeToken, ".")
+	if !found || epoch != f.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || seq > f.seq {
+		return nil, false
+	}
+	oldest := f.seq + 1
+	if len(f.changes) > 0 {
+		oldest = f.changes[0].seq
+	}
+	if seq+1 < oldest {
+		return nil, false
+	}
+	return f.changes[len(f.changes)-int(f.seq-seq):], true
+}
+
+// end removes the watcher and ends its stream with err.
+func (f *AvailabilityFeed) end(w *availabilityWatcher, err error) {
+	delete(f.watchers, w)
+	w.err = err
+	close(w.done)
+}
+
+func (f *AvailabilityFeed) event(seq uint64, change *pb.SeatChangeEvent) *pb.AvailabilityEvent {
+	return &pb.AvailabilityEvent{
+		Event:       &pb.AvailabilityEvent_Change{Change: change},
+		ResumeToken: f.token(seq),
+	}
+}
+
+func (f *AvailabilityFeed) token(seq uint64) string {
+	return fmt.Sprintf("%s.%d", f.epoch, seq)
+}
+
+// matches reports whether the change touches a section the watcher watches.
+// A move between sections is sent to the watchers of both.
+func (w *availabilityWatcher) matches(change *pb.SeatChangeEvent) bool {
+	if w.sectionId == "" {
+		return true
+	}
+	if change.Seat != nil && change.Seat.SectionId == w.sectionId {
+		return true
+	}
+	return change.PreviousSeat != nil && change.PreviousSeat.SectionId == w.sectionId
+}
+
+// WatchAvailability streams a snapshot of the seats of the train, or of one
+// section, followed by every seat change.
+func (s *BookingServer) WatchAvailability(req *pb.WatchAvailabilityRequest, stream pb.BookingService_WatchAvailabilityServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "invalid Watch Availability Request")
+	}
+	if s.Availability == nil {
+		return status.Error(codes.Unimplemented, "availability streaming is not enabled")
+	}
+	ctx := stream.Context()
+
+	unlock := rlockStore(ctx, s.Store)
+	if req.TrainId != "" && req.TrainId != s.Store.Train.Id {
+		unlock()
+		return status.Errorf(codes.NotFound, "train not found for the given Train ID: %s", req.TrainId)
+	}
+	if req.SectionId != "" && dataStore.GetSection(s.Store, req.SectionId) == nil {
+		unlock()
+		return status.Errorf(codes.NotFound, "section not found for the given Section ID: %s", req.SectionId)
+	}
+	w, initial, err := s.Availability.subscribe(req.SectionId, req.ResumeToken, func() *pb.AvailabilitySnapshot {
+		return s.availabilitySnapshot(req.SectionId)
+	})
+	unlock()
+	if err != nil {
+		return err
+	}
+	defer s.Availability.unsubscribe(w)
+
+	for _, event := range initial {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case event := <-w.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-w.done:
+			// Hand over what was queued before the stream was ended, so the
+			// client resumes from the latest token.
+			for {
+				select {
+				case event := <-w.events:
+					if err := stream.Send(event); err != nil {
+						return err
+					}
+				default:
+					return w.err
+				}
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// availabilitySnapshot returns the seats of the section, or of the train
+// when sectionId is empty. The caller holds the store lock.
+func (s *BookingServer) availabilitySnapshot(sectionId string) *pb.AvailabilitySnapshot {
+	snapshot := &pb.AvailabilitySnapshot{TrainId: s.Store.Train.Id}
+	for _, section := range s.Store.Train.Sections {
+		if sectionId != "" && section.Id != sectionId {
+			continue
+		}
+		for _, seat := range section.Seats {
+			snapshot.Seats = append(snapshot.Seats, s.seatState(seat))
+		}
+	}
+	return snapshot
+}
+
+// publishSeatChange publishes the current state of seat, and of
+// previousSeat for a move, to the availability feed.
+func (s *BookingServer) publishSeatChange(change pb.SeatChange, seat *models.Seat, previousSeat *models.Seat) {
+	if s.Availability == nil {
+		return
+	}
+	event := &pb.SeatChangeEvent{Change: change, Seat: s.seatState(seat)}
+	if previousSeat != nil {
+		event.PreviousSeat = s.seatState(previousSeat)
+	}
+	s.Availability.publish(event)
+}
+
+// seatState is the state of the seat including its hold. The caller holds
+// the store lock.
+func (s *BookingServer) seatState(seat *models.Seat) *pb.SeatState {
+	state := seatState(seat)
+	state.Held = s.holds[seat] != nil
+	return state
+}
+
+func seatState(seat *models.Seat) *pb.SeatState {
+	return &pb.SeatState{
+		SeatId:        seat.Id,
+		SeatNumber:    seat.SeatNumber,
+		SectionId:     seat.SectionId,
+		SectionName:   seat.SectionName,
+		SeatAvailable: seat.SeatAvailable,
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startBookingServer serves the booking service over bufconn.
+func startBookingServer(t *testing.T, bookingServer *BookingServer, opts ...grpc.ServerOption) pb.BookingServiceClient {
+	s := grpc.NewServer(opts...)
+	pb.RegisterBookingServiceServer(s, bookingServer)
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewBookingServiceClient(conn)
+}
+
+func watch(t *testing.T, client pb.BookingServiceClient, req *pb.WatchAvailabilityRequest) pb.BookingService_WatchAvailabilityClient {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	stream, err := client.WatchAvailability(ctx, req)
+	assert.NoError(t, err)
+	return stream
+}
+
+func Test_WatchAvailability(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0)}
+	client := startBookingServer(t, bookingServer)
+	ctx := context.Background()
+	sectionId := store.Train.Sections[0].Id
+
+	stream := watch(t, client, &pb.WatchAvailabilityRequest{TrainId: store.Train.Id, SectionId: sectionId})
+	first, err := stream.Recv()
+	assert.NoError(t, err)
+	snapshot := first.GetSnapshot()
+	assert.Equal(t, store.Train.Id, snapshot.TrainId)
+	assert.Len(t, snapshot.Seats, 5)
+	assert.False(t, snapshot.Seats[0].SeatAvailable)
+	assert.True(t, snapshot.Seats[1].SeatAvailable)
+
+	purchase, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From:      "London",
+		To:        "France",
+		User:      &pb.User{UserId: "2", FirstName: "Bob", LastName: "Smith", Email: "BobSmith@gmaiil.com"},
+		PricePaid: 20.0,
+	})
+	assert.NoError(t, err)
+	booked, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.SeatChange_SEAT_CHANGE_BOOKED, booked.GetChange().Change)
+	assert.Equal(t, "2", booked.GetChange().Seat.SeatNumber)
+	assert.False(t, booked.GetChange().Seat.SeatAvailable)
+
+	newSeat := store.Train.Sections[0].Seats[4]
+	_, err = bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:    purchase.Receipt.ReceiptId,
+		NewSeatId:    newSeat.Id,
+		NewSectionId: sectionId,
+	})
+	assert.NoError(t, err)
+	moved, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.SeatChange_SEAT_CHANGE_MOVED, moved.GetChange().Change)
+	assert.Equal(t, "5", moved.GetChange().Seat.SeatNumber)
+	assert.Equal(t, "2", moved.GetChange().PreviousSeat.SeatNumber)
+	assert.True(t, moved.GetChange().PreviousSeat.SeatAvailable)
+
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: purchase.Receipt.ReceiptId})
+	assert.NoError(t, err)
+	released, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.SeatChange_SEAT_CHANGE_RELEASED, released.GetChange().Change)
+	assert.Equal(t, "5", released.GetChange().Seat.SeatNumber)
+	assert.True(t, released.GetChange().Seat.SeatAvailable)
+
+	// A reconnecting client gets the changes it missed, without a snapshot.
+	resumed := watch(t, client, &pb.WatchAvailabilityRequest{SectionId: sectionId, ResumeToken: booked.ResumeToken})
+	for _, expected := range []*pb.AvailabilityEvent{moved, released} {
+		event, err := resumed.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.ResumeToken, event.ResumeToken)
+		assert.Equal(t, expected.GetChange().Change, event.GetChange().Change)
+	}
+
+	// Shutting down ends the streams.
+	bookingServer.Availability.Close()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = resumed.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func Test_WatchAvailability_Start(t *testing.T) {
+	store := InitializeStore()
+	feed := NewAvailabilityFeed(2)
+	client := startBookingServer(t, &BookingServer{Store: store, Availability: feed})
+	seat := store.Train.Sections[0].Seats[1]
+	for i := 0; i < 3; i++ {
+		feed.publish(&pb.SeatChangeEvent{Change: pb.SeatChange_SEAT_CHANGE_BOOKED, Seat: seatState(seat)})
+	}
+
+	type test struct {
+		Request          *pb.WatchAvailabilityRequest
+		ExpectedSnapshot bool
+		ExpectedSeats    int
+		ExpectedToken    string
+		ExpectedCode     codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Snapshot of the train": {
+			Request:          &pb.WatchAvailabilityRequest{},
+			ExpectedSnapshot: true,
+			ExpectedSeats:    10,
+			ExpectedToken:    feed.token(3),
+		},
+		"Happy Path - Resume from a retained change": {
+			Request:       &pb.WatchAvailabilityRequest{ResumeToken: feed.token(1)},
+			ExpectedToken: feed.token(2),
+		},
+		"Happy Path - Resume from a dropped change falls back to a snapshot": {
+			Request:          &pb.WatchAvailabilityRequest{ResumeToken: feed.token(0)},
+			ExpectedSnapshot: true,
+			ExpectedSeats:    10,
+			ExpectedToken:    feed.token(3),
+		},
+		"Happy Path - Token of an earlier server falls back to a snapshot": {
+			Request:          &pb.WatchAvailabilityRequest{ResumeToken: "0123456789ab.2"},
+			ExpectedSnapshot: true,
+			ExpectedSeats:    10,
+			ExpectedToken:    feed.token(3),
+		},
+		"Sad Path - Unknown train": {
+			Request:      &pb.WatchAvailabilityRequest{TrainId: "other"},
+			ExpectedCode: codes.NotFound,
+		},
+		"Sad Path - Unknown section": {
+			Request:      &pb.WatchAvailabilityRequest{SectionId: "other"},
+			ExpectedCode: codes.NotFound,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, err := watch(t, client, tc.Request).Recv()
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedToken, event.ResumeToken)
+			if tc.ExpectedSnapshot {
+				assert.Len(t, event.GetSnapshot().Seats, tc.ExpectedSeats)
+			} else {
+				assert.Equal(t, pb.SeatChange_SEAT_CHANGE_BOOKED, event.GetChange().Change)
+			}
+		})
+	}
+}
+
+func Test_WatchAvailability_SlowClient(t *testing.T) {
+	store := InitializeStore()
+	feed := NewAvailabilityFeed(0)
+	seat := seatState(store.Train.Sections[0].Seats[1])
+	w, _, err := feed.subscribe("", "", func() *pb.AvailabilitySnapshot { return &pb.AvailabilitySnapshot{} })
+	assert.NoError(t, err)
+
+	for i := 0; i <= watcherBuffer; i++ {
+		feed.publish(&pb.SeatChangeEvent{Change: pb.SeatChange_SEAT_CHANGE_BOOKED, Seat: seat})
+	}
+	<-w.done
+	assert.Equal(t, codes.ResourceExhausted, status.Code(w.err))
+	assert.Len(t, w.events, watcherBuffer)
+
+	// The changes the client did not get can still be resumed.
+	missed, ok := feed.since(feed.token(watcherBuffer))
+	assert.True(t, ok)
+	assert.Len(t, missed, 1)
+}
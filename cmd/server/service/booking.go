@@ -5,43 +5,123 @@ import (
 	"fmt"
 	pb "grpc-project/booking/proto"
 	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	"grpc-project/pkg/money"
 	dataStore "grpc-project/pkg/store"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type BookingServer struct {
 	pb.UnimplementedBookingServiceServer
 	Store *models.Store
+	// Idempotency stores the outcome of mutating requests per idempotency
+	// key. Idempotency keys are ignored when it is nil.
+	Idempotency *idempotency.Cache
+	// RequireAuth enables the per-user authorization rules: customers can
+	// only see and change their own bookings, staff can act for everybody.
+	RequireAuth bool
+	// Metrics records bookings, cancellations, seat changes and allocation
+	// failures. Nothing is recorded when it is nil.
+	Metrics *BookingMetrics
+	// MaxBookingsPerUser caps the confirmed bookings a user may hold on the
+	// train at once. Zero means no cap.
+	MaxBookingsPerUser int
+	// Availability streams seat changes to WatchAvailability. The RPC is
+	// unavailable when it is nil.
+	Availability *AvailabilityFeed
+	// Rates converts prices to the currency a customer asks to be charged
+	// in. Bookings can only be charged in the currency of the train when
+	// it is nil.
+	Rates money.RateSource
+	// HoldTTL is how long a booking session may hold a seat before the
+	// hold lapses. Zero means holds last until the session ends.
+	HoldTTL time.Duration
+
+	// holds maps the seats held by booking sessions to their hold. It is
+	// guarded by Store.Mu.
+	holds map[*models.Seat]*seatHold
+
+	// faultHook is handed to every saga so tests can inject failures.
+	faultHook func(saga string, step string) error
 }
 
 func (s *BookingServer) PurchaseBooking(ctx context.Context, req *pb.PurchaseBookingRequest) (*pb.PurchaseBookingResponse, error) {
+	if req == nil {
+		return s.purchaseBooking(ctx, req)
+	}
+	payload := proto.Clone(req).(*pb.PurchaseBookingRequest)
+	payload.IdempotencyKey = ""
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "PurchaseBooking", payload,
+		func(res *pb.PurchaseBookingResponse) string { return res.GetReceipt().GetUser().GetUserId() },
+		func() (*pb.PurchaseBookingResponse, error) { return s.purchaseBooking(ctx, req) })
+}
+func (s *BookingServer) UpdateSeatBooking(ctx context.Context, req *pb.UpdateSeatBookingRequest) (*pb.UpdateSeatBookingResponse, error) {
+	if req == nil {
+		return s.updateSeatBooking(ctx, req)
+	}
+	payload := proto.Clone(req).(*pb.UpdateSeatBookingRequest)
+	payload.IdempotencyKey = ""
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "UpdateSeatBooking", payload,
+		func(res *pb.UpdateSeatBookingResponse) string { return res.GetUpdatedReceipt().GetUser().GetUserId() },
+		func() (*pb.UpdateSeatBookingResponse, error) { return s.updateSeatBooking(ctx, req) })
+}
+func (s *BookingServer) DeleteBooking(ctx context.Context, req *pb.DeleteBookingRequest) (*pb.DeleteBookingResponse, error) {
+	if req == nil {
+		return s.deleteBooking(ctx, req)
+	}
+	payload := proto.Clone(req).(*pb.DeleteBookingRequest)
+	payload.IdempotencyKey = ""
+	userOf := func(res *pb.DeleteBookingResponse) string { return res.GetReceipt().GetUser().GetUserId() }
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "DeleteBooking", payload, userOf,
+		func() (*pb.DeleteBookingResponse, error) { return s.deleteBooking(ctx, req) })
+}
+
+func (s *BookingServer) purchaseBooking(ctx context.Context, req *pb.PurchaseBookingRequest) (*pb.PurchaseBookingResponse, error) {
 
 	//check if request is valid
 	if req == nil || req.User == nil || req.From == "" || req.To == "" {
 		return nil, fmt.Errorf("Invalid Booking Request")
 	}
-	var finalTicketPrice float32
-	var valiDiscountCode bool
 
-	if req.DisocuntCoupon == "" {
-		return nil, fmt.Errorf("please provide valid Discount coupon code")
-	}
+	defer lockStore(ctx, s.Store)()
 
-	valiDiscountCode = dataStore.CheckValidCouponCode(s.Store, req.DisocuntCoupon)
+	finalTicketPrice, err := s.price(ctx, req.Price, req.PricePaid, req.DisocuntCoupon)
+	if err != nil {
+		return nil, err
+	}
+	charged, rate, err := s.charge(finalTicketPrice, req.Currency)
+	if err != nil {
+		return nil, err
+	}
 
-	if valiDiscountCode {
-		discountRate := s.Store.DiscountCodes[req.DisocuntCoupon]
-		finalTicketPrice = req.PricePaid - discountRate
-	} else {
-		return nil, fmt.Errorf("please provide valid Discount code")
+	//Resolve the booking user, new customers are registered with the booking
+	user, register, err := s.ResolveUser(req.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, user.Id); err != nil {
+		return nil, err
+	}
+	if err := s.checkBookingLimit(user.Id, 1); err != nil {
+		return nil, err
 	}
-	user := s.ParseUser(req.User)
 
-	//Allocate the seat in the available section
-	seatId, sectionId := s.AllocateSeat(user)
-	if seatId == "" || sectionId == "" {
-		return nil, fmt.Errorf("No available seats found")
+	//Find the next available seat in the train
+	seat, section, err := s.allocateSeat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reference, err := dataStore.NewReference(s.Store)
+	if err != nil {
+		return nil, err
 	}
 
 	//Create a receipt for the booking
@@ -51,23 +131,54 @@ func (s *BookingServer) PurchaseBooking(ctx context.Context, req *pb.PurchaseBoo
 		To:            req.To,
 		Email:         user.Email,
 		UserId:        user.Id,
-		SeatNumber:    dataStore.GetSeat(s.Store, seatId, sectionId).SeatNumber,
-		SeatId:        seatId,
-		SectionId:     sectionId,
-		SectionName:   dataStore.GetSection(s.Store, sectionId).Name,
+		SeatNumber:    seat.SeatNumber,
+		SeatId:        seat.Id,
+		SectionId:     section.Id,
+		SectionName:   section.Name,
 		Price:         finalTicketPrice,
+		Charged:       charged,
+		Rate:          rate,
 		BookingStatus: "Confirmed",
-	}
-	if finalTicketPrice > 0 {
-		receipt.Price = finalTicketPrice
-	} else {
-		receipt.Price = 0.0
+		Version:       1,
+		BookedAt:      time.Now().UTC(),
+		Reference:     reference,
 	}
 
-	//Update User Store Receipts
-	dataStore.UpdateUserReceipts(s.Store, user.Id, receipt)
-	user.Receipts = append(user.Receipts, receipt)
-	s.Store.Receipts[receipt.Id] = *receipt
+	steps := []SagaStep{
+		{
+			Name:       "reserve-seat",
+			Action:     func() error { return reserveSeat(seat, user) },
+			Compensate: func() { releaseSeat(seat) },
+		},
+		{
+			Name:       "decrement-section-seats",
+			Action:     func() error { section.AvailableSeats--; return nil },
+			Compensate: func() { section.AvailableSeats++ },
+		},
+		{
+			Name:       "store-receipt",
+			Action:     func() error { return dataStore.AddReceipt(s.Store, receipt) },
+			Compensate: func() { dataStore.RemoveReceipt(s.Store, receipt.Id) },
+		},
+	}
+	if register {
+		steps = append([]SagaStep{{
+			Name:       "register-user",
+			Action:     func() error { return dataStore.AddUser(s.Store, user) },
+			Compensate: func() { dataStore.RemoveUser(s.Store, user.Id) },
+		}}, steps...)
+	}
+	purchase := &Saga{
+		Name:  "purchase",
+		Fault: s.faultHook,
+		Steps: steps,
+	}
+	if err := purchase.Run(ctx); err != nil {
+		s.Metrics.AllocationFailed(AllocationSagaFailed)
+		return nil, err
+	}
+	s.Metrics.Booked(receipt.Price, req.DisocuntCoupon)
+	s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_BOOKED, seat, nil)
 
 	//Response structure
 	response := &pb.PurchaseBookingResponse{
@@ -83,8 +194,15 @@ func (s *BookingServer) PurchaseBooking(ctx context.Context, req *pb.PurchaseBoo
 			},
 			Seat:          receipt.SeatNumber,
 			Section:       receipt.SectionName,
-			PricePaid:     receipt.Price,
+			PricePaid:     float32(receipt.Price.Float()),
+			Price:         mapMoney(receipt.Price),
+			Charged:       mapMoney(chargedAmount(receipt)),
+			ExchangeRate:  mapRate(receipt.Rate),
 			BookingStatus: receipt.BookingStatus,
+			Version:       receipt.Version,
+			BookedAt:      formatTime(receipt.BookedAt),
+			Reference:     receipt.Reference,
+			TravelClass:   travelClass(receipt),
 		},
 	}
 
@@ -95,14 +213,37 @@ func (s *BookingServer) ShowReceipt(ctx context.Context, req *pb.ShowReceiptRequ
 	if req == nil || req.UserId == "" {
 		return nil, fmt.Errorf("Invalid Receipt Request")
 	}
+	if err := authorizeUser(ctx, s.RequireAuth, req.UserId); err != nil {
+		return nil, err
+	}
+	filter, err := newReceiptFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newMaskTree((&pb.Receipt{}).ProtoReflect().Descriptor(), req.ReadMask, "readMask")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rlockStore(ctx, s.Store)()
 
 	//Get the user details
 	user := dataStore.GetUser(s.Store, req.UserId)
 	if user == nil {
 		return nil, fmt.Errorf("User not found")
 	}
+	receipts := filter.apply(s.Store, dataStore.GetUserReceipts(s.Store, user.Id))
+	page, next, err := paginate(receipts, receiptKey, req.Order, pageQuery(req), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
 	//map the user receipts to response struct
-	response := s.MapUserReceipts(user.Receipts, user)
+	response := s.MapUserReceipts(page, user)
+	response.NextPageToken = next
+	response.TotalSize = int32(len(receipts))
+	for _, receipt := range response.Receipt {
+		mask.trim(receipt)
+	}
 
 	return response, nil
 }
@@ -110,15 +251,46 @@ func (s *BookingServer) GetSectionBookingDetails(ctx context.Context, req *pb.Ge
 	if req == nil || req.SectionId == "" {
 		return nil, fmt.Errorf("invalid Show Section-Bookings Request")
 	}
+	view, err := seatView(ctx, s.RequireAuth, req.View)
+	if err != nil {
+		return nil, err
+	}
+	principal := auth.PrincipalFromContext(ctx)
+	if _, ok := pb.SeatAvailability_name[int32(req.Availability)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown seat availability %d", req.Availability)
+	}
+	mask, err := newMaskTree((&pb.SeatBooking{}).ProtoReflect().Descriptor(), req.ReadMask, "readMask")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rlockStore(ctx, s.Store)()
+
+	if req.TrainId != "" && req.TrainId != s.Store.Train.Id {
+		return nil, status.Errorf(codes.NotFound, "train not found for the given Train ID: %s", req.TrainId)
+	}
 	section := dataStore.GetSection(s.Store, req.SectionId)
 	if section == nil {
 		return nil, fmt.Errorf("section not found for the given Section ID: %s", req.SectionId)
 	}
-	seatsList := section.Seats
+	var seats []positionedSeat
+	for i, seat := range section.Seats {
+		switch {
+		case req.Availability == pb.SeatAvailability_SEAT_AVAILABILITY_AVAILABLE && !seat.SeatAvailable:
+		case req.Availability == pb.SeatAvailability_SEAT_AVAILABILITY_OCCUPIED && seat.SeatAvailable:
+		default:
+			seats = append(seats, positionedSeat{position: i, seat: seat})
+		}
+	}
+	page, next, err := paginate(seats, positionedSeat.key, req.Order, pageQuery(req), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
 
 	// Map the section seats to response struct
 	var pbSeats []*pb.SeatBooking
-	for _, seat := range seatsList {
+	for _, positioned := range page {
+		seat := positioned.seat
 		seatDetails := &pb.SeatBooking{
 			SeatId:        seat.Id,
 			SeatNumber:    seat.SeatNumber,
@@ -127,150 +299,169 @@ func (s *BookingServer) GetSectionBookingDetails(ctx context.Context, req *pb.Ge
 			SeatAvailable: seat.SeatAvailable,
 		}
 		if seat.User != nil {
-			seatDetails.User = &pb.User{
-				UserId:    seat.User.Id,
-				FirstName: seat.User.FirstName,
-				LastName:  seat.User.LastName,
-				Email:     seat.User.Email,
-			}
+			seatDetails.User = MapSeatUser(seat.User, view, principal)
 		}
+		mask.trim(seatDetails)
 		pbSeats = append(pbSeats, seatDetails)
 	}
 
 	// Create the response structure
 	response := &pb.GetSectionBookingDetailsResponse{
-		SeatBookings: pbSeats,
+		SeatBookings:  pbSeats,
+		NextPageToken: next,
+		TotalSize:     int32(len(seats)),
 	}
 	return response, nil
 }
-func (s *BookingServer) DeleteBooking(ctx context.Context, req *pb.DeleteBookingRequest) (*pb.DeleteBookingResponse, error) {
+func (s *BookingServer) deleteBooking(ctx context.Context, req *pb.DeleteBookingRequest) (*pb.DeleteBookingResponse, error) {
 
 	//check if request is valid
 	if req == nil || req.ReceiptId == "" {
 		return nil, fmt.Errorf("invalid Delete Booking Request")
 	}
 
+	defer lockStore(ctx, s.Store)()
+
 	//validate the receipt
 	receipt, err := dataStore.CheckValidReceipt(s.Store, req.ReceiptId)
 	if err != nil {
 		return nil, fmt.Errorf("receipt not found: %v", err)
 
 	}
+	if err := authorizeUser(ctx, s.RequireAuth, receipt.UserId); err != nil {
+		return nil, err
+	}
 	if receipt.BookingStatus == "Cancelled" {
 		return nil, fmt.Errorf("your booking is already cancelled")
 	}
+	if err := checkReceiptVersion(receipt, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
-	//Get the seat details from the receipt details
+	//Get the seat, section and user details from the receipt details
 	seat := dataStore.GetSeat(s.Store, receipt.SeatId, receipt.SectionId)
-
-	//Reset Seat status to available
-	seat.SeatAvailable = true
-	seat.User = nil
-
-	//Update the section Seat Availability count
 	section := dataStore.GetSection(s.Store, receipt.SectionId)
-	section.AvailableSeats--
-
-	//Update users store Receipts for cancellation
-	user := dataStore.GetUser(s.Store, receipt.UserId)
-	if user != nil {
-		for _, receipt := range user.Receipts {
-			if receipt.Id == req.ReceiptId {
-				receipt.BookingStatus = "Cancelled"
-				break
-			}
-		}
+	if seat == nil || section == nil {
+		return nil, fmt.Errorf("seat not found for the given Receipt ID : %s", receipt.Id)
+	}
+	seatUser := seat.User
+	previous := *receipt
+	cancelled := *receipt
+	cancelled.BookingStatus = "Cancelled"
+	cancelled.Version++
+
+	cancellation := &Saga{
+		Name:  "cancellation",
+		Fault: s.faultHook,
+		Steps: []SagaStep{
+			{
+				Name:       "release-seat",
+				Action:     func() error { releaseSeat(seat); return nil },
+				Compensate: func() { seat.SeatAvailable = false; seat.User = seatUser },
+			},
+			{
+				Name:       "increment-section-seats",
+				Action:     func() error { section.AvailableSeats++; return nil },
+				Compensate: func() { section.AvailableSeats-- },
+			},
+			{
+				Name:       "cancel-receipt",
+				Action:     func() error { return dataStore.UpdateReceipt(s.Store, cancelled) },
+				Compensate: func() { dataStore.UpdateReceipt(s.Store, previous) },
+			},
+		},
 	}
-
-	//Mark booking status in the receipts store
-	dataStore.CancelReceiptsFromStore(s.Store, req.ReceiptId)
+	if err := cancellation.Run(ctx); err != nil {
+		return nil, err
+	}
+	s.Metrics.Cancelled(previous.Price)
+	s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_RELEASED, seat, nil)
 
 	//Response structure
 	response := &pb.DeleteBookingResponse{
 		DeleteStatus: true,
+		Version:      cancelled.Version,
+		Receipt:      MapReceipt(&cancelled, s.receiptUser(&cancelled)),
 	}
 
 	return response, nil
 }
-func (s *BookingServer) UpdateSeatBooking(ctx context.Context, req *pb.UpdateSeatBookingRequest) (*pb.UpdateSeatBookingResponse, error) {
+func (s *BookingServer) updateSeatBooking(ctx context.Context, req *pb.UpdateSeatBookingRequest) (*pb.UpdateSeatBookingResponse, error) {
 
 	if req == nil || req.ReceiptId == "" || req.NewSeatId == "" || req.NewSectionId == "" {
 		return nil, fmt.Errorf("Invalid Update-Seat Booking Request")
 	}
+
+	defer lockStore(ctx, s.Store)()
+
 	receipt, err := dataStore.CheckValidReceipt(s.Store, req.ReceiptId)
 	if err != nil {
 		return nil, fmt.Errorf("receipt not found: %v", err)
 	}
+	if err := authorizeUser(ctx, s.RequireAuth, receipt.UserId); err != nil {
+		return nil, err
+	}
 	if receipt.BookingStatus == "Cancelled" {
 		return nil, fmt.Errorf("your booking is already cancelled, hence cannot update user seat")
 	}
-	user := dataStore.GetUser(s.Store, receipt.UserId)
-	//Check if the new seat is available
-	newSeat := dataStore.GetSeat(s.Store, req.NewSeatId, req.NewSectionId)
-	if newSeat == nil || !newSeat.SeatAvailable {
-		return nil, fmt.Errorf("requested seat is not available")
+	if err := checkReceiptVersion(receipt, req.ExpectedVersion); err != nil {
+		return nil, err
 	}
-	newSeat.SeatAvailable = false
-	newSeat.User = user
-	newSeatSection := dataStore.GetSection(s.Store, newSeat.SectionId)
-	if newSeatSection != nil {
-		newSeatSection.AvailableSeats--
+	user := dataStore.GetUser(s.Store, receipt.UserId)
+	if user == nil {
+		return nil, fmt.Errorf("User not found")
 	}
-
-	//Update the old seat to available
-	oldSeat := dataStore.GetSeat(s.Store, receipt.SeatId, receipt.SectionId)
-	if oldSeat != nil {
-		oldSeat.SeatAvailable = true
-		oldSeat.User = nil
+	move, err := s.seatMove(receipt, user, req.NewSeatId, req.NewSectionId)
+	if err != nil {
+		return nil, err
 	}
+	previous := *receipt
 
-	oldSeatSection := dataStore.GetSection(s.Store, receipt.SectionId)
-	oldSeatSection.AvailableSeats++
+	//Update the receipt with new seat details
+	updated := *receipt
+	move.apply(&updated)
+	updated.Version++
 
-	//Update the receipt with new seat details in the Store
-	receipt.SeatId = newSeat.Id
-	receipt.SeatNumber = newSeat.SeatNumber
-	receipt.SectionId = newSeat.SectionId
-	receipt.SectionName = newSeat.SectionName
-	s.Store.Receipts[receipt.Id] = *receipt
-
-	//Update the Store Receipt receipts in the Store
-	for i, r := range s.Store.Receipts {
-		if r.Id == receipt.Id {
-			s.Store.Receipts[i] = *receipt
-			break
-		}
+	seatChange := &Saga{
+		Name:  "seat-change",
+		Fault: s.faultHook,
+		Steps: append(move.steps(), SagaStep{
+			Name:       "update-receipt",
+			Action:     func() error { return dataStore.UpdateReceipt(s.Store, updated) },
+			Compensate: func() { dataStore.UpdateReceipt(s.Store, previous) },
+		}),
 	}
-
-	//Update the users store receipts
-	for i, user := range s.Store.Users {
-		if user.Id == user.Id {
-			for j, userReceipt := range user.Receipts {
-				if userReceipt.Id == receipt.Id {
-					s.Store.Users[i].Receipts[j] = receipt
-					break
-				}
-			}
-			break
-		}
+	if err := seatChange.Run(ctx); err != nil {
+		s.Metrics.AllocationFailed(AllocationSagaFailed)
+		return nil, err
 	}
+	s.Metrics.SeatChanged()
+	s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_MOVED, move.newSeat, move.oldSeat)
 
 	//Response structure
 	response := &pb.UpdateSeatBookingResponse{
 		UpdatedReceipt: &pb.Receipt{
-			ReceiptId: receipt.Id,
-			From:      receipt.From,
-			To:        receipt.To,
+			ReceiptId: updated.Id,
+			From:      updated.From,
+			To:        updated.To,
 			User: &pb.User{
 				UserId:    user.Id,
 				FirstName: user.FirstName,
 				LastName:  user.LastName,
 				Email:     user.Email,
 			},
-			Seat:          receipt.SeatNumber,
-			Section:       receipt.SectionName,
-			PricePaid:     dataStore.GetPriceFromReceipts(s.Store, receipt.Id),
-			BookingStatus: receipt.BookingStatus,
+			Seat:          updated.SeatNumber,
+			Section:       updated.SectionName,
+			PricePaid:     float32(updated.Price.Float()),
+			Price:         mapMoney(updated.Price),
+			Charged:       mapMoney(chargedAmount(&updated)),
+			ExchangeRate:  mapRate(updated.Rate),
+			BookingStatus: updated.BookingStatus,
+			Version:       updated.Version,
+			BookedAt:      formatTime(updated.BookedAt),
+			Reference:     updated.Reference,
+			TravelClass:   travelClass(&updated),
+			AddOns:        updated.AddOns,
 		},
 	}
 	return response, nil
@@ -281,63 +472,292 @@ func (s *BookingServer) MapUserReceipts(userReceipts []*models.Receipt, user *mo
 	var responseStruct *pb.ShowReceiptResponse
 	var pbReceipts []*pb.Receipt
 	for _, receipt := range userReceipts {
-		pbReceipts = append(pbReceipts, &pb.Receipt{
-			ReceiptId: receipt.Id,
-			From:      receipt.From,
-			To:        receipt.To,
-			User: &pb.User{
-				UserId:    user.Id,
-				FirstName: user.FirstName,
-				LastName:  user.LastName,
-				Email:     user.Email,
-			},
-			Seat:          receipt.SeatNumber,
-			Section:       receipt.SectionName,
-			PricePaid:     dataStore.GetPriceFromReceipts(s.Store, receipt.Id),
-			BookingStatus: receipt.BookingStatus,
-		})
+		pbReceipts = append(pbReceipts, MapReceipt(receipt, user))
 	}
 	responseStruct = &pb.ShowReceiptResponse{
 		Receipt: pbReceipts,
 	}
 	return responseStruct
 }
-func (s *BookingServer) AllocateSeat(user *models.User) (string, string) {
+
+func MapReceipt(receipt *models.Receipt, user *models.User) *pb.Receipt {
+	return &pb.Receipt{
+		ReceiptId: receipt.Id,
+		From:      receipt.From,
+		To:        receipt.To,
+		User: &pb.User{
+			UserId:    user.Id,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Email:     user.Email,
+		},
+		Seat:          receipt.SeatNumber,
+		Section:       receipt.SectionName,
+		PricePaid:     float32(receipt.Price.Float()),
+		Price:         mapMoney(receipt.Price),
+		Charged:       mapMoney(chargedAmount(receipt)),
+		ExchangeRate:  mapRate(receipt.Rate),
+		BookingStatus: receipt.BookingStatus,
+		Version:       receipt.Version,
+		BookedAt:      formatTime(receipt.BookedAt),
+		Reference:     receipt.Reference,
+		TravelClass:   travelClass(receipt),
+		AddOns:        receipt.AddOns,
+	}
+}
+
+func mapMoney(amount money.Money) *pb.Money {
+	return &pb.Money{CurrencyCode: amount.Currency, MinorUnits: amount.Minor}
+}
+
+func mapRate(rate *money.Rate) *pb.ExchangeRate {
+	if rate == nil {
+		return nil
+	}
+	return &pb.ExchangeRate{Version: rate.Version, From: rate.From, To: rate.To, Rate: rate.Value}
+}
+
+// chargedAmount returns the amount the customer was charged.
+func chargedAmount(receipt *models.Receipt) money.Money {
+	if receipt.Charged.Currency == "" {
+		return receipt.Price
+	}
+	return receipt.Charged
+}
+
+func travelClass(receipt *models.Receipt) string {
+	if receipt.Class == "" {
+		return models.ClassStandard
+	}
+	return receipt.Class
+}
+
+// price applies the discount of the coupon, if any, to the fare of the
+// train. The client does not choose the fare: the price it offers, exact
+// when set, otherwise pricePaid rounded to the cent, must be the fare when
+// it offers one. An exact price without a currency is in the currency of
+// the train. The price never goes below zero. The caller must hold the
+// store lock.
+func (s *BookingServer) price(ctx context.Context, exact *pb.Money, pricePaid float32, coupon string) (price money.Money, err error) {
+	_, span := tracer.Start(ctx, "booking.price", trace.WithAttributes(attribute.String("booking.coupon", coupon)))
+	defer func() { endSpan(span, err) }()
+
+	fare := s.Store.Train.Price
+	currency := fare.Currency
+	offered := fare
+	if exact != nil {
+		if exact.CurrencyCode != "" && exact.CurrencyCode != currency {
+			return money.Money{}, status.Errorf(codes.InvalidArgument, "price must be in %s, not %q", currency, exact.CurrencyCode)
+		}
+		offered = money.New(exact.MinorUnits, currency)
+	} else if pricePaid != 0 {
+		if offered, err = money.FromFloat32(pricePaid, currency); err != nil {
+			return money.Money{}, status.Errorf(codes.InvalidArgument, "PricePaid %v is out of range", pricePaid)
+		}
+	}
+	if offered != fare {
+		return money.Money{}, status.Errorf(codes.InvalidArgument, "price %s does not match the fare of %s", offered, fare)
+	}
+	discount := money.Zero(currency)
+	//Apply the discount when a coupon code is provided
+	if coupon != "" {
+		if !dataStore.CheckValidCouponCode(s.Store, coupon) {
+			return money.Money{}, fmt.Errorf("please provide valid Discount code")
+		}
+		discount = s.Store.DiscountCodes[coupon]
+	}
+	price, _, err = money.Discount(fare, discount)
+	return price, err
+}
+
+// charge converts the price to the currency the customer is charged in, the
+// currency of the train when empty. It returns the rate of the conversion,
+// nil when there was nothing to convert.
+func (s *BookingServer) charge(price money.Money, currency string) (money.Money, *money.Rate, error) {
+	if currency == "" || currency == price.Currency {
+		return price, nil, nil
+	}
+	if !money.ValidCurrency(currency) {
+		return money.Money{}, nil, status.Errorf(codes.InvalidArgument, "unknown currency %q", currency)
+	}
+	if s.Rates == nil {
+		return money.Money{}, nil, status.Errorf(codes.InvalidArgument, "bookings can only be charged in %s", price.Currency)
+	}
+	rate, err := s.Rates.Current().Rate(price.Currency, currency)
+	if err != nil {
+		return money.Money{}, nil, status.Errorf(codes.InvalidArgument, "bookings can't be charged in %s: %v", currency, err)
+	}
+	charged, err := rate.Convert(price)
+	if err != nil {
+		return money.Money{}, nil, status.Errorf(codes.InvalidArgument, "price can't be converted to %s: %v", currency, err)
+	}
+	return charged, &rate, nil
+}
+
+// allocateSeat finds the seat for a new booking. The caller must hold the
+// store lock.
+func (s *BookingServer) allocateSeat(ctx context.Context) (seat *models.Seat, section *models.Section, err error) {
+	_, span := tracer.Start(ctx, "booking.allocate-seat")
+	defer func() { endSpan(span, err) }()
+
+	seat, section = s.FindAvailableSeat()
+	if seat == nil {
+		s.Metrics.AllocationFailed(AllocationSoldOut)
+		return nil, nil, fmt.Errorf("No available seats found")
+	}
+	span.SetAttributes(attribute.String("booking.seat_id", seat.Id), attribute.String("booking.section_id", section.Id))
+	return seat, section, nil
+}
+
+func (s *BookingServer) FindAvailableSeat() (*models.Seat, *models.Section) {
 	var sections []*models.Section = dataStore.GetSectionStore(s.Store)
 
 	for _, section := range sections {
 		if section.AvailableSeats > 0 {
 			nextAvailableSeatId := s.GetNextAvailableSeat(section)
 			if nextAvailableSeatId != "" {
-				seat := dataStore.GetSeat(s.Store, nextAvailableSeatId, section.Id)
-				if seat != nil {
-					seat.SeatAvailable = false
-					seat.User = user
-				}
-				section.AvailableSeats--
-				return nextAvailableSeatId, section.Id
+				return dataStore.GetSeat(s.Store, nextAvailableSeatId, section.Id), section
 			}
 		}
 	}
-	return "", ""
+	return nil, nil
 }
 func (s *BookingServer) GetNextAvailableSeat(section *models.Section) string {
 
 	for _, seat := range section.Seats {
-		if seat.SeatAvailable {
+		if seat.SeatAvailable && s.holds[seat] == nil {
 			return seat.Id // Return the first available seat
 		}
 	}
 	return ""
 }
-func (s *BookingServer) ParseUser(user *pb.User) *models.User {
-	if user == nil {
+
+// ResolveUser returns the stored user a booking is made for. When the
+// customer is not known yet a new user is returned together with true, the
+// caller registers it as part of the booking. The caller must hold the store
+// lock.
+func (s *BookingServer) ResolveUser(reqUser *pb.User) (*models.User, bool, error) {
+	if reqUser.UserId != "" {
+		if user := dataStore.GetUser(s.Store, reqUser.UserId); user != nil {
+			return user, false, nil
+		}
+	} else if user := dataStore.GetUserByEmail(s.Store, reqUser.Email); user != nil {
+		return user, false, nil
+	}
+	user, err := NewUser(reqUser)
+	if err != nil {
+		return nil, false, err
+	}
+	if other := dataStore.GetUserByEmail(s.Store, user.Email); other != nil {
+		return nil, false, status.Errorf(codes.AlreadyExists, "user already exists for the given email : %s", user.Email)
+	}
+	return user, true, nil
+}
+
+// checkBookingLimit fails when booking the given number of seats would take
+// the user over MaxBookingsPerUser, so one user cannot buy up the train.
+func (s *BookingServer) checkBookingLimit(userId string, seats int) error {
+	if s.MaxBookingsPerUser <= 0 {
 		return nil
 	}
-	return &models.User{
-		Id:        user.GetUserId(),
-		FirstName: user.GetFirstName(),
-		LastName:  user.GetLastName(),
-		Email:     user.GetEmail(),
+	confirmed := 0
+	for _, receipt := range dataStore.GetUserReceipts(s.Store, userId) {
+		if receipt.BookingStatus == "Confirmed" {
+			confirmed++
+		}
+	}
+	if confirmed+seats > s.MaxBookingsPerUser {
+		return status.Errorf(codes.ResourceExhausted, "user %s already holds %d bookings on this train, the limit is %d", userId, confirmed, s.MaxBookingsPerUser)
+	}
+	return nil
+}
+
+// checkReceiptVersion fails with codes.Aborted when the client expects a
+// different version of the receipt than the stored one. An expected version
+// of 0 skips the check.
+func checkReceiptVersion(receipt *models.Receipt, expectedVersion int64) error {
+	if expectedVersion != 0 && expectedVersion != receipt.Version {
+		return status.Errorf(codes.Aborted, "receipt %s is at version %d, expected version %d", receipt.Id, receipt.Version, expectedVersion)
+	}
+	return nil
+}
+
+// seatMove moves a booking from the seat of its receipt to another seat.
+type seatMove struct {
+	user       *models.User
+	newSeat    *models.Seat
+	newSection *models.Section
+	oldSeat    *models.Seat
+	oldSection *models.Section
+}
+
+// seatMove checks that the booking of receipt can move to the seat. The
+// caller holds the store write lock.
+func (s *BookingServer) seatMove(receipt *models.Receipt, user *models.User, seatId string, sectionId string) (*seatMove, error) {
+	//Check if the new seat is available
+	newSeat := dataStore.GetSeat(s.Store, seatId, sectionId)
+	if newSeat == nil || !newSeat.SeatAvailable || s.holds[newSeat] != nil {
+		s.Metrics.AllocationFailed(AllocationSeatUnavailable)
+		return nil, fmt.Errorf("requested seat is not available")
+	}
+	move := &seatMove{
+		user:       user,
+		newSeat:    newSeat,
+		newSection: dataStore.GetSection(s.Store, sectionId),
+		oldSeat:    dataStore.GetSeat(s.Store, receipt.SeatId, receipt.SectionId),
+		oldSection: dataStore.GetSection(s.Store, receipt.SectionId),
+	}
+	if move.newSection == nil || move.oldSeat == nil || move.oldSection == nil {
+		return nil, fmt.Errorf("seat not found for the given Receipt ID : %s", receipt.Id)
+	}
+	return move, nil
+}
+
+// apply sets the new seat on the receipt.
+func (m *seatMove) apply(receipt *models.Receipt) {
+	receipt.SeatId = m.newSeat.Id
+	receipt.SeatNumber = m.newSeat.SeatNumber
+	receipt.SectionId = m.newSection.Id
+	receipt.SectionName = m.newSection.Name
+}
+
+// steps are the saga steps that move the seat, without updating the
+// receipt.
+func (m *seatMove) steps() []SagaStep {
+	oldSeatUser := m.oldSeat.User
+	return []SagaStep{
+		{
+			Name:       "reserve-new-seat",
+			Action:     func() error { return reserveSeat(m.newSeat, m.user) },
+			Compensate: func() { releaseSeat(m.newSeat) },
+		},
+		{
+			Name:       "decrement-new-section-seats",
+			Action:     func() error { m.newSection.AvailableSeats--; return nil },
+			Compensate: func() { m.newSection.AvailableSeats++ },
+		},
+		{
+			Name:       "release-old-seat",
+			Action:     func() error { releaseSeat(m.oldSeat); return nil },
+			Compensate: func() { m.oldSeat.SeatAvailable = false; m.oldSeat.User = oldSeatUser },
+		},
+		{
+			Name:       "increment-old-section-seats",
+			Action:     func() error { m.oldSection.AvailableSeats++; return nil },
+			Compensate: func() { m.oldSection.AvailableSeats-- },
+		},
+	}
+}
+
+func reserveSeat(seat *models.Seat, user *models.User) error {
+	if !seat.SeatAvailable {
+		return fmt.Errorf("requested seat is not available")
 	}
+	seat.SeatAvailable = false
+	seat.User = user
+	return nil
+}
+func releaseSeat(seat *models.Seat) {
+	seat.SeatAvailable = true
+	seat.User = nil
 }
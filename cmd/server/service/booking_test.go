@@ -5,10 +5,14 @@ import (
 	"fmt"
 	pb "grpc-project/booking/proto"
 	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+	dataStore "grpc-project/pkg/store"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func InitializeStore() *models.Store {
@@ -18,7 +22,7 @@ func InitializeStore() *models.Store {
 			Id:    "123-4567-8901-2345",
 			From:  "London",
 			To:    "France",
-			Price: 20.0,
+			Price: money.New(2000, money.USD),
 			Sections: []*models.Section{
 				{
 					Id:             "S1",
@@ -67,7 +71,7 @@ func InitializeStore() *models.Store {
 				Email:     "BobJohnson@gmail.com",
 			},
 		},
-		Receipts: make(map[string]models.Receipt),
+		Receipts: make(map[string]*models.Receipt),
 	}
 	aliceReceipts := []*models.Receipt{
 		{
@@ -81,12 +85,14 @@ func InitializeStore() *models.Store {
 			SeatId:        store.Train.Sections[0].Seats[0].Id,
 			UserId:        "1",
 			BookingStatus: "Confirmed",
+			Price:         money.New(2000, money.USD),
+			Version:       1,
 		},
 	}
-	// Assign the receipts to the user and the store
-	store.Users[0].Receipts = aliceReceipts
-	store.Train.Sections[0].Seats[0].User.Receipts = aliceReceipts
-	store.Receipts[aliceReceipts[0].Id] = *aliceReceipts[0]
+	// Add the receipts to the receipts store
+	for _, receipt := range aliceReceipts {
+		dataStore.AddReceipt(store, receipt)
+	}
 	return store
 }
 
@@ -125,6 +131,8 @@ func Test_PurchaseBooking(t *testing.T) {
 					Seat:          "1",
 					Section:       "Section 1",
 					PricePaid:     20.0,
+					Price:         &pb.Money{CurrencyCode: "USD", MinorUnits: 2000},
+					Charged:       &pb.Money{CurrencyCode: "USD", MinorUnits: 2000},
 				},
 			},
 		},
@@ -216,7 +224,7 @@ func Test_PurchaseBooking_WhenNoAvailableSeatsOrSections(t *testing.T) {
 			Id:    uuid.New().String(),
 			From:  "London",
 			To:    "France",
-			Price: 20.0,
+			Price: money.New(2000, money.USD),
 			Sections: []*models.Section{
 				{
 					Id:             uuid.New().String(),
@@ -258,7 +266,7 @@ func Test_PurchaseBooking_WhenNoAvailableSeatsOrSections(t *testing.T) {
 				Email:     "BobJohnson@gmail.com",
 			},
 		},
-		Receipts: make(map[string]models.Receipt),
+		Receipts: make(map[string]*models.Receipt),
 	}
 	type test struct {
 		PurchaseRequest  *pb.PurchaseBookingRequest
@@ -346,10 +354,12 @@ func Test_ShowReceipt(t *testing.T) {
 							LastName:  "Smith",
 							Email:     "AliceSmith@gmaiil.com",
 						},
-						Seat:          store.Users[0].Receipts[0].SeatNumber,
-						Section:       store.Users[0].Receipts[0].SectionName,
-						PricePaid:     store.Train.Price,
-						BookingStatus: store.Users[0].Receipts[0].BookingStatus,
+						Seat:          store.Receipts["11"].SeatNumber,
+						Section:       store.Receipts["11"].SectionName,
+						PricePaid:     float32(store.Train.Price.Float()),
+						Price:         &pb.Money{CurrencyCode: store.Train.Price.Currency, MinorUnits: store.Train.Price.Minor},
+						Charged:       &pb.Money{CurrencyCode: store.Train.Price.Currency, MinorUnits: store.Train.Price.Minor},
+						BookingStatus: store.Receipts["11"].BookingStatus,
 					},
 				},
 			},
@@ -570,6 +580,7 @@ func Test_DeleteBooking(t *testing.T) {
 
 			assert.NotNil(t, res)
 			assert.Equal(t, tc.ExpectedResponse.DeleteStatus, res.DeleteStatus, "Message should match")
+			assert.Equal(t, "Cancelled", res.Receipt.BookingStatus)
 			assert.Equal(t, "Cancelled", store.Receipts[tc.DeleteBookingRequest.ReceiptId].BookingStatus, "Booking status should be updated to Cancelled")
 
 		})
@@ -604,7 +615,11 @@ func Test_UpdateSeatBooking(t *testing.T) {
 					Seat:          store.Train.Sections[0].Seats[1].SeatNumber,
 					Section:       store.Train.Sections[0].Name,
 					BookingStatus: "Confirmed",
-					PricePaid:     store.Train.Price,
+					PricePaid:     float32(store.Train.Price.Float()),
+					Price:         &pb.Money{CurrencyCode: store.Train.Price.Currency, MinorUnits: store.Train.Price.Minor},
+					Charged:       &pb.Money{CurrencyCode: store.Train.Price.Currency, MinorUnits: store.Train.Price.Minor},
+					Version:       2,
+					TravelClass:   "Standard",
 				},
 			},
 			ExpectedError: nil,
@@ -674,3 +689,29 @@ func Test_UpdateSeatBooking(t *testing.T) {
 		})
 	}
 }
+
+func Test_PurchaseBooking_BookingLimit(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, MaxBookingsPerUser: 2}
+	ctx := context.Background()
+	purchase := func() (*pb.PurchaseBookingResponse, error) {
+		return bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+			From:      "London",
+			To:        "France",
+			User:      &pb.User{UserId: "1", FirstName: "Alice", LastName: "Smith", Email: "AliceSmith@gmaiil.com"},
+			PricePaid: 20.0,
+		})
+	}
+
+	// Alice already holds receipt 11.
+	_, err := purchase()
+	assert.NoError(t, err)
+	_, err = purchase()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Cancelled bookings do not count.
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+	_, err = purchase()
+	assert.NoError(t, err)
+}
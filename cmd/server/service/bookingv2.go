@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	pbv2 "grpc-project/booking/proto/v2"
+	"grpc-project/cmd/server/models"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BookingServerV2 serves booking.v2.BookingService by translating every
+// call into a call of the version 1 BookingServer, so both versions share
+// the authorization rules, validation, sagas and idempotency keys.
+type BookingServerV2 struct {
+	pbv2.UnimplementedBookingServiceServer
+	V1 *BookingServer
+}
+
+func (s *BookingServerV2) CreateBooking(ctx context.Context, req *pbv2.CreateBookingRequest) (*pbv2.Booking, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Create Booking Request")
+	}
+	res, err := s.V1.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From:           req.Origin,
+		To:             req.Destination,
+		User:           toV1User(req.Passenger),
+		Price:          toV1Money(req.Price),
+		Currency:       req.ChargeCurrency,
+		DisocuntCoupon: req.DiscountCoupon,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toV2Booking(res.Receipt), nil
+}
+
+func (s *BookingServerV2) GetBooking(ctx context.Context, req *pbv2.GetBookingRequest) (*pbv2.Booking, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Get Booking Request")
+	}
+	res, err := s.V1.GetReceipt(ctx, &pb.GetReceiptRequest{ReceiptId: req.BookingId})
+	if err != nil {
+		return nil, err
+	}
+	return toV2Booking(res.Receipt), nil
+}
+
+// ListBookings lists through ShowReceipt for one user and through the staff
+// only ListBookings of version 1 for all users.
+func (s *BookingServerV2) ListBookings(ctx context.Context, req *pbv2.ListBookingsRequest) (*pbv2.ListBookingsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid List Bookings Request")
+	}
+	filter, err := toV1Filter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newMaskTree((&pbv2.Booking{}).ProtoReflect().Descriptor(), req.ReadMask, "read_mask")
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []*pb.Receipt
+	response := &pbv2.ListBookingsResponse{}
+	if req.UserId != "" {
+		res, err := s.V1.ShowReceipt(ctx, &pb.ShowReceiptRequest{
+			UserId:    req.UserId,
+			Filter:    filter,
+			Order:     pb.SortOrder(req.Order),
+			PageSize:  req.PageSize,
+			PageToken: req.PageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		receipts, response.NextPageToken, response.TotalSize = res.Receipt, res.NextPageToken, res.TotalSize
+	} else {
+		res, err := s.V1.ListBookings(ctx, &pb.ListBookingsRequest{
+			Filter:    filter,
+			Order:     pb.SortOrder(req.Order),
+			PageSize:  req.PageSize,
+			PageToken: req.PageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		receipts, response.NextPageToken, response.TotalSize = res.Receipts, res.NextPageToken, res.TotalSize
+	}
+	for _, receipt := range receipts {
+		booking := toV2Booking(receipt)
+		mask.trim(booking)
+		response.Bookings = append(response.Bookings, booking)
+	}
+	return response, nil
+}
+
+// UpdateBooking passes the update mask on as it is: the mask paths of
+// version 1 are matched regardless of case and underscores, so the
+// snake_case field names of version 2 select the same fields.
+func (s *BookingServerV2) UpdateBooking(ctx context.Context, req *pbv2.UpdateBookingRequest) (*pbv2.Booking, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Update Booking Request")
+	}
+	booking := req.Booking
+	update := &pb.BookingUpdate{
+		FirstName:   booking.GetFirstName(),
+		LastName:    booking.GetLastName(),
+		Email:       booking.GetEmail(),
+		TravelClass: toV1Class(booking.GetTravelClass()),
+		AddOns:      booking.GetAddOns(),
+	}
+	if seat := booking.GetSeat(); seat != nil {
+		update.Seat = &pb.SeatRef{SeatId: seat.SeatId, SectionId: seat.SectionId}
+	}
+	res, err := s.V1.UpdateBooking(ctx, &pb.UpdateBookingRequest{
+		ReceiptId:       req.BookingId,
+		Booking:         update,
+		UpdateMask:      req.UpdateMask,
+		ExpectedVersion: req.ExpectedVersion,
+		IdempotencyKey:  req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toV2Booking(res.Receipt), nil
+}
+
+// CancelBooking returns the booking as cancelled, so a replay with the same
+// idempotency key returns it too.
+func (s *BookingServerV2) CancelBooking(ctx context.Context, req *pbv2.CancelBookingRequest) (*pbv2.Booking, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Cancel Booking Request")
+	}
+	res, err := s.V1.DeleteBooking(ctx, &pb.DeleteBookingRequest{
+		ReceiptId:       req.BookingId,
+		ExpectedVersion: req.ExpectedVersion,
+		IdempotencyKey:  req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toV2Booking(res.Receipt), nil
+}
+
+func (s *BookingServerV2) ListSeats(ctx context.Context, req *pbv2.ListSeatsRequest) (*pbv2.ListSeatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid List Seats Request")
+	}
+	mask, err := newMaskTree((&pbv2.Seat{}).ProtoReflect().Descriptor(), req.ReadMask, "read_mask")
+	if err != nil {
+		return nil, err
+	}
+	// The values of the enums are the same in both versions.
+	res, err := s.V1.GetSectionBookingDetails(ctx, &pb.GetSectionBookingDetailsRequest{
+		SectionId:    req.SectionId,
+		TrainId:      req.TrainId,
+		View:         pb.SeatView(req.View),
+		Availability: pb.SeatAvailability(req.Availability),
+		Order:        pb.SortOrder(req.Order),
+		PageSize:     req.PageSize,
+		PageToken:    req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &pbv2.ListSeatsResponse{NextPageToken: res.NextPageToken, TotalSize: res.TotalSize}
+	for _, seatBooking := range res.SeatBookings {
+		seat := &pbv2.Seat{
+			SeatId:      seatBooking.SeatId,
+			SeatNumber:  seatBooking.SeatNumber,
+			SectionId:   seatBooking.SectionId,
+			SectionName: seatBooking.SectionName,
+			Available:   seatBooking.SeatAvailable,
+			Passenger:   toV2Passenger(seatBooking.User),
+		}
+		mask.trim(seat)
+		response.Seats = append(response.Seats, seat)
+	}
+	return response, nil
+}
+
+func toV2Booking(receipt *pb.Receipt) *pbv2.Booking {
+	booking := &pbv2.Booking{
+		BookingId:   receipt.ReceiptId,
+		Reference:   receipt.Reference,
+		Passenger:   toV2Passenger(receipt.User),
+		Origin:      receipt.From,
+		Destination: receipt.To,
+		SectionName: receipt.Section,
+		SeatNumber:  receipt.Seat,
+		PricePaid:   toV2Money(receipt.Charged),
+		Fare:        toV2Money(receipt.Price),
+		TravelClass: pbv2.TravelClass_TRAVEL_CLASS_STANDARD,
+		AddOns:      receipt.AddOns,
+		Version:     receipt.Version,
+	}
+	switch receipt.BookingStatus {
+	case "Confirmed":
+		booking.Status = pbv2.BookingStatus_BOOKING_STATUS_CONFIRMED
+	case "Cancelled":
+		booking.Status = pbv2.BookingStatus_BOOKING_STATUS_CANCELLED
+	}
+	if receipt.TravelClass == models.ClassFirst {
+		booking.TravelClass = pbv2.TravelClass_TRAVEL_CLASS_FIRST
+	}
+	if bookedAt, err := time.Parse(time.RFC3339Nano, receipt.BookedAt); err == nil {
+		booking.BookedAt = timestamppb.New(bookedAt)
+	}
+	if rate := receipt.ExchangeRate; rate != nil {
+		booking.ExchangeRate = &pbv2.ExchangeRate{Version: rate.Version, From: rate.From, To: rate.To, Rate: rate.Rate}
+	}
+	return booking
+}
+
+func toV2Money(amount *pb.Money) *pbv2.Money {
+	return &pbv2.Money{CurrencyCode: amount.GetCurrencyCode(), MinorUnits: amount.GetMinorUnits()}
+}
+
+func toV2Passenger(user *pb.User) *pbv2.Passenger {
+	if user == nil {
+		return nil
+	}
+	return &pbv2.Passenger{
+		UserId:    user.UserId,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}
+}
+
+func toV1User(passenger *pbv2.Passenger) *pb.User {
+	if passenger == nil {
+		return nil
+	}
+	return &pb.User{
+		UserId:    passenger.UserId,
+		FirstName: passenger.FirstName,
+		LastName:  passenger.LastName,
+		Email:     passenger.Email,
+	}
+}
+
+// toV1Class returns the class name of version 1. An unspecified class has
+// no name, which version 1 refuses when the class is to be changed.
+func toV1Class(class pbv2.TravelClass) string {
+	switch class {
+	case pbv2.TravelClass_TRAVEL_CLASS_STANDARD:
+		return models.ClassStandard
+	case pbv2.TravelClass_TRAVEL_CLASS_FIRST:
+		return models.ClassFirst
+	}
+	return ""
+}
+
+func toV1Filter(filter *pbv2.BookingFilter) (*pb.BookingFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	v1 := &pb.BookingFilter{TrainId: filter.TrainId, SectionId: filter.SectionId}
+	switch filter.Status {
+	case pbv2.BookingStatus_BOOKING_STATUS_UNSPECIFIED:
+	case pbv2.BookingStatus_BOOKING_STATUS_CONFIRMED:
+		v1.Status = "Confirmed"
+	case pbv2.BookingStatus_BOOKING_STATUS_CANCELLED:
+		v1.Status = "Cancelled"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown booking status %d", filter.Status)
+	}
+	for _, bound := range []struct {
+		name  string
+		value *timestamppb.Timestamp
+		v1    *string
+	}{
+		{"booked_after", filter.BookedAfter, &v1.BookedAfter},
+		{"booked_before", filter.BookedBefore, &v1.BookedBefore},
+	} {
+		if bound.value == nil {
+			continue
+		}
+		if err := bound.value.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", bound.name, err)
+		}
+		*bound.v1 = formatTime(bound.value.AsTime())
+	}
+	return v1, nil
+}
+
+// toV1Money returns the exact price of version 1. Version 1 takes an
+// amount without a currency code to be in the currency of the train.
+func toV1Money(amount *pbv2.Money) *pb.Money {
+	if amount == nil {
+		return nil
+	}
+	return &pb.Money{CurrencyCode: amount.CurrencyCode, MinorUnits: amount.MinorUnits}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	pbv2 "grpc-project/booking/proto/v2"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	"grpc-project/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_BookingServerV2(t *testing.T) {
+	store := InitializeStore()
+	v1 := &BookingServer{Store: store}
+	v2 := &BookingServerV2{V1: v1}
+	ctx := context.Background()
+	section := store.Train.Sections[0]
+
+	// Without a price the fare of the train is charged.
+	booking, err := v2.CreateBooking(ctx, &pbv2.CreateBookingRequest{
+		Passenger:   &pbv2.Passenger{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		Origin:      "London",
+		Destination: "France",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "London", booking.Origin)
+	assert.Equal(t, "2", booking.SeatNumber)
+	assert.Equal(t, int64(2000), booking.PricePaid.MinorUnits)
+	assert.Equal(t, "USD", booking.PricePaid.CurrencyCode)
+	assert.Equal(t, int64(2000), booking.Fare.MinorUnits)
+	assert.Nil(t, booking.ExchangeRate)
+	assert.Equal(t, pbv2.BookingStatus_BOOKING_STATUS_CONFIRMED, booking.Status)
+	assert.Equal(t, pbv2.TravelClass_TRAVEL_CLASS_STANDARD, booking.TravelClass)
+	assert.WithinDuration(t, time.Now(), booking.BookedAt.AsTime(), time.Minute)
+
+	charged, err := (&BookingServerV2{V1: &BookingServer{Store: InitializeStore(), Rates: &money.RateTable{
+		Version: "7", Base: money.USD, Rates: map[string]string{money.GBP: "0.79"},
+	}}}).CreateBooking(ctx, &pbv2.CreateBookingRequest{
+		Passenger:      &pbv2.Passenger{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		Origin:         "London",
+		Destination:    "France",
+		Price:          &pbv2.Money{MinorUnits: 2000},
+		ChargeCurrency: "GBP",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &pbv2.Money{CurrencyCode: "GBP", MinorUnits: 1580}, charged.PricePaid)
+	assert.Equal(t, &pbv2.Money{CurrencyCode: "USD", MinorUnits: 2000}, charged.Fare)
+	assert.Equal(t, &pbv2.ExchangeRate{Version: "7", From: "USD", To: "GBP", Rate: "0.79"}, charged.ExchangeRate)
+
+	// Bookings are shared with version 1.
+	receipt, err := v1.GetReceipt(ctx, &pb.GetReceiptRequest{ReceiptId: booking.BookingId})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(20), receipt.Receipt.PricePaid)
+
+	// The client cannot choose its own fare.
+	_, err = v2.CreateBooking(ctx, &pbv2.CreateBookingRequest{
+		Passenger:   &pbv2.Passenger{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		Origin:      "London",
+		Destination: "France",
+		Price:       &pbv2.Money{CurrencyCode: "USD", MinorUnits: 1},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updated, err := v2.UpdateBooking(ctx, &pbv2.UpdateBookingRequest{
+		BookingId: booking.BookingId,
+		Booking: &pbv2.BookingUpdate{
+			Seat:        &pbv2.SeatRef{SeatId: section.Seats[4].Id, SectionId: section.Id},
+			TravelClass: pbv2.TravelClass_TRAVEL_CLASS_FIRST,
+			AddOns:      []string{"Meal"},
+		},
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"seat", "travel_class", "add_ons"}},
+		ExpectedVersion: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", updated.SeatNumber)
+	assert.Equal(t, pbv2.TravelClass_TRAVEL_CLASS_FIRST, updated.TravelClass)
+	assert.Equal(t, []string{"Meal"}, updated.AddOns)
+	assert.Equal(t, int64(2), updated.Version)
+
+	list, err := v2.ListBookings(ctx, &pbv2.ListBookingsRequest{
+		UserId:   "2",
+		Filter:   &pbv2.BookingFilter{Status: pbv2.BookingStatus_BOOKING_STATUS_CONFIRMED, BookedAfter: timestamppb.New(time.Now().Add(-time.Hour))},
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"booking_id", "price_paid.minor_units"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), list.TotalSize)
+	assert.Equal(t, booking.BookingId, list.Bookings[0].BookingId)
+	assert.Equal(t, int64(2000), list.Bookings[0].PricePaid.MinorUnits)
+	assert.Empty(t, list.Bookings[0].PricePaid.CurrencyCode)
+	assert.Nil(t, list.Bookings[0].Passenger)
+
+	cancelled, err := v2.CancelBooking(ctx, &pbv2.CancelBookingRequest{BookingId: booking.BookingId, ExpectedVersion: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.BookingStatus_BOOKING_STATUS_CANCELLED, cancelled.Status)
+	assert.Equal(t, int64(3), cancelled.Version)
+
+	seats, err := v2.ListSeats(ctx, &pbv2.ListSeatsRequest{
+		SectionId:    section.Id,
+		View:         pbv2.SeatView_SEAT_VIEW_FULL,
+		Availability: pbv2.SeatAvailability_SEAT_AVAILABILITY_OCCUPIED,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seats.Seats, 1)
+	assert.Equal(t, "1", seats.Seats[0].SeatNumber)
+	assert.False(t, seats.Seats[0].Available)
+	assert.Equal(t, "Alice", seats.Seats[0].Passenger.FirstName)
+}
+
+// Test_BookingServerV2_CancelReplay checks that a replayed cancellation
+// returns the booking as it was cancelled, without reading it again.
+func Test_BookingServerV2_CancelReplay(t *testing.T) {
+	store := InitializeStore()
+	v2 := &BookingServerV2{V1: &BookingServer{Store: store, Idempotency: idempotency.New(time.Hour)}}
+	ctx := context.Background()
+	req := &pbv2.CancelBookingRequest{BookingId: "11", IdempotencyKey: "cancel-11"}
+
+	cancelled, err := v2.CancelBooking(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.BookingStatus_BOOKING_STATUS_CANCELLED, cancelled.Status)
+	assert.Equal(t, "11", cancelled.BookingId)
+
+	delete(store.Receipts, "11")
+	replay, err := v2.CancelBooking(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(cancelled, replay), replay)
+}
+
+func Test_BookingServerV2_Errors(t *testing.T) {
+	store := InitializeStore()
+	v2 := &BookingServerV2{V1: &BookingServer{Store: store, RequireAuth: true}}
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+
+	type test struct {
+		Call         func() error
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Sad Path - Price in another currency": {
+			Call: func() error {
+				_, err := v2.CreateBooking(bob, &pbv2.CreateBookingRequest{
+					Passenger: &pbv2.Passenger{UserId: "2"}, Origin: "London", Destination: "France",
+					Price: &pbv2.Money{CurrencyCode: "EUR", MinorUnits: 2000},
+				})
+				return err
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Charge currency without exchange rates": {
+			Call: func() error {
+				_, err := v2.CreateBooking(bob, &pbv2.CreateBookingRequest{
+					Passenger: &pbv2.Passenger{UserId: "2"}, Origin: "London", Destination: "France",
+					Price: &pbv2.Money{MinorUnits: 2000}, ChargeCurrency: "GBP",
+				})
+				return err
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Booking of another user": {
+			Call: func() error {
+				_, err := v2.GetBooking(bob, &pbv2.GetBookingRequest{BookingId: "11"})
+				return err
+			},
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Bookings of all users as a customer": {
+			Call: func() error {
+				_, err := v2.ListBookings(bob, &pbv2.ListBookingsRequest{})
+				return err
+			},
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Unknown read mask field": {
+			Call: func() error {
+				_, err := v2.ListBookings(bob, &pbv2.ListBookingsRequest{UserId: "2", ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}}})
+				return err
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Unspecified travel class": {
+			Call: func() error {
+				_, err := v2.UpdateBooking(bob, &pbv2.UpdateBookingRequest{
+					BookingId:  "11",
+					Booking:    &pbv2.BookingUpdate{},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"travel_class"}},
+				})
+				return err
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Unknown status in the filter": {
+			Call: func() error {
+				_, err := v2.ListBookings(bob, &pbv2.ListBookingsRequest{UserId: "2", Filter: &pbv2.BookingFilter{Status: 9}})
+				return err
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedCode, status.Code(tc.Call()))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// maskTree is a field mask resolved against a message type. It maps the
+// selected fields to the selected fields of their own message, or to nil
+// when the whole field is selected. An empty tree selects every field.
+type maskTree map[protoreflect.Name]maskTree
+
+// newMaskTree resolves the paths of mask against the message md. Path
+// elements match field names ignoring case and underscores, so proto names,
+// JSON names and the snake_case paths of the JSON mapping of a FieldMask
+// all work. Repeated fields can only be selected as a whole.
+func newMaskTree(md protoreflect.MessageDescriptor, mask *fieldmaskpb.FieldMask, field string) (maskTree, error) {
+	tree := maskTree{}
+	for _, path := range mask.GetPaths() {
+		node, d := tree, md
+		elements := strings.Split(path, ".")
+		for i, element := range elements {
+			if d == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s path %q selects a field inside a field that is not a message", field, path)
+			}
+			fd := findField(d, element)
+			if fd == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s path %q: unknown field %q of %s", field, path, element, d.Name())
+			}
+			child, seen := node[fd.Name()]
+			if i == len(elements)-1 {
+				node[fd.Name()] = nil
+				break
+			}
+			if seen && child == nil {
+				// The whole field is selected already.
+				break
+			}
+			if !seen {
+				child = maskTree{}
+				node[fd.Name()] = child
+			}
+			node, d = child, nil
+			if !fd.IsList() && !fd.IsMap() {
+				d = fd.Message()
+			}
+		}
+	}
+	return tree, nil
+}
+
+func findField(md protoreflect.MessageDescriptor, element string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fold(string(fields.Get(i).Name())) == fold(element) {
+			return fields.Get(i)
+		}
+	}
+	return nil
+}
+
+func fold(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// has reports whether the tree selects the field.
+func (t maskTree) has(name protoreflect.Name) bool {
+	_, ok := t[name]
+	return ok
+}
+
+// trim clears the fields of m the tree does not select.
+func (t maskTree) trim(m proto.Message) {
+	if len(t) == 0 {
+		return
+	}
+	t.trimMessage(m.ProtoReflect())
+}
+
+func (t maskTree) trimMessage(m protoreflect.Message) {
+	var unselected []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := t[fd.Name()]
+		switch {
+		case !ok:
+			unselected = append(unselected, fd)
+		case sub != nil:
+			sub.trimMessage(v.Message())
+		}
+		return true
+	})
+	for _, fd := range unselected {
+		m.Clear(fd)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/idempotency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func newPurchaseRequest(key string) *pb.PurchaseBookingRequest {
+	return &pb.PurchaseBookingRequest{
+		From: "London",
+		To:   "France",
+		User: &pb.User{
+			UserId:    "2",
+			FirstName: "Bob",
+			LastName:  "Johnson",
+			Email:     "BobJohnson@gmail.com",
+		},
+		PricePaid:      20.0,
+		IdempotencyKey: key,
+	}
+}
+
+func Test_PurchaseBooking_IdempotentReplay(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:       store,
+		Idempotency: idempotency.New(time.Hour),
+	}
+	ctx := context.Background()
+
+	first, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest("key-1"))
+	assert.NoError(t, err)
+	availableSeats := store.Train.Sections[0].AvailableSeats
+
+	replay, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest("key-1"))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(first, replay), "replay should return the original response")
+	assert.Equal(t, availableSeats, store.Train.Sections[0].AvailableSeats, "replay should not book another seat")
+	assert.Len(t, store.Receipts, 2)
+
+	other, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest("key-2"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Receipt.ReceiptId, other.Receipt.ReceiptId)
+}
+
+func Test_PurchaseBooking_IdempotencyKeyFromMetadata(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:       store,
+		Idempotency: idempotency.New(time.Hour),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotency.MetadataKey, "key-1"))
+
+	first, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	replay, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	assert.Equal(t, first.Receipt.ReceiptId, replay.Receipt.ReceiptId)
+}
+
+func Test_IdempotencyKey_ReusedWithDifferentPayload(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:       store,
+		Idempotency: idempotency.New(time.Hour),
+	}
+	ctx := context.Background()
+
+	res, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest("key-1"))
+	assert.NoError(t, err)
+
+	changed := newPurchaseRequest("key-1")
+	changed.To = "Paris"
+	_, err = bookingServer.PurchaseBooking(ctx, changed)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{
+		ReceiptId:      res.Receipt.ReceiptId,
+		IdempotencyKey: "key-1",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "key reuse across methods should be rejected")
+}
+
+func Test_DeleteBooking_IdempotentReplay(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:       store,
+		Idempotency: idempotency.New(time.Hour),
+	}
+	ctx := context.Background()
+	req := &pb.DeleteBookingRequest{ReceiptId: "11", IdempotencyKey: "cancel-11"}
+
+	res, err := bookingServer.DeleteBooking(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, res.DeleteStatus)
+
+	// Without the key the second cancellation is rejected, with it the
+	// original outcome is replayed.
+	replay, err := bookingServer.DeleteBooking(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(res, replay), replay)
+	assert.Equal(t, "Cancelled", replay.Receipt.BookingStatus)
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.EqualError(t, err, "your booking is already cancelled")
+}
+
+func Test_UpdateSeatBooking_FailedRequestIsNotRemembered(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:       store,
+		Idempotency: idempotency.New(time.Hour),
+	}
+	ctx := context.Background()
+	req := &pb.UpdateSeatBookingRequest{
+		ReceiptId:      "11",
+		NewSeatId:      store.Train.Sections[0].Seats[1].Id,
+		NewSectionId:   store.Train.Sections[0].Id,
+		IdempotencyKey: "move-11",
+	}
+
+	bookingServer.faultHook = failAt("seat-change", "update-receipt")
+	_, err := bookingServer.UpdateSeatBooking(ctx, req)
+	assert.Error(t, err)
+
+	bookingServer.faultHook = nil
+	res, err := bookingServer.UpdateSeatBooking(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, store.Train.Sections[0].Seats[1].SeatNumber, res.UpdatedReceipt.Seat)
+}
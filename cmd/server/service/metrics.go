@@ -0,0 +1,135 @@
+package service
+
+import (
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons a seat could not be allocated, used as the "reason" label of
+// booking_allocation_failures_total.
+const (
+	AllocationSoldOut         = "sold_out"
+	AllocationSeatUnavailable = "seat_unavailable"
+	AllocationSagaFailed      = "saga_failed"
+)
+
+// BookingMetrics are the business metrics of the booking service. A nil
+// *BookingMetrics records nothing, so servers built without metrics, e.g. in
+// tests, need no special casing.
+type BookingMetrics struct {
+	bookings           prometheus.Counter
+	cancellations      prometheus.Counter
+	seatChanges        prometheus.Counter
+	couponRedemptions  *prometheus.CounterVec
+	revenue            *prometheus.CounterVec
+	refunds            *prometheus.CounterVec
+	allocationFailures *prometheus.CounterVec
+}
+
+func NewBookingMetrics(store *models.Store, registerer prometheus.Registerer) *BookingMetrics {
+	m := &BookingMetrics{
+		bookings: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "booking_bookings_total",
+			Help: "Number of confirmed bookings.",
+		}),
+		cancellations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "booking_cancellations_total",
+			Help: "Number of cancelled bookings.",
+		}),
+		seatChanges: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "booking_seat_changes_total",
+			Help: "Number of bookings moved to another seat.",
+		}),
+		couponRedemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_coupon_redemptions_total",
+			Help: "Number of bookings that redeemed a discount code, by code.",
+		}, []string{"code"}),
+		revenue: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_revenue_total",
+			Help: "Sum of the prices paid for confirmed bookings, by currency.",
+		}, []string{"currency"}),
+		refunds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_refunds_total",
+			Help: "Sum of the prices of cancelled bookings, by currency.",
+		}, []string{"currency"}),
+		allocationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "booking_allocation_failures_total",
+			Help: "Number of bookings and seat changes that could not get a seat, by reason.",
+		}, []string{"reason"}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(m.bookings, m.cancellations, m.seatChanges, m.couponRedemptions,
+			m.revenue, m.refunds, m.allocationFailures, &sectionCollector{store: store})
+	}
+	return m
+}
+
+func (m *BookingMetrics) Booked(price money.Money, coupon string) {
+	if m == nil {
+		return
+	}
+	m.bookings.Inc()
+	m.revenue.WithLabelValues(price.Currency).Add(price.Float())
+	if coupon != "" {
+		m.couponRedemptions.WithLabelValues(coupon).Inc()
+	}
+}
+
+func (m *BookingMetrics) Cancelled(price money.Money) {
+	if m == nil {
+		return
+	}
+	m.cancellations.Inc()
+	m.refunds.WithLabelValues(price.Currency).Add(price.Float())
+}
+
+func (m *BookingMetrics) SeatChanged() {
+	if m == nil {
+		return
+	}
+	m.seatChanges.Inc()
+}
+
+func (m *BookingMetrics) AllocationFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.allocationFailures.WithLabelValues(reason).Inc()
+}
+
+var (
+	sectionSeatsAvailable = prometheus.NewDesc("booking_section_seats_available",
+		"Number of seats available in a section.", []string{"section_id", "section_name"}, nil)
+	sectionSeats = prometheus.NewDesc("booking_section_seats",
+		"Number of seats in a section.", []string{"section_id", "section_name"}, nil)
+	sectionLoadFactor = prometheus.NewDesc("booking_section_load_factor",
+		"Share of the seats of a section that are booked.", []string{"section_id", "section_name"}, nil)
+)
+
+// sectionCollector reports the seat availability of every section at scrape
+// time.
+type sectionCollector struct {
+	store *models.Store
+}
+
+func (c *sectionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sectionSeatsAvailable
+	ch <- sectionSeats
+	ch <- sectionLoadFactor
+}
+
+func (c *sectionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.store.Mu.RLock()
+	defer c.store.Mu.RUnlock()
+	for _, section := range c.store.Train.Sections {
+		total := len(section.Seats)
+		ch <- prometheus.MustNewConstMetric(sectionSeatsAvailable, prometheus.GaugeValue, float64(section.AvailableSeats), section.Id, section.Name)
+		ch <- prometheus.MustNewConstMetric(sectionSeats, prometheus.GaugeValue, float64(total), section.Id, section.Name)
+		if total > 0 {
+			loadFactor := float64(total-section.AvailableSeats) / float64(total)
+			ch <- prometheus.MustNewConstMetric(sectionLoadFactor, prometheus.GaugeValue, loadFactor, section.Id, section.Name)
+		}
+	}
+}
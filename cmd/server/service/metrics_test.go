@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/money"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BookingMetrics(t *testing.T) {
+	store := InitializeStore()
+	store.DiscountCodes = map[string]money.Money{"discount1": money.New(1000, money.USD)}
+	registry := prometheus.NewRegistry()
+	metrics := NewBookingMetrics(store, registry)
+	bookingServer := &BookingServer{Store: store, Metrics: metrics}
+	ctx := context.Background()
+
+	purchase, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From:           "London",
+		To:             "France",
+		User:           &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		PricePaid:      20.0,
+		DisocuntCoupon: "discount1",
+	})
+	assert.NoError(t, err)
+	_, err = bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:    purchase.Receipt.ReceiptId,
+		NewSeatId:    store.Train.Sections[0].Seats[0].Id,
+		NewSectionId: "S1",
+	})
+	assert.Error(t, err)
+	_, err = bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:    purchase.Receipt.ReceiptId,
+		NewSeatId:    store.Train.Sections[0].Seats[4].Id,
+		NewSectionId: "S1",
+	})
+	assert.NoError(t, err)
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.bookings))
+	assert.Equal(t, 10.0, testutil.ToFloat64(metrics.revenue.WithLabelValues(money.USD)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.couponRedemptions.WithLabelValues("discount1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.seatChanges))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.allocationFailures.WithLabelValues(AllocationSeatUnavailable)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.cancellations))
+	assert.Equal(t, 20.0, testutil.ToFloat64(metrics.refunds.WithLabelValues(money.USD)))
+
+	// Section 1 lost a seat to the purchase and gained one from the
+	// cancellation, the seat change stayed within the section.
+	expected := fmt.Sprintf(`
+# HELP booking_section_seats_available Number of seats available in a section.
+# TYPE booking_section_seats_available gauge
+booking_section_seats_available{section_id="S1",section_name="Section 1"} 20
+booking_section_seats_available{section_id="%s",section_name="Section 2"} 20
+`, store.Train.Sections[1].Id)
+	err = testutil.CollectAndCompare(&sectionCollector{store: store}, strings.NewReader(expected), "booking_section_seats_available")
+	assert.NoError(t, err)
+}
+
+func Test_BookingMetrics_SoldOut(t *testing.T) {
+	store := InitializeStore()
+	for _, section := range store.Train.Sections {
+		for _, seat := range section.Seats {
+			seat.SeatAvailable = false
+		}
+	}
+	metrics := NewBookingMetrics(store, prometheus.NewRegistry())
+	bookingServer := &BookingServer{Store: store, Metrics: metrics}
+
+	_, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+		From:      "London",
+		To:        "France",
+		User:      &pb.User{UserId: "2", FirstName: "Bob", Email: "BobJohnson@gmail.com"},
+		PricePaid: 20.0,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.allocationFailures.WithLabelValues(AllocationSoldOut)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.bookings))
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+	dataStore "grpc-project/pkg/store"
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// purchase is a random purchase of Test_Pricing_Reconciles.
+type purchase struct {
+	Cents  uint16
+	Coupon uint8
+	Cancel bool
+}
+
+// Test_Pricing_Reconciles makes random purchases at changing fares, some
+// with coupons, cancels some of them and checks that the receipts and the
+// revenue and refund metrics add up to the cent.
+func Test_Pricing_Reconciles(t *testing.T) {
+	coupons := []string{"", "discount1", "discount2", "discount3"}
+	discounts := map[string]money.Money{
+		"discount1": money.New(1000, money.USD),
+		"discount2": money.New(333, money.USD),
+		"discount3": money.New(99999, money.USD),
+	}
+	// inCents reads a metric of dollars back as cents.
+	inCents := func(c prometheus.Collector) int64 {
+		return int64(math.Round(testutil.ToFloat64(c) * 100))
+	}
+
+	property := func(purchases []purchase) bool {
+		store := InitializeStore()
+		// Stay below the free seats of the fixture, so no purchase fails
+		// for want of a seat.
+		if free := freeSeats(store); len(purchases) > free {
+			purchases = purchases[:free]
+		}
+		store.DiscountCodes = discounts
+		metrics := NewBookingMetrics(store, prometheus.NewRegistry())
+		bookingServer := &BookingServer{Store: store, Metrics: metrics}
+		ctx := context.Background()
+
+		revenue, refunds := money.Zero(money.USD), money.Zero(money.USD)
+		for _, p := range purchases {
+			coupon := coupons[int(p.Coupon)%len(coupons)]
+			fare := money.New(int64(p.Cents), money.USD)
+			store.Train.Price = fare
+			res, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+				From:           "London",
+				To:             "France",
+				User:           &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+				Price:          &pb.Money{CurrencyCode: money.USD, MinorUnits: fare.Minor},
+				DisocuntCoupon: coupon,
+			})
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			charged := money.New(res.Receipt.Price.MinorUnits, res.Receipt.Price.CurrencyCode)
+			expected, _, _ := money.Discount(fare, money.New(discounts[coupon].Minor, money.USD))
+			if charged != expected || float32(charged.Float()) != res.Receipt.PricePaid {
+				t.Logf("charged %s for %s with %q", charged, fare, coupon)
+				return false
+			}
+			revenue, _ = revenue.Add(charged)
+			if p.Cancel {
+				if _, err := bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: res.Receipt.ReceiptId}); err != nil {
+					t.Log(err)
+					return false
+				}
+				// The refund is what the purchase charged.
+				refunds, _ = refunds.Add(charged)
+			}
+		}
+		recordedRevenue, recordedRefunds := inCents(metrics.revenue.WithLabelValues(money.USD)), inCents(metrics.refunds.WithLabelValues(money.USD))
+		if recordedRevenue != revenue.Minor || recordedRefunds != refunds.Minor {
+			t.Logf("revenue %d, refunds %d cents recorded for %s and %s", recordedRevenue, recordedRefunds, revenue, refunds)
+			return false
+		}
+
+		confirmed := money.Zero(money.USD)
+		for _, receipt := range dataStore.GetUserReceipts(store, "2") {
+			if receipt.BookingStatus == "Confirmed" {
+				confirmed, _ = confirmed.Add(receipt.Price)
+			}
+		}
+		net, err := revenue.Sub(refunds)
+		return err == nil && net == confirmed
+	}
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 50}))
+}
+
+func freeSeats(store *models.Store) int {
+	free := 0
+	for _, section := range store.Train.Sections {
+		for _, seat := range section.Seats {
+			if seat.SeatAvailable {
+				free++
+			}
+		}
+	}
+	return free
+}
+
+func Test_Pricing(t *testing.T) {
+	type test struct {
+		Request       *pb.PurchaseBookingRequest
+		ExpectedPrice *pb.Money
+		ExpectedCode  codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Without a price the fare is charged": {
+			Request:       &pb.PurchaseBookingRequest{},
+			ExpectedPrice: &pb.Money{CurrencyCode: money.USD, MinorUnits: 2000},
+		},
+		"Happy Path - Float price is rounded to the cent": {
+			Request:       &pb.PurchaseBookingRequest{PricePaid: 20.004},
+			ExpectedPrice: &pb.Money{CurrencyCode: money.USD, MinorUnits: 2000},
+		},
+		"Happy Path - Exact price is used instead of the float price": {
+			Request:       &pb.PurchaseBookingRequest{PricePaid: 99, Price: &pb.Money{CurrencyCode: money.USD, MinorUnits: 2000}},
+			ExpectedPrice: &pb.Money{CurrencyCode: money.USD, MinorUnits: 2000},
+		},
+		"Happy Path - Discount": {
+			Request:       &pb.PurchaseBookingRequest{PricePaid: 20, DisocuntCoupon: "discount1"},
+			ExpectedPrice: &pb.Money{CurrencyCode: money.USD, MinorUnits: 1000},
+		},
+		"Happy Path - Discount larger than the fare": {
+			Request:       &pb.PurchaseBookingRequest{DisocuntCoupon: "discount2"},
+			ExpectedPrice: &pb.Money{CurrencyCode: money.USD, MinorUnits: 0},
+		},
+		"Sad Path - Float price other than the fare": {
+			Request:      &pb.PurchaseBookingRequest{PricePaid: 5.5},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Exact price other than the fare": {
+			Request:      &pb.PurchaseBookingRequest{PricePaid: 20, Price: &pb.Money{CurrencyCode: money.USD, MinorUnits: 1999}},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Price in another currency": {
+			Request:      &pb.PurchaseBookingRequest{Price: &pb.Money{CurrencyCode: money.EUR, MinorUnits: 2000}},
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := InitializeStore()
+			store.DiscountCodes = map[string]money.Money{"discount1": money.New(1000, money.USD), "discount2": money.New(5000, money.USD)}
+			bookingServer := &BookingServer{Store: store}
+			tc.Request.From, tc.Request.To = "London", "France"
+			tc.Request.User = &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"}
+
+			res, err := bookingServer.PurchaseBooking(context.Background(), tc.Request)
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+			if tc.ExpectedPrice != nil && assert.NotNil(t, res) {
+				assert.Equal(t, tc.ExpectedPrice.MinorUnits, res.Receipt.Price.MinorUnits)
+				assert.Equal(t, tc.ExpectedPrice.CurrencyCode, res.Receipt.Price.CurrencyCode)
+			}
+		})
+	}
+}
+
+func Test_Pricing_ChargeCurrency(t *testing.T) {
+	rates := &money.RateTable{Version: "1", Base: money.USD, Rates: map[string]string{money.EUR: "0.92", money.GBP: "0.79"}}
+
+	type test struct {
+		Rates           money.RateSource
+		Currency        string
+		ExpectedCharged *pb.Money
+		ExpectedRate    *pb.ExchangeRate
+		ExpectedCode    codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Charged in the currency of the train": {
+			Rates:           rates,
+			ExpectedCharged: &pb.Money{CurrencyCode: money.USD, MinorUnits: 1999},
+		},
+		"Happy Path - Charged in another currency": {
+			Rates:           rates,
+			Currency:        money.GBP,
+			ExpectedCharged: &pb.Money{CurrencyCode: money.GBP, MinorUnits: 1579},
+			ExpectedRate:    &pb.ExchangeRate{Version: "1", From: money.USD, To: money.GBP, Rate: "0.79"},
+		},
+		"Sad Path - No exchange rates": {
+			Currency:     money.EUR,
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Currency without a rate": {
+			Rates:        rates,
+			Currency:     money.JPY,
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Unknown currency": {
+			Rates:        rates,
+			Currency:     "XYZ",
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := InitializeStore()
+			store.Train.Price = money.New(1999, money.USD)
+			metrics := NewBookingMetrics(store, prometheus.NewRegistry())
+			bookingServer := &BookingServer{Store: store, Rates: tc.Rates, Metrics: metrics}
+
+			res, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+				From:     "London",
+				To:       "France",
+				User:     &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+				Currency: tc.Currency,
+			})
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+			if tc.ExpectedCharged == nil || !assert.NotNil(t, res) {
+				return
+			}
+			assert.True(t, proto.Equal(tc.ExpectedCharged, res.Receipt.Charged), res.Receipt.Charged)
+			assert.True(t, proto.Equal(tc.ExpectedRate, res.Receipt.ExchangeRate), res.Receipt.ExchangeRate)
+			assert.Equal(t, int64(1999), res.Receipt.Price.MinorUnits)
+			// Revenue is counted in the currency of the train only.
+			assert.Equal(t, 1, testutil.CollectAndCount(metrics.revenue))
+			assert.Equal(t, 19.99, testutil.ToFloat64(metrics.revenue.WithLabelValues(money.USD)))
+		})
+	}
+}
+
+// Test_Pricing_RateSnapshot checks that receipts keep the rate they were
+// charged at when the rates change.
+func Test_Pricing_RateSnapshot(t *testing.T) {
+	rates := &money.RateTable{Version: "1", Base: money.USD, Rates: map[string]string{money.EUR: "0.92"}}
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Rates: rates}
+	ctx := context.Background()
+
+	res, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From: "London", To: "France", PricePaid: 20,
+		User:     &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		Currency: money.EUR,
+	})
+	assert.NoError(t, err)
+	rates.Version, rates.Rates[money.EUR] = "2", "0.5"
+
+	receipt, err := bookingServer.GetReceipt(ctx, &pb.GetReceiptRequest{ReceiptId: res.Receipt.ReceiptId})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1840), receipt.Receipt.Charged.MinorUnits)
+	assert.Equal(t, "1", receipt.Receipt.ExchangeRate.Version)
+	assert.Equal(t, "0.92", receipt.Receipt.ExchangeRate.Rate)
+
+	// Receipts from before charge currencies were charged their price.
+	legacy := store.Receipts["11"]
+	assert.Equal(t, money.Money{}, legacy.Charged)
+	shown, err := bookingServer.GetReceipt(ctx, &pb.GetReceiptRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), shown.Receipt.Charged.MinorUnits)
+	assert.Nil(t, shown.Receipt.ExchangeRate)
+}
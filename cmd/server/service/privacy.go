@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/privacy"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seatView resolves the seat manifest view a caller gets. Staff see the full
+// manifest by default, customers a masked one and may not ask for the full
+// one. Without authentication every caller is trusted like staff.
+func seatView(ctx context.Context, required bool, requested pb.SeatView) (pb.SeatView, error) {
+	if !required {
+		if requested == pb.SeatView_SEAT_VIEW_DEFAULT {
+			return pb.SeatView_SEAT_VIEW_FULL, nil
+		}
+		return requested, nil
+	}
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return 0, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	switch {
+	case requested == pb.SeatView_SEAT_VIEW_DEFAULT && principal.IsStaff():
+		return pb.SeatView_SEAT_VIEW_FULL, nil
+	case requested == pb.SeatView_SEAT_VIEW_DEFAULT:
+		return pb.SeatView_SEAT_VIEW_MASKED, nil
+	case requested == pb.SeatView_SEAT_VIEW_FULL && !principal.IsStaff():
+		return 0, status.Error(codes.PermissionDenied, "staff role required for the full seat view")
+	}
+	return requested, nil
+}
+
+// MapSeatUser returns the occupant of a seat as shown in the given view. The
+// caller's own seats are always shown in full.
+func MapSeatUser(user *models.User, view pb.SeatView, principal *auth.Principal) *pb.User {
+	switch {
+	case view == pb.SeatView_SEAT_VIEW_OCCUPIED:
+		return nil
+	case view == pb.SeatView_SEAT_VIEW_FULL, principal != nil && principal.UserId == user.Id:
+		return MapUser(user)
+	}
+	return &pb.User{
+		FirstName: privacy.MaskName(user.FirstName),
+		LastName:  privacy.MaskName(user.LastName),
+		Email:     privacy.MaskEmail(user.Email),
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	"grpc-project/pkg/money"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_GetSectionBookingDetails_Views(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, RequireAuth: true}
+
+	alice := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "1", Role: auth.RoleCustomer})
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+	agent := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "9", Role: auth.RoleAgent})
+
+	fullAlice := &pb.User{UserId: "1", FirstName: "Alice", LastName: "Smith", Email: "AliceSmith@gmaiil.com"}
+	maskedAlice := &pb.User{FirstName: "A.", LastName: "S.", Email: "A***@gmaiil.com"}
+
+	type test struct {
+		Ctx          context.Context
+		View         pb.SeatView
+		ExpectedUser *pb.User
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Staff sees full manifest by default": {
+			Ctx:          agent,
+			ExpectedUser: fullAlice,
+		},
+		"Happy Path - Customer sees masked manifest by default": {
+			Ctx:          bob,
+			ExpectedUser: maskedAlice,
+		},
+		"Happy Path - Customer sees own seat in full": {
+			Ctx:          alice,
+			View:         pb.SeatView_SEAT_VIEW_MASKED,
+			ExpectedUser: fullAlice,
+		},
+		"Happy Path - Occupied view omits occupants": {
+			Ctx:  bob,
+			View: pb.SeatView_SEAT_VIEW_OCCUPIED,
+		},
+		"Happy Path - Staff asks for masked view": {
+			Ctx:          agent,
+			View:         pb.SeatView_SEAT_VIEW_MASKED,
+			ExpectedUser: maskedAlice,
+		},
+		"Sad Path - Customer asks for full view": {
+			Ctx:          bob,
+			View:         pb.SeatView_SEAT_VIEW_FULL,
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Unauthenticated request": {
+			Ctx:          context.Background(),
+			ExpectedCode: codes.Unauthenticated,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := bookingServer.GetSectionBookingDetails(tc.Ctx, &pb.GetSectionBookingDetailsRequest{SectionId: "S1", View: tc.View})
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			occupied := res.SeatBookings[0]
+			assert.False(t, occupied.SeatAvailable)
+			assert.Equal(t, tc.ExpectedUser.String(), occupied.User.String())
+			for _, seat := range res.SeatBookings[1:] {
+				assert.Nil(t, seat.User)
+			}
+		})
+	}
+}
+
+func Test_ExportAndEraseUser(t *testing.T) {
+	store := InitializeStore()
+	userServer := &UserServer{Store: store}
+	bookingServer := &BookingServer{Store: store}
+	ctx := context.Background()
+
+	export, err := userServer.ExportUserData(ctx, &pb.ExportUserDataRequest{UserId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "AliceSmith@gmaiil.com", export.User.Email)
+	assert.Len(t, export.Receipts, 1)
+	assert.Equal(t, "11", export.Receipts[0].ReceiptId)
+
+	// Active bookings block the erasure.
+	_, err = userServer.EraseUser(ctx, &pb.EraseUserRequest{UserId: "1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+	erased, err := userServer.EraseUser(ctx, &pb.EraseUserRequest{UserId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), erased.AnonymizedReceipts)
+
+	// The account is gone, the receipt is kept without personal data.
+	_, err = userServer.GetUser(ctx, &pb.GetUserRequest{UserId: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, dataStore.GetUserReceipts(store, "1"))
+	receipt := store.Receipts["11"]
+	assert.Equal(t, "", receipt.Email)
+	assert.NotEqual(t, "1", receipt.UserId)
+	assert.Equal(t, money.New(2000, money.USD), receipt.Price)
+	assert.Equal(t, "Cancelled", receipt.BookingStatus)
+	assert.Equal(t, []string{"11"}, store.ReceiptsByUser[receipt.UserId])
+
+	_, err = userServer.ExportUserData(ctx, &pb.ExportUserDataRequest{UserId: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_EraseUser_ForgetsIdempotentResponses(t *testing.T) {
+	type test struct {
+		Principal *auth.Principal
+	}
+	tests := map[string]test{
+		"Happy Path - Booked by staff": {
+			Principal: &auth.Principal{UserId: "9", Role: auth.RoleAgent},
+		},
+		"Happy Path - Booked without authentication": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := InitializeStore()
+			cache := idempotency.New(time.Hour)
+			userServer := &UserServer{Store: store, RequireAuth: tc.Principal != nil, Idempotency: cache}
+			bookingServer := &BookingServer{Store: store, RequireAuth: tc.Principal != nil, Idempotency: cache}
+			ctx := context.Background()
+			if tc.Principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.Principal)
+			}
+			purchase := &pb.PurchaseBookingRequest{
+				From: "London", To: "France", PricePaid: 20, IdempotencyKey: "book-carol",
+				User: &pb.User{UserId: "3", FirstName: "Carol", LastName: "Jones", Email: "carol@example.com"},
+			}
+			booked, err := bookingServer.PurchaseBooking(ctx, purchase)
+			assert.NoError(t, err)
+			replayed, err := bookingServer.PurchaseBooking(ctx, purchase)
+			assert.NoError(t, err)
+			assert.Equal(t, booked.Receipt.ReceiptId, replayed.Receipt.ReceiptId)
+
+			_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{ReceiptId: booked.Receipt.ReceiptId})
+			assert.NoError(t, err)
+			_, err = userServer.EraseUser(ctx, &pb.EraseUserRequest{UserId: "3"})
+			assert.NoError(t, err)
+
+			// The stored response with Carol's details is gone: the retry
+			// is a new booking, not a replay of the erased one.
+			retried, err := bookingServer.PurchaseBooking(ctx, purchase)
+			assert.NoError(t, err)
+			assert.NotEqual(t, booked.Receipt.ReceiptId, retried.Receipt.ReceiptId)
+		})
+	}
+}
+
+func Test_EraseUser_Authorization(t *testing.T) {
+	store := InitializeStore()
+	store.Users = append(store.Users, &models.User{Id: "3", FirstName: "Carol", Email: "carol@example.com"})
+	userServer := &UserServer{Store: store, RequireAuth: true}
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+
+	_, err := userServer.EraseUser(bob, &pb.EraseUserRequest{UserId: "3"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = userServer.ExportUserData(bob, &pb.ExportUserDataRequest{UserId: "3"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = userServer.EraseUser(bob, &pb.EraseUserRequest{UserId: "2"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Page sizes of the paginated lists.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// sortTimeLayout formats times with a fixed width, so that they sort as
+// strings.
+const sortTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// ListBookings returns the receipts of all users that match the filter.
+// Receipts of erased users are listed with their anonymous user ID.
+func (s *BookingServer) ListBookings(ctx context.Context, req *pb.ListBookingsRequest) (*pb.ListBookingsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid List Bookings Request")
+	}
+	if err := authorizeStaff(ctx, s.RequireAuth); err != nil {
+		return nil, err
+	}
+	filter, err := newReceiptFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rlockStore(ctx, s.Store)()
+
+	users := make(map[string]*models.User, len(s.Store.Users))
+	for _, user := range s.Store.Users {
+		users[user.Id] = user
+	}
+	var receipts []*models.Receipt
+	for _, receipt := range s.Store.Receipts {
+		if req.UserId != "" && receipt.UserId != req.UserId {
+			continue
+		}
+		receipts = append(receipts, receipt)
+	}
+	receipts = filter.apply(s.Store, receipts)
+	page, next, err := paginate(receipts, receiptKey, req.Order, pageQuery(req), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListBookingsResponse{
+		NextPageToken: next,
+		TotalSize:     int32(len(receipts)),
+	}
+	for _, receipt := range page {
+		user := users[receipt.UserId]
+		if user == nil {
+			user = &models.User{Id: receipt.UserId}
+		}
+		response.Receipts = append(response.Receipts, MapReceipt(receipt, user))
+	}
+	return response, nil
+}
+
+// receiptFilter is a validated BookingFilter.
+type receiptFilter struct {
+	status    string
+	after     time.Time
+	before    time.Time
+	trainId   string
+	sectionId string
+}
+
+func newReceiptFilter(filter *pb.BookingFilter) (*receiptFilter, error) {
+	f := &receiptFilter{}
+	if filter == nil {
+		return f, nil
+	}
+	switch {
+	case filter.Status == "":
+	case strings.EqualFold(filter.Status, "Confirmed"):
+		f.status = "Confirmed"
+	case strings.EqualFold(filter.Status, "Cancelled"):
+		f.status = "Cancelled"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown booking status %q, expected Confirmed or Cancelled", filter.Status)
+	}
+	var err error
+	if f.after, err = parseFilterTime("bookedAfter", filter.BookedAfter); err != nil {
+		return nil, err
+	}
+	if f.before, err = parseFilterTime("bookedBefore", filter.BookedBefore); err != nil {
+		return nil, err
+	}
+	if !f.after.IsZero() && !f.before.IsZero() && !f.after.Before(f.before) {
+		return nil, status.Error(codes.InvalidArgument, "bookedAfter must be before bookedBefore")
+	}
+	f.trainId = filter.TrainId
+	f.sectionId = filter.SectionId
+	return f, nil
+}
+
+func parseFilterTime(field string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 time: %v", field, err)
+	}
+	return t, nil
+}
+
+// apply returns the matching receipts sorted by booking time. The caller
+// holds the store lock.
+func (f *receiptFilter) apply(store *models.Store, receipts []*models.Receipt) []*models.Receipt {
+	var matched []*models.Receipt
+	for _, receipt := range receipts {
+		if f.matches(store, receipt) {
+			matched = append(matched, receipt)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return receiptKey(matched[i]) < receiptKey(matched[j]) })
+	return matched
+}
+
+func (f *receiptFilter) matches(store *models.Store, receipt *models.Receipt) bool {
+	switch {
+	case f.status != "" && receipt.BookingStatus != f.status:
+		return false
+	case !f.after.IsZero() && receipt.BookedAt.Before(f.after):
+		return false
+	case !f.before.IsZero() && !receipt.BookedAt.Before(f.before):
+		return false
+	case f.trainId != "" && f.trainId != store.Train.Id:
+		// All receipts are for the one train of the store.
+		return false
+	case f.sectionId != "" && receipt.SectionId != f.sectionId:
+		return false
+	}
+	return true
+}
+
+// receiptKey orders receipts by booking time. Receipts without one come
+// first, in the order of their IDs.
+func receiptKey(receipt *models.Receipt) string {
+	return receipt.BookedAt.UTC().Format(sortTimeLayout) + "/" + receipt.Id
+}
+
+// positionedSeat is a seat of a section manifest with its position in the
+// section, which orders the manifest.
+type positionedSeat struct {
+	position int
+	seat     *models.Seat
+}
+
+func (p positionedSeat) key() string {
+	return fmt.Sprintf("%08d", p.position)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// pageCursor is the content of a page token: the key of the last item
+// returned and a fingerprint of the query, so that a token is not used with
+// another filter or order.
+type pageCursor struct {
+	Query string `json:"q"`
+	After string `json:"a"`
+}
+
+// paginate returns the page of items after the page token and the token of
+// the next page, empty on the last page. items are sorted ascending by key,
+// keys are unique. The cursor is a key rather than an offset, so items added
+// or removed between the calls do not shift the pages.
+func paginate[T any](items []T, key func(T) string, order pb.SortOrder, query string, pageSize int32, pageToken string) ([]T, string, error) {
+	size := DefaultPageSize
+	switch {
+	case pageSize < 0:
+		return nil, "", status.Error(codes.InvalidArgument, "pageSize must not be negative")
+	case pageSize > MaxPageSize:
+		size = MaxPageSize
+	case pageSize > 0:
+		size = int(pageSize)
+	}
+	descending := false
+	switch order {
+	case pb.SortOrder_SORT_ORDER_DEFAULT, pb.SortOrder_SORT_ORDER_ASCENDING:
+	case pb.SortOrder_SORT_ORDER_DESCENDING:
+		descending = true
+	default:
+		return nil, "", status.Errorf(codes.InvalidArgument, "unknown sort order %d", order)
+	}
+
+	start := 0
+	if descending {
+		start = len(items)
+	}
+	if pageToken != "" {
+		after, err := decodePageToken(pageToken, query)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(len(items), func(i int) bool { return key(items[i]) > after })
+		if descending {
+			start = sort.Search(len(items), func(i int) bool { return key(items[i]) >= after })
+		}
+	}
+
+	var page []T
+	if descending {
+		for i := start - 1; i >= 0 && len(page) < size; i-- {
+			page = append(page, items[i])
+		}
+		if start-len(page) > 0 {
+			return page, encodePageToken(query, key(page[len(page)-1])), nil
+		}
+		return page, "", nil
+	}
+	end := min(start+size, len(items))
+	page = items[start:end]
+	if end < len(items) {
+		return page, encodePageToken(query, key(page[len(page)-1])), nil
+	}
+	return page, "", nil
+}
+
+func encodePageToken(query string, after string) string {
+	data, _ := json.Marshal(pageCursor{Query: query, After: after})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, query string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	var cursor pageCursor
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	if cursor.Query != query {
+		return "", status.Error(codes.InvalidArgument, "page token belongs to a different query")
+	}
+	return cursor.After, nil
+}
+
+// pageQuery fingerprints a list request without its pageSize, pageToken and
+// readMask, so a page token is only accepted for the query it was issued
+// for.
+func pageQuery(req proto.Message) string {
+	query := proto.Clone(req)
+	fields := query.ProtoReflect().Descriptor().Fields()
+	for _, name := range []protoreflect.Name{"pageSize", "pageToken", "readMask"} {
+		if field := fields.ByName(name); field != nil {
+			query.ProtoReflect().Clear(field)
+		}
+	}
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
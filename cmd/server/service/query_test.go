@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var bookingDay = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// addReceipts books Bob, user "2", onto the free seats of the first section,
+// one hour apart, and cancels every second booking.
+func addReceipts(t *testing.T, store *models.Store) []*models.Receipt {
+	section := store.Train.Sections[0]
+	var receipts []*models.Receipt
+	for i, seat := range section.Seats[1:] {
+		receipt := &models.Receipt{
+			Id:            fmt.Sprintf("bob-%d", i),
+			UserId:        "2",
+			SeatId:        seat.Id,
+			SeatNumber:    seat.SeatNumber,
+			SectionId:     section.Id,
+			SectionName:   section.Name,
+			BookingStatus: "Confirmed",
+			Version:       1,
+			BookedAt:      bookingDay.Add(time.Duration(i) * time.Hour),
+		}
+		if i%2 == 1 {
+			receipt.BookingStatus = "Cancelled"
+		}
+		assert.NoError(t, dataStore.AddReceipt(store, receipt))
+		receipts = append(receipts, receipt)
+	}
+	return receipts
+}
+
+func Test_ShowReceipt_Pagination(t *testing.T) {
+	store := InitializeStore()
+	addReceipts(t, store)
+	bookingServer := &BookingServer{Store: store}
+
+	type test struct {
+		Order       pb.SortOrder
+		ExpectedIds []string
+	}
+	tests := map[string]test{
+		"Happy Path - Oldest first": {
+			ExpectedIds: []string{"bob-0", "bob-1", "bob-2", "bob-3"},
+		},
+		"Happy Path - Newest first": {
+			Order:       pb.SortOrder_SORT_ORDER_DESCENDING,
+			ExpectedIds: []string{"bob-3", "bob-2", "bob-1", "bob-0"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &pb.ShowReceiptRequest{UserId: "2", Order: tc.Order, PageSize: 3}
+			var ids []string
+			for pages := 0; ; pages++ {
+				res, err := bookingServer.ShowReceipt(context.Background(), req)
+				assert.NoError(t, err)
+				assert.Equal(t, int32(4), res.TotalSize)
+				for _, receipt := range res.Receipt {
+					ids = append(ids, receipt.ReceiptId)
+				}
+				if res.NextPageToken == "" {
+					assert.Equal(t, 1, pages)
+					break
+				}
+				req.PageToken = res.NextPageToken
+			}
+			assert.Equal(t, tc.ExpectedIds, ids)
+		})
+	}
+}
+
+func Test_ShowReceipt_PageToken(t *testing.T) {
+	store := InitializeStore()
+	receipts := addReceipts(t, store)
+	bookingServer := &BookingServer{Store: store}
+	ctx := context.Background()
+
+	first, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "2", PageSize: 2})
+	assert.NoError(t, err)
+	assert.Len(t, first.Receipt, 2)
+
+	// A booking before the cursor does not shift the next page.
+	early := *receipts[0]
+	early.Id = "bob-early"
+	early.BookedAt = bookingDay.Add(-time.Hour)
+	assert.NoError(t, dataStore.AddReceipt(store, &early))
+	second, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "2", PageSize: 2, PageToken: first.NextPageToken})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob-2", second.Receipt[0].ReceiptId)
+	assert.Equal(t, bookingDay.Add(2*time.Hour).Format(time.RFC3339Nano), second.Receipt[0].BookedAt)
+
+	// The token only works for the query it was issued for.
+	_, err = bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{
+		UserId: "2", PageSize: 2, PageToken: first.NextPageToken,
+		Filter: &pb.BookingFilter{Status: "Confirmed"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "2", PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_ListBookings(t *testing.T) {
+	store := InitializeStore()
+	addReceipts(t, store)
+	bookingServer := &BookingServer{Store: store, RequireAuth: true}
+	agent := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "9", Role: auth.RoleAgent})
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+
+	type test struct {
+		Ctx          context.Context
+		Request      *pb.ListBookingsRequest
+		ExpectedIds  []string
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - All bookings": {
+			Ctx:         agent,
+			Request:     &pb.ListBookingsRequest{},
+			ExpectedIds: []string{"11", "bob-0", "bob-1", "bob-2", "bob-3"},
+		},
+		"Happy Path - Confirmed bookings of a user": {
+			Ctx:         agent,
+			Request:     &pb.ListBookingsRequest{UserId: "2", Filter: &pb.BookingFilter{Status: "confirmed"}},
+			ExpectedIds: []string{"bob-0", "bob-2"},
+		},
+		"Happy Path - Booking time range": {
+			Ctx: agent,
+			Request: &pb.ListBookingsRequest{Filter: &pb.BookingFilter{
+				BookedAfter:  bookingDay.Add(time.Hour).Format(time.RFC3339),
+				BookedBefore: bookingDay.Add(3 * time.Hour).Format(time.RFC3339),
+			}},
+			ExpectedIds: []string{"bob-1", "bob-2"},
+		},
+		"Happy Path - Train and section": {
+			Ctx: agent,
+			Request: &pb.ListBookingsRequest{
+				Filter: &pb.BookingFilter{TrainId: store.Train.Id, SectionId: store.Train.Sections[0].Id},
+				Order:  pb.SortOrder_SORT_ORDER_DESCENDING,
+			},
+			ExpectedIds: []string{"bob-3", "bob-2", "bob-1", "bob-0", "11"},
+		},
+		"Happy Path - Other train": {
+			Ctx:     agent,
+			Request: &pb.ListBookingsRequest{Filter: &pb.BookingFilter{TrainId: "other"}},
+		},
+		"Sad Path - Unknown status": {
+			Ctx:          agent,
+			Request:      &pb.ListBookingsRequest{Filter: &pb.BookingFilter{Status: "Pending"}},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Malformed time": {
+			Ctx:          agent,
+			Request:      &pb.ListBookingsRequest{Filter: &pb.BookingFilter{BookedAfter: "yesterday"}},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Negative page size": {
+			Ctx:          agent,
+			Request:      &pb.ListBookingsRequest{PageSize: -1},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Customer": {
+			Ctx:          bob,
+			Request:      &pb.ListBookingsRequest{UserId: "2"},
+			ExpectedCode: codes.PermissionDenied,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := bookingServer.ListBookings(tc.Ctx, tc.Request)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			var ids []string
+			for _, receipt := range res.Receipts {
+				ids = append(ids, receipt.ReceiptId)
+			}
+			assert.Equal(t, tc.ExpectedIds, ids)
+			assert.Equal(t, int32(len(tc.ExpectedIds)), res.TotalSize)
+		})
+	}
+}
+
+func Test_GetSectionBookingDetails_Query(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store}
+	sectionId := store.Train.Sections[0].Id
+
+	type test struct {
+		Request            *pb.GetSectionBookingDetailsRequest
+		ExpectedSeats      []string
+		ExpectedTotal      int32
+		ExpectedNextPage   bool
+		ExpectedCode       codes.Code
+		ExpectedSecondPage []string
+	}
+	tests := map[string]test{
+		"Happy Path - Occupied seats": {
+			Request:       &pb.GetSectionBookingDetailsRequest{SectionId: sectionId, Availability: pb.SeatAvailability_SEAT_AVAILABILITY_OCCUPIED},
+			ExpectedSeats: []string{"1"},
+			ExpectedTotal: 1,
+		},
+		"Happy Path - Available seats, last first, paginated": {
+			Request: &pb.GetSectionBookingDetailsRequest{
+				SectionId:    sectionId,
+				TrainId:      store.Train.Id,
+				Availability: pb.SeatAvailability_SEAT_AVAILABILITY_AVAILABLE,
+				Order:        pb.SortOrder_SORT_ORDER_DESCENDING,
+				PageSize:     3,
+			},
+			ExpectedSeats:      []string{"5", "4", "3"},
+			ExpectedTotal:      4,
+			ExpectedNextPage:   true,
+			ExpectedSecondPage: []string{"2"},
+		},
+		"Sad Path - Other train": {
+			Request:      &pb.GetSectionBookingDetailsRequest{SectionId: sectionId, TrainId: "other"},
+			ExpectedCode: codes.NotFound,
+		},
+		"Sad Path - Unknown availability": {
+			Request:      &pb.GetSectionBookingDetailsRequest{SectionId: sectionId, Availability: 7},
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	seatNumbers := func(res *pb.GetSectionBookingDetailsResponse) []string {
+		var numbers []string
+		for _, seat := range res.SeatBookings {
+			numbers = append(numbers, seat.SeatNumber)
+		}
+		return numbers
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := bookingServer.GetSectionBookingDetails(context.Background(), tc.Request)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedSeats, seatNumbers(res))
+			assert.Equal(t, tc.ExpectedTotal, res.TotalSize)
+			assert.Equal(t, tc.ExpectedNextPage, res.NextPageToken != "")
+			if tc.ExpectedNextPage {
+				tc.Request.PageToken = res.NextPageToken
+				res, err = bookingServer.GetSectionBookingDetails(context.Background(), tc.Request)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedSecondPage, seatNumbers(res))
+				assert.Empty(t, res.NextPageToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertReceiptsAgree checks that ShowReceipt, the receipts store and its
+// indexes all report the same receipts for a user.
+func assertReceiptsAgree(t *testing.T, bookingServer *BookingServer, userId string) {
+	t.Helper()
+	show, err := bookingServer.ShowReceipt(context.Background(), &pb.ShowReceiptRequest{UserId: userId})
+	assert.NoError(t, err)
+	store := bookingServer.Store
+	assert.Len(t, show.Receipt, len(store.ReceiptsByUser[userId]))
+	for _, shown := range show.Receipt {
+		stored := store.Receipts[shown.ReceiptId]
+		if !assert.NotNil(t, stored) {
+			continue
+		}
+		assert.Equal(t, stored.SeatNumber, shown.Seat)
+		assert.Equal(t, stored.SectionName, shown.Section)
+		assert.Equal(t, stored.BookingStatus, shown.BookingStatus)
+		assert.Equal(t, stored.Version, shown.Version)
+		assert.Equal(t, float32(stored.Price.Float()), shown.PricePaid)
+		assert.Equal(t, stored.Price.Minor, shown.Price.MinorUnits)
+		assert.Equal(t, dataStore.GetPriceFromReceipts(store, stored.Id), stored.Price)
+		assert.Contains(t, store.ReceiptsBySeat[stored.SeatId], stored.Id)
+		assert.Contains(t, store.ReceiptsBySection[stored.SectionId], stored.Id)
+	}
+	assert.Empty(t, dataStore.CheckInvariants(store, false))
+}
+
+func Test_Receipts_PurchaseIsRecordedOnce(t *testing.T) {
+	store := InitializeStore()
+	dataStore.CheckInvariants(store, true)
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+
+	res, err := bookingServer.PurchaseBooking(context.Background(), newPurchaseRequest(""))
+	assert.NoError(t, err)
+
+	// PurchaseBooking used to append the receipt to the user twice.
+	assert.Equal(t, []string{res.Receipt.ReceiptId}, store.ReceiptsByUser["2"])
+	assertReceiptsAgree(t, bookingServer, "2")
+	assertReceiptsAgree(t, bookingServer, "1")
+}
+
+func Test_Receipts_SeatChangeMovesIndexes(t *testing.T) {
+	store := InitializeStore()
+	dataStore.CheckInvariants(store, true)
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	oldSeat := store.Train.Sections[0].Seats[0]
+	newSeat := store.Train.Sections[1].Seats[2]
+
+	// UpdateSeatBooking used to update the receipts of the first user in the
+	// store instead of the receipt owner.
+	res, err := bookingServer.UpdateSeatBooking(context.Background(), &pb.UpdateSeatBookingRequest{
+		ReceiptId:    "11",
+		NewSeatId:    newSeat.Id,
+		NewSectionId: store.Train.Sections[1].Id,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, newSeat.SeatNumber, res.UpdatedReceipt.Seat)
+
+	assert.NotContains(t, store.ReceiptsBySeat[oldSeat.Id], "11")
+	assert.Equal(t, []*models.Receipt{store.Receipts["11"]}, dataStore.GetSeatReceipts(store, newSeat.Id))
+	assert.Equal(t, []*models.Receipt{store.Receipts["11"]}, dataStore.GetSectionReceipts(store, store.Train.Sections[1].Id))
+	assertReceiptsAgree(t, bookingServer, "1")
+}
+
+func Test_Receipts_CancellationIsVisibleEverywhere(t *testing.T) {
+	store := InitializeStore()
+	dataStore.CheckInvariants(store, true)
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+
+	_, err := bookingServer.DeleteBooking(context.Background(), &pb.DeleteBookingRequest{ReceiptId: "11"})
+	assert.NoError(t, err)
+
+	show, err := bookingServer.ShowReceipt(context.Background(), &pb.ShowReceiptRequest{UserId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Cancelled", show.Receipt[0].BookingStatus)
+	assertReceiptsAgree(t, bookingServer, "1")
+}
+
+func Test_Receipts_CopiesDoNotLeakIntoTheStore(t *testing.T) {
+	store := InitializeStore()
+
+	receipt, err := dataStore.CheckValidReceipt(store, "11")
+	assert.NoError(t, err)
+	receipt.BookingStatus = "Cancelled"
+	receipt.Price = money.Zero(money.USD)
+
+	assert.Equal(t, "Confirmed", store.Receipts["11"].BookingStatus)
+	assert.Equal(t, money.New(2000, money.USD), dataStore.GetPriceFromReceipts(store, "11"))
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	dataStore "grpc-project/pkg/store"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *BookingServer) GetReceipt(ctx context.Context, req *pb.GetReceiptRequest) (*pb.GetReceiptResponse, error) {
+	if req == nil || req.ReceiptId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Get Receipt Request")
+	}
+
+	defer rlockStore(ctx, s.Store)()
+
+	receipt := s.Store.Receipts[req.ReceiptId]
+	if receipt == nil {
+		return nil, status.Errorf(codes.NotFound, "receipt not found for the given Receipt ID : %s", req.ReceiptId)
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, receipt.UserId); err != nil {
+		return nil, err
+	}
+	return &pb.GetReceiptResponse{Receipt: MapReceipt(receipt, s.receiptUser(receipt))}, nil
+}
+
+// GetBookingByReference is public: the booking reference and the last name
+// are the passenger's credentials.
+func (s *BookingServer) GetBookingByReference(ctx context.Context, req *pb.GetBookingByReferenceRequest) (*pb.GetReceiptResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Get Booking By Reference Request")
+	}
+
+	defer rlockStore(ctx, s.Store)()
+
+	receipt, user, err := s.findByReference(req.Booking)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetReceiptResponse{Receipt: MapReceipt(receipt, user)}, nil
+}
+
+// CancelBookingByReference cancels like DeleteBooking, on behalf of the
+// passenger of the booking reference. Its idempotency key is scoped to the
+// passenger.
+func (s *BookingServer) CancelBookingByReference(ctx context.Context, req *pb.CancelBookingByReferenceRequest) (*pb.DeleteBookingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Cancel Booking By Reference Request")
+	}
+	ctx, receiptId, err := s.actAsPassenger(ctx, req.Booking)
+	if err != nil {
+		return nil, err
+	}
+	payload := proto.Clone(req).(*pb.CancelBookingByReferenceRequest)
+	payload.IdempotencyKey = ""
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "CancelBookingByReference", payload,
+		func(res *pb.DeleteBookingResponse) string { return res.GetReceipt().GetUser().GetUserId() },
+		func() (*pb.DeleteBookingResponse, error) {
+			return s.deleteBooking(ctx, &pb.DeleteBookingRequest{
+				ReceiptId:       receiptId,
+				ExpectedVersion: req.ExpectedVersion,
+			})
+		})
+}
+
+// ChangeSeatByReference moves the booking like UpdateSeatBooking, on behalf
+// of the passenger of the booking reference. Its idempotency key is scoped
+// to the passenger.
+func (s *BookingServer) ChangeSeatByReference(ctx context.Context, req *pb.ChangeSeatByReferenceRequest) (*pb.UpdateSeatBookingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Change Seat By Reference Request")
+	}
+	ctx, receiptId, err := s.actAsPassenger(ctx, req.Booking)
+	if err != nil {
+		return nil, err
+	}
+	payload := proto.Clone(req).(*pb.ChangeSeatByReferenceRequest)
+	payload.IdempotencyKey = ""
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "ChangeSeatByReference", payload,
+		func(res *pb.UpdateSeatBookingResponse) string { return res.GetUpdatedReceipt().GetUser().GetUserId() },
+		func() (*pb.UpdateSeatBookingResponse, error) {
+			return s.updateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+				ReceiptId:       receiptId,
+				NewSeatId:       req.NewSeatId,
+				NewSectionId:    req.NewSectionId,
+				ExpectedVersion: req.ExpectedVersion,
+			})
+		})
+}
+
+// actAsPassenger checks the booking reference and returns a context in
+// which the caller acts as the passenger, for this booking's user only, and
+// the ID of the receipt.
+func (s *BookingServer) actAsPassenger(ctx context.Context, booking *pb.BookingReference) (context.Context, string, error) {
+	unlock := rlockStore(ctx, s.Store)
+	defer unlock()
+	receipt, user, err := s.findByReference(booking)
+	if err != nil {
+		return nil, "", err
+	}
+	passenger := &auth.Principal{UserId: user.Id, Email: user.Email, Role: auth.RoleCustomer, Scope: auth.ScopeSelf}
+	return auth.WithPrincipal(ctx, passenger), receipt.Id, nil
+}
+
+// findByReference returns the receipt of the booking reference and its user
+// when the last name matches. A wrong reference and a wrong last name give
+// the same error, so the reference of a booking cannot be probed on its
+// own. The caller holds the store lock.
+func (s *BookingServer) findByReference(booking *pb.BookingReference) (*models.Receipt, *models.User, error) {
+	if booking == nil || booking.Reference == "" || strings.TrimSpace(booking.LastName) == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "booking reference and last name are required")
+	}
+	receipt := dataStore.GetReceiptByReference(s.Store, booking.Reference)
+	var user *models.User
+	if receipt != nil {
+		user = dataStore.GetUser(s.Store, receipt.UserId)
+	}
+	if user == nil || !strings.EqualFold(strings.TrimSpace(user.LastName), strings.TrimSpace(booking.LastName)) {
+		return nil, nil, status.Error(codes.NotFound, "no booking found for the given reference and last name")
+	}
+	return receipt, user, nil
+}
+
+// receiptUser returns the user of a receipt. Receipts of erased users keep
+// only their anonymous user ID. The caller holds the store lock.
+func (s *BookingServer) receiptUser(receipt *models.Receipt) *models.User {
+	if user := dataStore.GetUser(s.Store, receipt.UserId); user != nil {
+		return user
+	}
+	return &models.User{Id: receipt.UserId}
+}
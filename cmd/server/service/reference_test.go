@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_GetReceipt(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, RequireAuth: true}
+	alice := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "1", Role: auth.RoleCustomer})
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+
+	type test struct {
+		Ctx          context.Context
+		ReceiptId    string
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Own receipt": {
+			Ctx:       alice,
+			ReceiptId: "11",
+		},
+		"Sad Path - Receipt of another user": {
+			Ctx:          bob,
+			ReceiptId:    "11",
+			ExpectedCode: codes.PermissionDenied,
+		},
+		"Sad Path - Unknown receipt": {
+			Ctx:          alice,
+			ReceiptId:    "12",
+			ExpectedCode: codes.NotFound,
+		},
+		"Sad Path - Missing receipt ID": {
+			Ctx:          alice,
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := bookingServer.GetReceipt(tc.Ctx, &pb.GetReceiptRequest{ReceiptId: tc.ReceiptId})
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ReceiptId, res.Receipt.ReceiptId)
+			assert.Equal(t, "Alice", res.Receipt.User.FirstName)
+		})
+	}
+}
+
+func Test_BookingReference(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, RequireAuth: true}
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+	anonymous := context.Background()
+
+	purchase, err := bookingServer.PurchaseBooking(bob, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	reference := purchase.Receipt.Reference
+	assert.Regexp(t, regexp.MustCompile(`^[A-HJ-NP-Z2-9]{6}$`), reference)
+	lastName := store.Users[1].LastName
+
+	type test struct {
+		Booking      *pb.BookingReference
+		ExpectedCode codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Reference and last name": {
+			Booking: &pb.BookingReference{Reference: reference, LastName: lastName},
+		},
+		"Happy Path - Reference typed loosely": {
+			Booking: &pb.BookingReference{Reference: strings.ToLower(reference[:3] + "-" + reference[3:]), LastName: " " + strings.ToUpper(lastName)},
+		},
+		"Sad Path - Wrong last name": {
+			Booking:      &pb.BookingReference{Reference: reference, LastName: "Doe"},
+			ExpectedCode: codes.NotFound,
+		},
+		"Sad Path - Unknown reference": {
+			Booking:      &pb.BookingReference{Reference: "ZZZZZZ", LastName: lastName},
+			ExpectedCode: codes.NotFound,
+		},
+		"Sad Path - Missing last name": {
+			Booking:      &pb.BookingReference{Reference: reference},
+			ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := bookingServer.GetBookingByReference(anonymous, &pb.GetBookingByReferenceRequest{Booking: tc.Booking})
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, purchase.Receipt.ReceiptId, res.Receipt.ReceiptId)
+		})
+	}
+
+	// The passenger manages the booking without an account.
+	booking := &pb.BookingReference{Reference: reference, LastName: lastName}
+	newSeat := store.Train.Sections[0].Seats[4]
+	changed, err := bookingServer.ChangeSeatByReference(anonymous, &pb.ChangeSeatByReferenceRequest{
+		Booking: booking, NewSeatId: newSeat.Id, NewSectionId: newSeat.SectionId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", changed.UpdatedReceipt.Seat)
+	assert.Equal(t, reference, changed.UpdatedReceipt.Reference)
+
+	_, err = bookingServer.CancelBookingByReference(anonymous, &pb.CancelBookingByReferenceRequest{Booking: booking, ExpectedVersion: 1})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	_, err = bookingServer.CancelBookingByReference(anonymous, &pb.CancelBookingByReferenceRequest{
+		Booking: &pb.BookingReference{Reference: reference, LastName: "Doe"},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	cancelled, err := bookingServer.CancelBookingByReference(anonymous, &pb.CancelBookingByReferenceRequest{Booking: booking})
+	assert.NoError(t, err)
+	assert.True(t, cancelled.DeleteStatus)
+	assert.Equal(t, "Cancelled", store.Receipts[purchase.Receipt.ReceiptId].BookingStatus)
+}
+
+func Test_BookingReference_IdempotentReplay(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, RequireAuth: true, Idempotency: idempotency.New(time.Hour)}
+	bob := auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "2", Role: auth.RoleCustomer})
+	anonymous := context.Background()
+
+	purchase, err := bookingServer.PurchaseBooking(bob, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	booking := &pb.BookingReference{Reference: purchase.Receipt.Reference, LastName: store.Users[1].LastName}
+
+	// A retried seat change is replayed instead of failing on the seat it
+	// already holds.
+	newSeat := store.Train.Sections[0].Seats[4]
+	changeSeat := &pb.ChangeSeatByReferenceRequest{
+		Booking: booking, NewSeatId: newSeat.Id, NewSectionId: newSeat.SectionId, IdempotencyKey: "change-seat",
+	}
+	changed, err := bookingServer.ChangeSeatByReference(anonymous, changeSeat)
+	assert.NoError(t, err)
+	replay, err := bookingServer.ChangeSeatByReference(anonymous, changeSeat)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(changed, replay), replay)
+
+	// A retried cancellation is replayed instead of failing on the cancelled
+	// booking.
+	cancel := &pb.CancelBookingByReferenceRequest{Booking: booking, IdempotencyKey: "cancel"}
+	cancelled, err := bookingServer.CancelBookingByReference(anonymous, cancel)
+	assert.NoError(t, err)
+	replayed, err := bookingServer.CancelBookingByReference(anonymous, cancel)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(cancelled, replayed), replayed)
+	_, err = bookingServer.CancelBookingByReference(anonymous, &pb.CancelBookingByReferenceRequest{Booking: booking})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SagaStep is a single forward action of a saga together with the
+// compensating action that undoes it.
+type SagaStep struct {
+	Name       string
+	Action     func() error
+	Compensate func()
+}
+
+// Saga runs its steps in order. If any step fails, the compensating actions
+// of every step that already completed are run in reverse order so the store
+// is left as it was before the saga started.
+type Saga struct {
+	Name  string
+	Steps []SagaStep
+	// Fault is consulted after every completed step and lets tests inject a
+	// failure at a given stage of the saga.
+	Fault func(saga string, step string) error
+}
+
+// Run executes the saga. The saga and each of its steps are traced as spans
+// of ctx, compensations are recorded as events on the saga span.
+func (sg *Saga) Run(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "saga."+sg.Name)
+	defer func() { endSpan(span, err) }()
+
+	for i, step := range sg.Steps {
+		_, stepSpan := tracer.Start(ctx, "saga."+sg.Name+"."+step.Name)
+		err := step.Action()
+		endSpan(stepSpan, err)
+		if err != nil {
+			sg.compensate(i - 1)
+			span.AddEvent("compensated", trace.WithAttributes(attribute.String("saga.failed_step", step.Name)))
+			return err
+		}
+		if sg.Fault != nil {
+			if err := sg.Fault(sg.Name, step.Name); err != nil {
+				sg.compensate(i)
+				span.AddEvent("compensated", trace.WithAttributes(attribute.String("saga.failed_step", step.Name)))
+				return fmt.Errorf("%s failed at step %s: %v", sg.Name, step.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// compensate undoes the steps up to and including index last, newest first.
+func (sg *Saga) compensate(last int) {
+	for i := last; i >= 0; i-- {
+		if sg.Steps[i].Compensate != nil {
+			sg.Steps[i].Compensate()
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// storeSnapshot captures the parts of the store a saga is allowed to touch.
+type storeSnapshot struct {
+	SeatAvailable  map[string]bool
+	SeatUser       map[string]*models.User
+	AvailableSeats map[string]int
+	Receipts       map[string]models.Receipt
+	ByUser         map[string][]string
+	BySeat         map[string][]string
+	BySection      map[string][]string
+}
+
+func takeSnapshot(store *models.Store) storeSnapshot {
+	snapshot := storeSnapshot{
+		SeatAvailable:  map[string]bool{},
+		SeatUser:       map[string]*models.User{},
+		AvailableSeats: map[string]int{},
+		Receipts:       map[string]models.Receipt{},
+		ByUser:         copyIndex(store.ReceiptsByUser),
+		BySeat:         copyIndex(store.ReceiptsBySeat),
+		BySection:      copyIndex(store.ReceiptsBySection),
+	}
+	for _, section := range store.Train.Sections {
+		snapshot.AvailableSeats[section.Id] = section.AvailableSeats
+		for _, seat := range section.Seats {
+			snapshot.SeatAvailable[seat.Id] = seat.SeatAvailable
+			snapshot.SeatUser[seat.Id] = seat.User
+		}
+	}
+	for id, receipt := range store.Receipts {
+		snapshot.Receipts[id] = *receipt
+	}
+	return snapshot
+}
+
+func copyIndex(index map[string][]string) map[string][]string {
+	copied := make(map[string][]string)
+	for key, ids := range index {
+		copied[key] = append([]string(nil), ids...)
+	}
+	return copied
+}
+
+// failAt returns a fault hook that fails the given step of the given saga.
+func failAt(saga string, step string) func(string, string) error {
+	return func(s string, st string) error {
+		if s == saga && st == step {
+			return fmt.Errorf("injected fault")
+		}
+		return nil
+	}
+}
+
+func Test_PurchaseSaga_CompensatesOnFault(t *testing.T) {
+	steps := []string{"reserve-seat", "decrement-section-seats", "store-receipt"}
+	for _, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			store := InitializeStore()
+			before := takeSnapshot(store)
+			bookingServer := &BookingServer{
+				Store:     store,
+				faultHook: failAt("purchase", step),
+			}
+
+			res, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+				From: "London",
+				To:   "France",
+				User: &pb.User{
+					UserId:    "1",
+					FirstName: "Alice",
+					LastName:  "Smith",
+					Email:     "AliceSmith@gmaiil.com",
+				},
+				PricePaid: 20.0,
+			})
+
+			assert.Nil(t, res)
+			assert.EqualError(t, err, fmt.Sprintf("purchase failed at step %s: injected fault", step))
+			assert.Equal(t, before, takeSnapshot(store))
+		})
+	}
+}
+
+func Test_SeatChangeSaga_CompensatesOnFault(t *testing.T) {
+	steps := []string{"reserve-new-seat", "decrement-new-section-seats", "release-old-seat", "increment-old-section-seats", "update-receipt"}
+	for _, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			store := InitializeStore()
+			before := takeSnapshot(store)
+			bookingServer := &BookingServer{
+				Store:     store,
+				faultHook: failAt("seat-change", step),
+			}
+
+			res, err := bookingServer.UpdateSeatBooking(context.Background(), &pb.UpdateSeatBookingRequest{
+				ReceiptId:    "11",
+				NewSeatId:    store.Train.Sections[0].Seats[1].Id,
+				NewSectionId: store.Train.Sections[0].Id,
+			})
+
+			assert.Nil(t, res)
+			assert.EqualError(t, err, fmt.Sprintf("seat-change failed at step %s: injected fault", step))
+			assert.Equal(t, before, takeSnapshot(store))
+		})
+	}
+}
+
+func Test_CancellationSaga_CompensatesOnFault(t *testing.T) {
+	steps := []string{"release-seat", "increment-section-seats", "cancel-receipt"}
+	for _, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			store := InitializeStore()
+			before := takeSnapshot(store)
+			bookingServer := &BookingServer{
+				Store:     store,
+				faultHook: failAt("cancellation", step),
+			}
+
+			res, err := bookingServer.DeleteBooking(context.Background(), &pb.DeleteBookingRequest{
+				ReceiptId: "11",
+			})
+
+			assert.Nil(t, res)
+			assert.EqualError(t, err, fmt.Sprintf("cancellation failed at step %s: injected fault", step))
+			assert.Equal(t, before, takeSnapshot(store))
+		})
+	}
+}
+
+func Test_Sagas_KeepSectionCountersConsistent(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	section := store.Train.Sections[0]
+	before := section.AvailableSeats
+
+	res, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+		From: "London",
+		To:   "France",
+		User: &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, before-1, section.AvailableSeats)
+
+	_, err = bookingServer.DeleteBooking(context.Background(), &pb.DeleteBookingRequest{
+		ReceiptId: res.Receipt.ReceiptId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, before, section.AvailableSeats)
+}
@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	dataStore "grpc-project/pkg/store"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bookingSession is the state of one BookingSession stream. It is only used
+// by the goroutine serving the stream.
+type bookingSession struct {
+	id string
+	// userId is the authenticated user of the stream, empty when the
+	// stream is not authenticated.
+	userId  string
+	server  *BookingServer
+	stream  pb.BookingService_BookingSessionServer
+	held    []*seatHold
+	hovered *models.Seat
+}
+
+// seatHold is the hold of a booking session on a seat.
+type seatHold struct {
+	seat    *models.Seat
+	session string
+	userId  string
+	// expiresAt is when the hold lapses, zero when it lasts until the
+	// session ends.
+	expiresAt time.Time
+}
+
+func (h *seatHold) lapsed(now time.Time) bool {
+	return !h.expiresAt.IsZero() && !now.Before(h.expiresAt)
+}
+
+// BookingSession serves an interactive seat selection. Seats selected in
+// the session are held, so nobody else can book them, until they are
+// confirmed, deselected, the hold lapses after HoldTTL or the stream ends.
+// The session is told when a hovered seat is taken by somebody else and
+// when a hold lapses.
+func (s *BookingServer) BookingSession(stream pb.BookingService_BookingSessionServer) error {
+	if s.Availability == nil {
+		return status.Error(codes.Unimplemented, "booking sessions are not enabled")
+	}
+	ctx := stream.Context()
+	session := &bookingSession{id: uuid.New().String(), server: s, stream: stream}
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		session.userId = principal.UserId
+	}
+
+	// Changes by other clients are needed to report conflicts on the
+	// hovered seat.
+	w, err := s.Availability.subscribeSession()
+	if err != nil {
+		return err
+	}
+	defer s.Availability.unsubscribe(w)
+	defer session.releaseAll(ctx)
+
+	requests := make(chan *pb.BookingSessionRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-session.nextLapse():
+			if err := session.expire(ctx); err != nil {
+				return err
+			}
+		case req := <-requests:
+			if err := session.handle(ctx, req); err != nil {
+				return err
+			}
+		case event := <-w.events:
+			if err := session.checkHovered(ctx, event.GetChange()); err != nil {
+				return err
+			}
+		case <-w.missed:
+			// Changes were dropped while the session was busy, one of them
+			// may have taken the hovered seat.
+			if err := session.recheckHovered(ctx); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-w.done:
+			return w.err
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// handle runs one action of the client. Failed actions are sent to the
+// client as a SessionError; only a failed send ends the session.
+func (bs *bookingSession) handle(ctx context.Context, req *pb.BookingSessionRequest) error {
+	// Lapsed holds are let go first, so no action uses them.
+	if err := bs.expire(ctx); err != nil {
+		return err
+	}
+	var res *pb.BookingSessionResponse
+	var err error
+	switch action := req.GetAction().(type) {
+	case *pb.BookingSessionRequest_Hover:
+		res, err = bs.hover(ctx, action.Hover)
+	case *pb.BookingSessionRequest_Select:
+		res, err = bs.selectSeat(ctx, action.Select)
+	case *pb.BookingSessionRequest_Deselect:
+		res, err = bs.deselect(ctx, action.Deselect)
+	case *pb.BookingSessionRequest_Confirm:
+		res, err = bs.confirm(ctx, action.Confirm)
+	default:
+		err = status.Error(codes.InvalidArgument, "invalid Booking Session Request")
+	}
+	if err != nil {
+		s := status.Convert(err)
+		res = &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Error{Error: &pb.SessionError{
+			Status:  code.Code(s.Code()).String(),
+			Message: s.Message(),
+		}}}
+	}
+	return bs.stream.Send(res)
+}
+
+func (bs *bookingSession) hover(ctx context.Context, ref *pb.SeatRef) (*pb.BookingSessionResponse, error) {
+	s := bs.server
+	defer rlockStore(ctx, s.Store)()
+
+	seat, err := bs.seat(ref)
+	if err != nil {
+		return nil, err
+	}
+	bs.hovered = seat
+	return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Seat{Seat: s.seatState(seat)}}, nil
+}
+
+func (bs *bookingSession) selectSeat(ctx context.Context, ref *pb.SeatRef) (*pb.BookingSessionResponse, error) {
+	s := bs.server
+	defer lockStore(ctx, s.Store)()
+
+	seat, err := bs.seat(ref)
+	if err != nil {
+		return nil, err
+	}
+	if hold := s.holds[seat]; hold != nil && hold.session == bs.id {
+		return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Selected{Selected: s.seatState(seat)}}, nil
+	} else if hold != nil || !seat.SeatAvailable {
+		return bs.conflict(seat), nil
+	}
+	if err := bs.checkHoldLimit(); err != nil {
+		return nil, err
+	}
+	if s.holds == nil {
+		s.holds = map[*models.Seat]*seatHold{}
+	}
+	hold := &seatHold{seat: seat, session: bs.id, userId: bs.userId}
+	if s.HoldTTL > 0 {
+		hold.expiresAt = time.Now().Add(s.HoldTTL)
+	}
+	s.holds[seat] = hold
+	bs.held = append(bs.held, hold)
+	s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_HELD, seat, nil)
+	return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Selected{Selected: s.seatState(seat)}}, nil
+}
+
+func (bs *bookingSession) deselect(ctx context.Context, ref *pb.SeatRef) (*pb.BookingSessionResponse, error) {
+	s := bs.server
+	defer lockStore(ctx, s.Store)()
+
+	seat, err := bs.seat(ref)
+	if err != nil {
+		return nil, err
+	}
+	if hold := s.holds[seat]; hold == nil || hold.session != bs.id {
+		return nil, status.Error(codes.FailedPrecondition, "seat is not selected in this session")
+	}
+	bs.release(seat)
+	return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Deselected{Deselected: s.seatState(seat)}}, nil
+}
+
+// confirm books the held seats for the user in one saga, so either all of
+// them are booked or none.
+func (bs *bookingSession) confirm(ctx context.Context, req *pb.SessionConfirm) (*pb.BookingSessionResponse, error) {
+	s := bs.server
+	if req.User == nil || req.From == "" || req.To == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Session Confirm Request")
+	}
+	if len(bs.held) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no seats are selected")
+	}
+
+	defer lockStore(ctx, s.Store)()
+
+	finalTicketPrice, err := s.price(ctx, nil, req.PricePaid, req.DisocuntCoupon)
+	if err != nil {
+		return nil, err
+	}
+	charged, rate, err := s.charge(finalTicketPrice, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	user, register, err := s.ResolveUser(req.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, user.Id); err != nil {
+		return nil, err
+	}
+	if err := s.checkBookingLimit(user.Id, len(bs.held)); err != nil {
+		return nil, err
+	}
+
+	var steps []SagaStep
+	if register {
+		steps = append(steps, SagaStep{
+			Name:       "register-user",
+			Action:     func() error { return dataStore.AddUser(s.Store, user) },
+			Compensate: func() { dataStore.RemoveUser(s.Store, user.Id) },
+		})
+	}
+	var receipts []*models.Receipt
+	var references []string
+	for _, hold := range bs.held {
+		seat := hold.seat
+		section := dataStore.GetSection(s.Store, seat.SectionId)
+		if section == nil {
+			return nil, fmt.Errorf("section not found for the given Section ID: %s", seat.SectionId)
+		}
+		reference, err := dataStore.NewReference(s.Store, references...)
+		if err != nil {
+			return nil, err
+		}
+		references = append(references, reference)
+		receipt := &models.Receipt{
+			Id:            uuid.New().String(),
+			From:          req.From,
+			To:            req.To,
+			Email:         user.Email,
+			UserId:        user.Id,
+			SeatNumber:    seat.SeatNumber,
+			SeatId:        seat.Id,
+			SectionId:     section.Id,
+			SectionName:   section.Name,
+			Price:         finalTicketPrice,
+			Charged:       charged,
+			Rate:          rate,
+			BookingStatus: "Confirmed",
+			Version:       1,
+			BookedAt:      time.Now().UTC(),
+			Reference:     reference,
+		}
+		receipts = append(receipts, receipt)
+		steps = append(steps,
+			SagaStep{
+				Name:       "reserve-seat",
+				Action:     func() error { return reserveSeat(seat, user) },
+				Compensate: func() { releaseSeat(seat) },
+			},
+			SagaStep{
+				Name:       "decrement-section-seats",
+				Action:     func() error { section.AvailableSeats--; return nil },
+				Compensate: func() { section.AvailableSeats++ },
+			},
+			SagaStep{
+				Name:       "store-receipt",
+				Action:     func() error { return dataStore.AddReceipt(s.Store, receipt) },
+				Compensate: func() { dataStore.RemoveReceipt(s.Store, receipt.Id) },
+			},
+		)
+	}
+	purchase := &Saga{
+		Name:  "session-purchase",
+		Fault: s.faultHook,
+		Steps: steps,
+	}
+	if err := purchase.Run(ctx); err != nil {
+		s.Metrics.AllocationFailed(AllocationSagaFailed)
+		return nil, err
+	}
+
+	confirmed := &pb.SessionConfirmed{}
+	for i, hold := range bs.held {
+		delete(s.holds, hold.seat)
+		if hold.seat == bs.hovered {
+			bs.hovered = nil
+		}
+		s.Metrics.Booked(receipts[i].Price, req.DisocuntCoupon)
+		s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_BOOKED, hold.seat, nil)
+		confirmed.Receipts = append(confirmed.Receipts, MapReceipt(receipts[i], user))
+	}
+	bs.held = nil
+	return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Confirmed{Confirmed: confirmed}}, nil
+}
+
+// checkHovered tells the client when a change took the hovered seat. The
+// seat is checked as it is now, so changes of the session itself and
+// changes that were undone in the meantime are not reported.
+func (bs *bookingSession) checkHovered(ctx context.Context, change *pb.SeatChangeEvent) error {
+	if bs.hovered == nil || change == nil || change.Seat == nil || change.Seat.SeatId != bs.hovered.Id {
+		return nil
+	}
+	return bs.recheckHovered(ctx)
+}
+
+// recheckHovered tells the client when the hovered seat is no longer free
+// for the session.
+func (bs *bookingSession) recheckHovered(ctx context.Context) error {
+	if bs.hovered == nil {
+		return nil
+	}
+	s := bs.server
+	unlock := rlockStore(ctx, s.Store)
+	seat := bs.hovered
+	hold := s.holds[seat]
+	if (hold != nil && hold.session == bs.id) || (hold == nil && seat.SeatAvailable) {
+		unlock()
+		return nil
+	}
+	res := bs.conflict(seat)
+	unlock()
+	bs.hovered = nil
+	return bs.stream.Send(res)
+}
+
+// conflict reports that seat was taken. The caller holds the store lock.
+func (bs *bookingSession) conflict(seat *models.Seat) *pb.BookingSessionResponse {
+	reason := "seat is held by another session"
+	if !seat.SeatAvailable {
+		reason = "seat is already booked"
+	}
+	return &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Conflict{Conflict: &pb.SeatConflict{
+		Seat:   bs.server.seatState(seat),
+		Reason: reason,
+	}}}
+}
+
+// seat looks up the seat of ref. The caller holds the store lock.
+func (bs *bookingSession) seat(ref *pb.SeatRef) (*models.Seat, error) {
+	if ref == nil || ref.SeatId == "" {
+		return nil, status.Error(codes.InvalidArgument, "seat ID is required")
+	}
+	seat := dataStore.GetSeat(bs.server.Store, ref.SeatId, ref.SectionId)
+	if seat == nil {
+		return nil, status.Errorf(codes.NotFound, "seat not found for the given Seat ID: %s", ref.SeatId)
+	}
+	return seat, nil
+}
+
+// release ends the hold of the session on seat. The caller holds the store
+// write lock.
+func (bs *bookingSession) release(seat *models.Seat) {
+	s := bs.server
+	delete(s.holds, seat)
+	for i, hold := range bs.held {
+		if hold.seat == seat {
+			bs.held = append(bs.held[:i], bs.held[i+1:]...)
+			break
+		}
+	}
+	s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_RELEASED, seat, nil)
+}
+
+// releaseAll ends all holds of the session when the stream ends.
+func (bs *bookingSession) releaseAll(ctx context.Context) {
+	if len(bs.held) == 0 {
+		return
+	}
+	defer lockStore(context.WithoutCancel(ctx), bs.server.Store)()
+	for len(bs.held) > 0 {
+		bs.release(bs.held[0].seat)
+	}
+}
+
+// checkHoldLimit fails when holding one more seat would take the user of the
+// session over MaxBookingsPerUser. The seats the user holds in all sessions
+// count, as do the confirmed bookings of the user. Sessions without an
+// authenticated user are limited on their own. The caller holds the store
+// lock.
+func (bs *bookingSession) checkHoldLimit() error {
+	s := bs.server
+	if s.MaxBookingsPerUser <= 0 {
+		return nil
+	}
+	held := 0
+	for _, hold := range s.holds {
+		if hold.session == bs.id || (bs.userId != "" && hold.userId == bs.userId) {
+			held++
+		}
+	}
+	if bs.userId == "" {
+		if held >= s.MaxBookingsPerUser {
+			return status.Errorf(codes.ResourceExhausted, "a session can hold at most %d seats", s.MaxBookingsPerUser)
+		}
+		return nil
+	}
+	return s.checkBookingLimit(bs.userId, held+1)
+}
+
+// nextLapse fires when the oldest hold of the session lapses. Holds are kept
+// in the order they were taken and all last HoldTTL, so the oldest lapses
+// first. It never fires when holds do not lapse.
+func (bs *bookingSession) nextLapse() <-chan time.Time {
+	if len(bs.held) == 0 || bs.held[0].expiresAt.IsZero() {
+		return nil
+	}
+	return time.After(time.Until(bs.held[0].expiresAt))
+}
+
+// expire releases the lapsed holds of the session and sends the client a
+// deselected event for each of them.
+func (bs *bookingSession) expire(ctx context.Context) error {
+	now := time.Now()
+	if len(bs.held) == 0 || !bs.held[0].lapsed(now) {
+		return nil
+	}
+	s := bs.server
+	var lapsed []*pb.BookingSessionResponse
+	unlock := lockStore(ctx, s.Store)
+	for len(bs.held) > 0 && bs.held[0].lapsed(now) {
+		seat := bs.held[0].seat
+		bs.release(seat)
+		lapsed = append(lapsed, &pb.BookingSessionResponse{Event: &pb.BookingSessionResponse_Deselected{Deselected: s.seatState(seat)}})
+	}
+	unlock()
+	for _, res := range lapsed {
+		if err := bs.stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/pkg/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func openSession(t *testing.T, client pb.BookingServiceClient) pb.BookingService_BookingSessionClient {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	stream, err := client.BookingSession(ctx)
+	assert.NoError(t, err)
+	return stream
+}
+
+// act sends an action and returns the next response of the session.
+func act(t *testing.T, stream pb.BookingService_BookingSessionClient, req *pb.BookingSessionRequest) *pb.BookingSessionResponse {
+	assert.NoError(t, stream.Send(req))
+	res, err := stream.Recv()
+	assert.NoError(t, err)
+	return res
+}
+
+func hover(seatId, sectionId string) *pb.BookingSessionRequest {
+	return &pb.BookingSessionRequest{Action: &pb.BookingSessionRequest_Hover{Hover: &pb.SeatRef{SeatId: seatId, SectionId: sectionId}}}
+}
+
+func selectSeat(seatId, sectionId string) *pb.BookingSessionRequest {
+	return &pb.BookingSessionRequest{Action: &pb.BookingSessionRequest_Select{Select: &pb.SeatRef{SeatId: seatId, SectionId: sectionId}}}
+}
+
+func deselect(seatId, sectionId string) *pb.BookingSessionRequest {
+	return &pb.BookingSessionRequest{Action: &pb.BookingSessionRequest_Deselect{Deselect: &pb.SeatRef{SeatId: seatId, SectionId: sectionId}}}
+}
+
+func confirm(user *pb.User) *pb.BookingSessionRequest {
+	return &pb.BookingSessionRequest{Action: &pb.BookingSessionRequest_Confirm{Confirm: &pb.SessionConfirm{
+		User: user, From: "London", To: "France", PricePaid: 20.0,
+	}}}
+}
+
+func Test_BookingSession(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0)}
+	client := startBookingServer(t, bookingServer)
+	section := store.Train.Sections[0]
+	seat2, seat3 := section.Seats[1], section.Seats[2]
+	bob := &pb.User{UserId: "2", FirstName: "Bob", LastName: "Smith", Email: "BobSmith@gmaiil.com"}
+
+	kioskA := openSession(t, client)
+	kioskB := openSession(t, client)
+
+	res := act(t, kioskA, hover(seat2.Id, section.Id))
+	assert.True(t, res.GetSeat().SeatAvailable)
+	assert.False(t, res.GetSeat().Held)
+
+	// B grabs the seat A is looking at.
+	res = act(t, kioskB, selectSeat(seat2.Id, section.Id))
+	assert.True(t, res.GetSelected().Held)
+	res, err := kioskA.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, seat2.Id, res.GetConflict().Seat.SeatId)
+	assert.Equal(t, "seat is held by another session", res.GetConflict().Reason)
+
+	res = act(t, kioskA, selectSeat(seat2.Id, section.Id))
+	assert.NotNil(t, res.GetConflict())
+	res = act(t, kioskA, selectSeat(seat3.Id, section.Id))
+	assert.Equal(t, seat3.Id, res.GetSelected().SeatId)
+
+	// Held seats are skipped by other bookings.
+	purchase, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+		From: "London", To: "France", User: bob, PricePaid: 20.0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "4", purchase.Receipt.Seat)
+
+	res = act(t, kioskA, confirm(bob))
+	receipts := res.GetConfirmed().Receipts
+	assert.Len(t, receipts, 1)
+	assert.Equal(t, "3", receipts[0].Seat)
+	assert.Equal(t, "Confirmed", receipts[0].BookingStatus)
+	assert.False(t, seat3.SeatAvailable)
+
+	// The holds of a session end with its stream.
+	assert.NoError(t, kioskB.CloseSend())
+	assert.Eventually(t, func() bool {
+		store.Mu.RLock()
+		defer store.Mu.RUnlock()
+		return len(bookingServer.holds) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, seat2.SeatAvailable)
+}
+
+func Test_BookingSession_Errors(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0), MaxBookingsPerUser: 2}
+	client := startBookingServer(t, bookingServer)
+	section := store.Train.Sections[0]
+	alice := &pb.User{UserId: "1", FirstName: "Alice", LastName: "Smith", Email: "AliceSmith@gmaiil.com"}
+
+	type test struct {
+		Setup          []*pb.BookingSessionRequest
+		Request        *pb.BookingSessionRequest
+		ExpectedStatus string
+	}
+	tests := map[string]test{
+		"Sad Path - Unknown seat": {
+			Request:        selectSeat("unknown", section.Id),
+			ExpectedStatus: "NOT_FOUND",
+		},
+		"Sad Path - Deselect a seat that is not selected": {
+			Request:        deselect(section.Seats[1].Id, section.Id),
+			ExpectedStatus: "FAILED_PRECONDITION",
+		},
+		"Sad Path - Confirm without seats": {
+			Request:        confirm(alice),
+			ExpectedStatus: "FAILED_PRECONDITION",
+		},
+		"Sad Path - More seats than a user may book": {
+			Setup:          []*pb.BookingSessionRequest{selectSeat(section.Seats[1].Id, section.Id), selectSeat(section.Seats[2].Id, section.Id)},
+			Request:        selectSeat(section.Seats[3].Id, section.Id),
+			ExpectedStatus: "RESOURCE_EXHAUSTED",
+		},
+		"Sad Path - Confirm over the booking limit": {
+			// Alice already holds a booking.
+			Setup:          []*pb.BookingSessionRequest{selectSeat(section.Seats[1].Id, section.Id), selectSeat(section.Seats[2].Id, section.Id)},
+			Request:        confirm(alice),
+			ExpectedStatus: "RESOURCE_EXHAUSTED",
+		},
+		"Sad Path - Empty action": {
+			Request:        &pb.BookingSessionRequest{},
+			ExpectedStatus: "INVALID_ARGUMENT",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream := openSession(t, client)
+			for _, req := range tc.Setup {
+				assert.NotNil(t, act(t, stream, req).GetSelected())
+			}
+			res := act(t, stream, tc.Request)
+			assert.Equal(t, tc.ExpectedStatus, res.GetError().GetStatus())
+
+			// The session goes on after a failed action.
+			res = act(t, stream, hover(section.Seats[4].Id, section.Id))
+			assert.NotNil(t, res.GetSeat())
+			assert.NoError(t, stream.CloseSend())
+			assert.Eventually(t, func() bool {
+				store.Mu.RLock()
+				defer store.Mu.RUnlock()
+				return len(bookingServer.holds) == 0
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// principalFromMetadata authenticates streams as the user in the "user-id"
+// metadata header.
+func principalFromMetadata(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	md, _ := metadata.FromIncomingContext(ss.Context())
+	if ids := md.Get("user-id"); len(ids) > 0 {
+		ctx := auth.WithPrincipal(ss.Context(), &auth.Principal{UserId: ids[0], Role: auth.RoleCustomer})
+		ss = &contextStream{ServerStream: ss, ctx: ctx}
+	}
+	return handler(srv, ss)
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+func openSessionAs(t *testing.T, client pb.BookingServiceClient, userId string) pb.BookingService_BookingSessionClient {
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), "user-id", userId), 5*time.Second)
+	t.Cleanup(cancel)
+	stream, err := client.BookingSession(ctx)
+	assert.NoError(t, err)
+	return stream
+}
+
+func Test_BookingSession_HoldLimitPerUser(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0), MaxBookingsPerUser: 2}
+	client := startBookingServer(t, bookingServer, grpc.StreamInterceptor(principalFromMetadata))
+	seats := store.Train.Sections[0].Seats
+	sectionId := store.Train.Sections[0].Id
+
+	// Bob holds seats in two sessions, up to the limit.
+	bobA, bobB := openSessionAs(t, client, "2"), openSessionAs(t, client, "2")
+	assert.NotNil(t, act(t, bobA, selectSeat(seats[1].Id, sectionId)).GetSelected())
+	assert.NotNil(t, act(t, bobB, selectSeat(seats[2].Id, sectionId)).GetSelected())
+	assert.Equal(t, "RESOURCE_EXHAUSTED", act(t, bobA, selectSeat(seats[3].Id, sectionId)).GetError().GetStatus())
+	assert.Equal(t, "RESOURCE_EXHAUSTED", act(t, bobB, selectSeat(seats[3].Id, sectionId)).GetError().GetStatus())
+
+	// Alice already holds a booking, so she can hold one seat more.
+	alice := openSessionAs(t, client, "1")
+	assert.NotNil(t, act(t, alice, selectSeat(seats[3].Id, sectionId)).GetSelected())
+	assert.Equal(t, "RESOURCE_EXHAUSTED", act(t, alice, selectSeat(seats[4].Id, sectionId)).GetError().GetStatus())
+
+	// Letting go of a seat in one session makes room in the other.
+	assert.NotNil(t, act(t, bobA, deselect(seats[1].Id, sectionId)).GetDeselected())
+	assert.NotNil(t, act(t, bobB, selectSeat(seats[1].Id, sectionId)).GetSelected())
+}
+
+func Test_BookingSession_HoldLapses(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0), HoldTTL: 50 * time.Millisecond}
+	client := startBookingServer(t, bookingServer)
+	section := store.Train.Sections[0]
+	seat := section.Seats[1]
+
+	kiosk := openSession(t, client)
+	assert.NotNil(t, act(t, kiosk, selectSeat(seat.Id, section.Id)).GetSelected())
+
+	// The session is told without asking when the hold lapses.
+	res, err := kiosk.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, seat.Id, res.GetDeselected().GetSeatId())
+	assert.False(t, res.GetDeselected().GetHeld())
+	store.Mu.RLock()
+	assert.Empty(t, bookingServer.holds)
+	store.Mu.RUnlock()
+
+	res = act(t, kiosk, confirm(&pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"}))
+	assert.Equal(t, "FAILED_PRECONDITION", res.GetError().GetStatus())
+}
+
+func Test_BookingSession_FallsBehind(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, Availability: NewAvailabilityFeed(0)}
+	client := startBookingServer(t, bookingServer)
+	section := store.Train.Sections[0]
+	seat := section.Seats[1]
+
+	kiosk := openSession(t, client)
+	assert.NotNil(t, act(t, kiosk, hover(seat.Id, section.Id)).GetSeat())
+
+	// The session waits for the store lock on the first change while far
+	// more changes than it can queue are published.
+	store.Mu.Lock()
+	seat.SeatAvailable = false
+	for range 2 * watcherBuffer {
+		bookingServer.publishSeatChange(pb.SeatChange_SEAT_CHANGE_BOOKED, seat, nil)
+	}
+	store.Mu.Unlock()
+
+	// The session is not ended and still learns that the seat was taken.
+	res, err := kiosk.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, seat.Id, res.GetConflict().GetSeat().GetSeatId())
+	assert.NotNil(t, act(t, kiosk, hover(section.Seats[2].Id, section.Id)).GetSeat())
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"grpc-project/cmd/server/models"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the booking service and its store access. It
+// uses the global tracer provider, so spans are dropped until tracing is set
+// up.
+var tracer = otel.Tracer("grpc-project/cmd/server/service")
+
+// lockStore takes the store's write lock and records the time spent waiting
+// for it. The returned function releases the lock.
+func lockStore(ctx context.Context, store *models.Store) func() {
+	_, span := tracer.Start(ctx, "store.lock")
+	store.Mu.Lock()
+	span.End()
+	return store.Mu.Unlock
+}
+
+// rlockStore is lockStore for the read lock.
+func rlockStore(ctx context.Context, store *models.Store) func() {
+	_, span := tracer.Start(ctx, "store.rlock")
+	store.Mu.RLock()
+	span.End()
+	return store.Mu.RUnlock
+}
+
+// endSpan marks the span as failed when err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
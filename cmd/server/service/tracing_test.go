@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	recorder     = tracetest.NewSpanRecorder()
+	recorderOnce sync.Once
+)
+
+// startTrace starts a root span. The package tracer binds to the first
+// provider installed, so all tests share one recorder and tell their spans
+// apart by trace ID.
+func startTrace() (context.Context, trace.Span) {
+	recorderOnce.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+	return otel.Tracer("test").Start(context.Background(), "test")
+}
+
+// endedSpans returns the names and spans of the trace that have ended.
+func endedSpans(root trace.Span) ([]string, []sdktrace.ReadOnlySpan) {
+	var names []string
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == root.SpanContext().TraceID() {
+			names = append(names, span.Name())
+			spans = append(spans, span)
+		}
+	}
+	return names, spans
+}
+
+func Test_PurchaseBooking_Spans(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store}
+
+	ctx, root := startTrace()
+	_, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From:      "London",
+		To:        "France",
+		User:      &pb.User{UserId: "2", FirstName: "Bob", LastName: "Johnson", Email: "BobJohnson@gmail.com"},
+		PricePaid: 20.0,
+	})
+	root.End()
+	assert.NoError(t, err)
+
+	names, _ := endedSpans(root)
+	assert.Equal(t, []string{
+		"store.lock",
+		"booking.price",
+		"booking.allocate-seat",
+		"saga.purchase.reserve-seat",
+		"saga.purchase.decrement-section-seats",
+		"saga.purchase.store-receipt",
+		"saga.purchase",
+		"test",
+	}, names)
+}
+
+func Test_Saga_Spans(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store:     store,
+		faultHook: failAt("seat-change", "release-old-seat"),
+	}
+	ctx, root := startTrace()
+	_, err := bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:    "11",
+		NewSeatId:    store.Train.Sections[0].Seats[1].Id,
+		NewSectionId: store.Train.Sections[0].Id,
+	})
+	root.End()
+	assert.Error(t, err)
+
+	_, spans := endedSpans(root)
+	saga := spans[len(spans)-2]
+	assert.Equal(t, "saga.seat-change", saga.Name())
+	assert.Equal(t, otelcodes.Error, saga.Status().Code)
+	var events []string
+	for _, event := range saga.Events() {
+		events = append(events, event.Name)
+	}
+	assert.Contains(t, events, "compensated")
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/idempotency"
+	dataStore "grpc-project/pkg/store"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateBooking changes the fields of a booking named by the update mask in
+// one saga, so either all of them change or none. The passenger is the user
+// of the booking, so name and email changes apply to the user's account.
+func (s *BookingServer) UpdateBooking(ctx context.Context, req *pb.UpdateBookingRequest) (*pb.UpdateBookingResponse, error) {
+	if req == nil {
+		return s.updateBooking(ctx, req)
+	}
+	payload := proto.Clone(req).(*pb.UpdateBookingRequest)
+	payload.IdempotencyKey = ""
+	return idempotency.Do(ctx, s.Idempotency, idempotencyKey(ctx, req.IdempotencyKey), "UpdateBooking", payload,
+		func(res *pb.UpdateBookingResponse) string { return res.GetReceipt().GetUser().GetUserId() },
+		func() (*pb.UpdateBookingResponse, error) { return s.updateBooking(ctx, req) })
+}
+
+func (s *BookingServer) updateBooking(ctx context.Context, req *pb.UpdateBookingRequest) (*pb.UpdateBookingResponse, error) {
+	if req == nil || req.ReceiptId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Update Booking Request")
+	}
+	change, err := newBookingChange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer lockStore(ctx, s.Store)()
+
+	receipt, err := dataStore.CheckValidReceipt(s.Store, req.ReceiptId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "receipt not found: %v", err)
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, receipt.UserId); err != nil {
+		return nil, err
+	}
+	if receipt.BookingStatus == "Cancelled" {
+		return nil, status.Error(codes.FailedPrecondition, "your booking is already cancelled, hence cannot update it")
+	}
+	if err := checkReceiptVersion(receipt, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	user := dataStore.GetUser(s.Store, receipt.UserId)
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found for the given User ID : %s", receipt.UserId)
+	}
+
+	previous := *receipt
+	updated := *receipt
+	updated.Version++
+	var steps []SagaStep
+
+	var move *seatMove
+	if change.seat != nil {
+		if move, err = s.seatMove(receipt, user, change.seat.SeatId, change.seat.SectionId); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		move.apply(&updated)
+		steps = append(steps, move.steps()...)
+	}
+
+	passenger := *user
+	if change.mask.has("firstName") {
+		passenger.FirstName = change.firstName
+	}
+	if change.mask.has("lastName") {
+		passenger.LastName = change.lastName
+	}
+	if change.mask.has("email") {
+		if other := dataStore.GetUserByEmail(s.Store, change.email); other != nil && other.Id != user.Id {
+			return nil, status.Errorf(codes.AlreadyExists, "user already exists for the given email : %s", change.email)
+		}
+		passenger.Email = change.email
+		updated.Email = change.email
+	}
+	if passenger != *user {
+		original := *user
+		steps = append(steps, SagaStep{
+			Name:       "update-passenger",
+			Action:     func() error { *user = passenger; return nil },
+			Compensate: func() { *user = original },
+		})
+	}
+
+	if change.mask.has("travelClass") {
+		updated.Class = change.class
+	}
+	if change.mask.has("addOns") {
+		updated.AddOns = change.addOns
+	}
+	steps = append(steps, SagaStep{
+		Name:       "update-receipt",
+		Action:     func() error { return dataStore.UpdateReceipt(s.Store, updated) },
+		Compensate: func() { dataStore.UpdateReceipt(s.Store, previous) },
+	})
+
+	update := &Saga{
+		Name:  "booking-update",
+		Fault: s.faultHook,
+		Steps: steps,
+	}
+	if err := update.Run(ctx); err != nil {
+		if move != nil {
+			s.Metrics.AllocationFailed(AllocationSagaFailed)
+		}
+		return nil, err
+	}
+	if move != nil {
+		s.Metrics.SeatChanged()
+		s.publishSeatChange(pb.SeatChange_SEAT_CHANGE_MOVED, move.newSeat, move.oldSeat)
+	}
+	return &pb.UpdateBookingResponse{Receipt: MapReceipt(&updated, user)}, nil
+}
+
+// bookingChange is a validated UpdateBookingRequest: the fields named by
+// the update mask and their new values.
+type bookingChange struct {
+	mask      maskTree
+	firstName string
+	lastName  string
+	email     string
+	seat      *pb.SeatRef
+	class     string
+	addOns    []string
+}
+
+// newBookingChange validates the fields of the update mask. A seat is
+// changed as a whole, seat.seatId and seat.sectionId select all of it.
+func newBookingChange(req *pb.UpdateBookingRequest) (*bookingChange, error) {
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "updateMask is required")
+	}
+	mask, err := newMaskTree((&pb.BookingUpdate{}).ProtoReflect().Descriptor(), req.UpdateMask, "updateMask")
+	if err != nil {
+		return nil, err
+	}
+	booking := req.Booking
+	change := &bookingChange{mask: mask}
+	if mask.has("firstName") {
+		change.firstName = strings.TrimSpace(booking.GetFirstName())
+		if change.firstName == "" {
+			return nil, status.Error(codes.InvalidArgument, "first name is required")
+		}
+	}
+	if mask.has("lastName") {
+		change.lastName = strings.TrimSpace(booking.GetLastName())
+	}
+	if mask.has("email") {
+		if change.email, err = dataStore.NormalizeEmail(booking.GetEmail()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if mask.has("seat") {
+		change.seat = booking.GetSeat()
+		if change.seat.GetSeatId() == "" || change.seat.GetSectionId() == "" {
+			return nil, status.Error(codes.InvalidArgument, "seat.seatId and seat.sectionId are required")
+		}
+	}
+	if mask.has("travelClass") {
+		if change.class, err = parseTravelClass(booking.GetTravelClass()); err != nil {
+			return nil, err
+		}
+	}
+	if mask.has("addOns") {
+		if change.addOns, err = parseAddOns(booking.GetAddOns()); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
+
+func parseTravelClass(class string) (string, error) {
+	for _, known := range []string{models.ClassStandard, models.ClassFirst} {
+		if strings.EqualFold(class, known) {
+			return known, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown travel class %q, expected Standard or First", class)
+}
+
+// parseAddOns returns the add-ons with the spelling of the catalog. An empty
+// list removes all add-ons.
+func parseAddOns(addOns []string) ([]string, error) {
+	var parsed []string
+	for _, addOn := range addOns {
+		i := slices.IndexFunc(models.AddOns, func(known string) bool { return strings.EqualFold(addOn, known) })
+		if i < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown add-on %q, expected one of %s", addOn, strings.Join(models.AddOns, ", "))
+		}
+		if slices.Contains(parsed, models.AddOns[i]) {
+			return nil, status.Errorf(codes.InvalidArgument, "add-on %s is listed twice", models.AddOns[i])
+		}
+		parsed = append(parsed, models.AddOns[i])
+	}
+	return parsed, nil
+}
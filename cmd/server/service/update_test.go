@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func updateMask(paths ...string) *fieldmaskpb.FieldMask {
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+func Test_UpdateBooking(t *testing.T) {
+	type test struct {
+		Request          func(req *pb.UpdateBookingRequest)
+		ExpectedReceipt  func(t *testing.T, receipt *pb.Receipt)
+		ExpectedCode     codes.Code
+		ExpectedSeatFree bool
+	}
+	tests := map[string]test{
+		"Happy Path - Passenger name and email": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking = &pb.BookingUpdate{FirstName: " Alicia ", LastName: "Jones", Email: "Alicia.Jones@Example.com", TravelClass: "first"}
+				req.UpdateMask = updateMask("firstName", "last_name", "email")
+			},
+			ExpectedReceipt: func(t *testing.T, receipt *pb.Receipt) {
+				assert.Equal(t, &pb.User{UserId: "1", FirstName: "Alicia", LastName: "Jones", Email: "alicia.jones@example.com"}, receipt.User)
+				// travelClass is not in the mask.
+				assert.Equal(t, "Standard", receipt.TravelClass)
+			},
+		},
+		"Happy Path - Seat, class and add-ons": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking = &pb.BookingUpdate{
+					Seat:        &pb.SeatRef{SeatId: req.Booking.Seat.SeatId, SectionId: "S1"},
+					TravelClass: "FIRST",
+					AddOns:      []string{"wifi", "Meal"},
+				}
+				req.UpdateMask = updateMask("seat.seatId", "seat.sectionId", "travelClass", "addOns")
+			},
+			ExpectedReceipt: func(t *testing.T, receipt *pb.Receipt) {
+				assert.Equal(t, "3", receipt.Seat)
+				assert.Equal(t, "First", receipt.TravelClass)
+				assert.Equal(t, []string{"WiFi", "Meal"}, receipt.AddOns)
+			},
+			ExpectedSeatFree: true,
+		},
+		"Sad Path - Empty mask": {
+			Request:      func(req *pb.UpdateBookingRequest) { req.UpdateMask = nil },
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Unknown field": {
+			Request:      func(req *pb.UpdateBookingRequest) { req.UpdateMask = updateMask("price") },
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Empty first name": {
+			Request:      func(req *pb.UpdateBookingRequest) { req.UpdateMask = updateMask("firstName") },
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Invalid email": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking.Email = "not an email"
+				req.UpdateMask = updateMask("email")
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Email of another user": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking.Email = "bobjohnson@gmail.com"
+				req.UpdateMask = updateMask("email")
+			},
+			ExpectedCode: codes.AlreadyExists,
+		},
+		"Sad Path - Unknown travel class": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking.TravelClass = "Business"
+				req.UpdateMask = updateMask("travelClass")
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Duplicate add-on": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking.AddOns = []string{"Meal", "meal"}
+				req.UpdateMask = updateMask("addOns")
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Occupied seat leaves the other fields unchanged": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking = &pb.BookingUpdate{FirstName: "Alicia", Seat: &pb.SeatRef{SeatId: "taken", SectionId: "S1"}}
+				req.UpdateMask = updateMask("firstName", "seat")
+			},
+			ExpectedCode: codes.FailedPrecondition,
+		},
+		"Sad Path - Stale version": {
+			Request: func(req *pb.UpdateBookingRequest) {
+				req.Booking.LastName = "Jones"
+				req.UpdateMask = updateMask("lastName")
+				req.ExpectedVersion = 7
+			},
+			ExpectedCode: codes.Aborted,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := InitializeStore()
+			bookingServer := &BookingServer{Store: store}
+			section := store.Train.Sections[0]
+			oldSeat, newSeat := section.Seats[0], section.Seats[2]
+			req := &pb.UpdateBookingRequest{ReceiptId: "11", Booking: &pb.BookingUpdate{Seat: &pb.SeatRef{SeatId: newSeat.Id}}}
+			tc.Request(req)
+			if req.Booking.GetSeat().GetSeatId() == "taken" {
+				req.Booking.Seat.SeatId = oldSeat.Id
+			}
+
+			res, err := bookingServer.UpdateBooking(context.Background(), req)
+			receipt := store.Receipts["11"]
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				assert.Equal(t, int64(1), receipt.Version)
+				assert.Equal(t, "Alice", dataStore.GetUser(store, "1").FirstName)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), res.Receipt.Version)
+			tc.ExpectedReceipt(t, res.Receipt)
+			assert.Equal(t, MapReceipt(receipt, dataStore.GetUser(store, "1")), res.Receipt)
+			assert.Equal(t, tc.ExpectedSeatFree, oldSeat.SeatAvailable)
+		})
+	}
+}
+
+func Test_UpdateBooking_Rollback(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store, faultHook: failAt("booking-update", "update-receipt")}
+	section := store.Train.Sections[0]
+
+	_, err := bookingServer.UpdateBooking(context.Background(), &pb.UpdateBookingRequest{
+		ReceiptId:  "11",
+		Booking:    &pb.BookingUpdate{LastName: "Jones", Seat: &pb.SeatRef{SeatId: section.Seats[2].Id, SectionId: "S1"}},
+		UpdateMask: updateMask("lastName", "seat"),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "Smith", dataStore.GetUser(store, "1").LastName)
+	assert.False(t, section.Seats[0].SeatAvailable)
+	assert.True(t, section.Seats[2].SeatAvailable)
+	assert.Equal(t, "1", store.Receipts["11"].SeatNumber)
+}
+
+func Test_ReadMask(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{Store: store}
+	ctx := context.Background()
+
+	receipts, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "1", ReadMask: updateMask("ReceiptId", "user.email", "seat")})
+	assert.NoError(t, err)
+	assert.Len(t, receipts.Receipt, 1)
+	assert.True(t, proto.Equal(&pb.Receipt{ReceiptId: "11", User: &pb.User{Email: "AliceSmith@gmaiil.com"}, Seat: "1"}, receipts.Receipt[0]))
+
+	seats, err := bookingServer.GetSectionBookingDetails(ctx, &pb.GetSectionBookingDetailsRequest{
+		SectionId: "S1", PageSize: 2, ReadMask: updateMask("SeatNumber", "SeatAvailable"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seats.SeatBookings, 2)
+	assert.True(t, proto.Equal(&pb.SeatBooking{SeatNumber: "1"}, seats.SeatBookings[0]))
+	assert.True(t, proto.Equal(&pb.SeatBooking{SeatNumber: "2", SeatAvailable: true}, seats.SeatBookings[1]))
+
+	// The mask may change between pages.
+	seats, err = bookingServer.GetSectionBookingDetails(ctx, &pb.GetSectionBookingDetailsRequest{
+		SectionId: "S1", PageSize: 2, PageToken: seats.NextPageToken, ReadMask: updateMask("seat_id"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, store.Train.Sections[0].Seats[2].Id, seats.SeatBookings[0].SeatId)
+	assert.Empty(t, seats.SeatBookings[0].SeatNumber)
+
+	for _, path := range []string{"Fare", "user.phone", "Seat.number"} {
+		_, err = bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "1", ReadMask: updateMask(path)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), path)
+	}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/auth"
+	"grpc-project/pkg/idempotency"
+	dataStore "grpc-project/pkg/store"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	Store *models.Store
+	// RequireAuth limits customers to their own account, staff can manage
+	// every account. Creating an account never requires authentication.
+	RequireAuth bool
+	// Idempotency is the cache of the booking service. The responses stored
+	// for a user are dropped when the user is erased.
+	Idempotency *idempotency.Cache
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if req == nil || req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Create User Request")
+	}
+	user, err := NewUser(req.User)
+	if err != nil {
+		return nil, err
+	}
+	if req.Password != "" {
+		user.PasswordHash, err = auth.HashPassword(req.Password)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+		}
+	}
+
+	s.Store.Mu.Lock()
+	defer s.Store.Mu.Unlock()
+
+	if err := dataStore.AddUser(s.Store, user); err != nil {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	return &pb.CreateUserResponse{User: MapUser(user)}, nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Get User Request")
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, req.UserId); err != nil {
+		return nil, err
+	}
+
+	s.Store.Mu.RLock()
+	defer s.Store.Mu.RUnlock()
+
+	user := dataStore.GetUser(s.Store, req.UserId)
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found for the given User ID : %s", req.UserId)
+	}
+	return &pb.GetUserResponse{User: MapUser(user)}, nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	if req == nil || req.User == nil || req.User.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Update User Request")
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, req.User.UserId); err != nil {
+		return nil, err
+	}
+	updated, err := NewUser(req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Store.Mu.Lock()
+	defer s.Store.Mu.Unlock()
+
+	user := dataStore.GetUser(s.Store, req.User.UserId)
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found for the given User ID : %s", req.User.UserId)
+	}
+	if other := dataStore.GetUserByEmail(s.Store, updated.Email); other != nil && other.Id != user.Id {
+		return nil, status.Errorf(codes.AlreadyExists, "user already exists for the given email : %s", updated.Email)
+	}
+	// Seats point at the user, so the change is visible on the seat manifest too.
+	user.FirstName = updated.FirstName
+	user.LastName = updated.LastName
+	user.Email = updated.Email
+	return &pb.UpdateUserResponse{User: MapUser(user)}, nil
+}
+
+func (s *UserServer) FindUserByEmail(ctx context.Context, req *pb.FindUserByEmailRequest) (*pb.FindUserByEmailResponse, error) {
+	if req == nil || req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Find User Request")
+	}
+	email, err := dataStore.NormalizeEmail(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.Store.Mu.RLock()
+	defer s.Store.Mu.RUnlock()
+
+	user := dataStore.GetUserByEmail(s.Store, email)
+	if user == nil {
+		if err := authorizeStaff(ctx, s.RequireAuth); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.NotFound, "user not found for the given email : %s", email)
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, user.Id); err != nil {
+		return nil, err
+	}
+	return &pb.FindUserByEmailResponse{User: MapUser(user)}, nil
+}
+
+// ExportUserData returns everything stored about a user: the account and
+// every receipt, confirmed or cancelled.
+func (s *UserServer) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Export User-Data Request")
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, req.UserId); err != nil {
+		return nil, err
+	}
+
+	s.Store.Mu.RLock()
+	defer s.Store.Mu.RUnlock()
+
+	user := dataStore.GetUser(s.Store, req.UserId)
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found for the given User ID : %s", req.UserId)
+	}
+	response := &pb.ExportUserDataResponse{User: MapUser(user)}
+	for _, receipt := range dataStore.GetUserReceipts(s.Store, user.Id) {
+		response.Receipts = append(response.Receipts, MapReceipt(receipt, user))
+	}
+	return response, nil
+}
+
+// EraseUser deletes a user's account and anonymizes their receipts. The
+// receipts are kept as financial records, but they no longer hold the email
+// and are moved to a random user ID that can't be linked to the user. Active
+// bookings have to be cancelled first. The stored idempotent responses about
+// the user are forgotten, whoever made the calls, since they hold the
+// user's details.
+func (s *UserServer) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid Erase User Request")
+	}
+	if err := authorizeUser(ctx, s.RequireAuth, req.UserId); err != nil {
+		return nil, err
+	}
+
+	s.Store.Mu.Lock()
+	defer s.Store.Mu.Unlock()
+
+	user := dataStore.GetUser(s.Store, req.UserId)
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found for the given User ID : %s", req.UserId)
+	}
+	receipts := dataStore.GetUserReceipts(s.Store, user.Id)
+	for _, receipt := range receipts {
+		if receipt.BookingStatus != "Cancelled" {
+			return nil, status.Errorf(codes.FailedPrecondition, "user has an active booking %s, cancel it before erasing the user", receipt.Id)
+		}
+	}
+	anonymousId := "erased-" + uuid.New().String()
+	for _, receipt := range receipts {
+		anonymized := *receipt
+		anonymized.UserId = anonymousId
+		anonymized.Email = ""
+		anonymized.Version++
+		if err := dataStore.UpdateReceipt(s.Store, anonymized); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to anonymize receipt %s: %v", receipt.Id, err)
+		}
+	}
+	dataStore.RemoveUser(s.Store, user.Id)
+	s.Idempotency.ForgetUser(user.Id)
+	return &pb.EraseUserResponse{AnonymizedReceipts: int32(len(receipts))}, nil
+}
+
+/*Helper Methods*/
+
+// NewUser validates the user details of a request and returns a user with a
+// normalized email address. A user ID is generated when none is given.
+func NewUser(user *pb.User) (*models.User, error) {
+	email, err := dataStore.NormalizeEmail(user.GetEmail())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	firstName := strings.TrimSpace(user.GetFirstName())
+	if firstName == "" {
+		return nil, status.Error(codes.InvalidArgument, "first name is required")
+	}
+	id := user.GetUserId()
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return &models.User{
+		Id:        id,
+		FirstName: firstName,
+		LastName:  strings.TrimSpace(user.GetLastName()),
+		Email:     email,
+	}, nil
+}
+
+func MapUser(user *models.User) *pb.User {
+	return &pb.User{
+		UserId:    user.Id,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-project/booking/proto"
+	dataStore "grpc-project/pkg/store"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_CreateUser(t *testing.T) {
+	store := InitializeStore()
+	type test struct {
+		CreateUserRequest *pb.CreateUserRequest
+		ExpectedUser      *pb.User
+		ExpectedCode      codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Email is normalized": {
+			CreateUserRequest: &pb.CreateUserRequest{
+				User: &pb.User{UserId: "3", FirstName: "Carol", LastName: "White", Email: "  Carol.White@Example.COM "},
+			},
+			ExpectedUser: &pb.User{UserId: "3", FirstName: "Carol", LastName: "White", Email: "carol.white@example.com"},
+		},
+		"Sad Path - Email already registered with different case": {
+			CreateUserRequest: &pb.CreateUserRequest{
+				User: &pb.User{FirstName: "Alice", Email: "alicesmith@GMAIIL.com"},
+			},
+			ExpectedCode: codes.AlreadyExists,
+		},
+		"Sad Path - User ID already registered": {
+			CreateUserRequest: &pb.CreateUserRequest{
+				User: &pb.User{UserId: "2", FirstName: "Bobby", Email: "bobby@example.com"},
+			},
+			ExpectedCode: codes.AlreadyExists,
+		},
+		"Sad Path - Invalid email": {
+			CreateUserRequest: &pb.CreateUserRequest{
+				User: &pb.User{FirstName: "Dave", Email: "Dave <dave@example.com>"},
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Missing first name": {
+			CreateUserRequest: &pb.CreateUserRequest{
+				User: &pb.User{Email: "nobody@example.com"},
+			},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Request without user": {
+			CreateUserRequest: &pb.CreateUserRequest{},
+			ExpectedCode:      codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			userServer := &UserServer{
+				Store: store,
+			}
+			res, err := userServer.CreateUser(context.Background(), tc.CreateUserRequest)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedUser.String(), res.User.String())
+		})
+	}
+}
+
+func Test_UserLifecycle(t *testing.T) {
+	store := InitializeStore()
+	userServer := &UserServer{
+		Store: store,
+	}
+	ctx := context.Background()
+
+	created, err := userServer.CreateUser(ctx, &pb.CreateUserRequest{
+		User: &pb.User{FirstName: "Carol", LastName: "White", Email: "carol@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, created.User.UserId, "a user ID should be generated")
+
+	found, err := userServer.FindUserByEmail(ctx, &pb.FindUserByEmailRequest{Email: "CAROL@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, created.User.UserId, found.User.UserId)
+
+	updated, err := userServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+		User: &pb.User{UserId: created.User.UserId, FirstName: "Carol", LastName: "Black", Email: "Carol.Black@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "carol.black@example.com", updated.User.Email)
+
+	got, err := userServer.GetUser(ctx, &pb.GetUserRequest{UserId: created.User.UserId})
+	assert.NoError(t, err)
+	assert.Equal(t, "Black", got.User.LastName)
+
+	_, err = userServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+		User: &pb.User{UserId: created.User.UserId, FirstName: "Carol", Email: "BobJohnson@gmail.com"},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = userServer.GetUser(ctx, &pb.GetUserRequest{UserId: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = userServer.FindUserByEmail(ctx, &pb.FindUserByEmailRequest{Email: "carol@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_PurchaseBooking_RegistersNewCustomer(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	ctx := context.Background()
+
+	res, err := bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From: "London",
+		To:   "France",
+		User: &pb.User{FirstName: "Carol", LastName: "White", Email: "Carol@Example.com"},
+	})
+	assert.NoError(t, err)
+	userId := res.Receipt.User.UserId
+	assert.NotEmpty(t, userId)
+	assert.Equal(t, "carol@example.com", res.Receipt.User.Email)
+
+	// The new customer's booking is visible to ShowReceipt.
+	show, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: userId})
+	assert.NoError(t, err)
+	assert.Len(t, show.Receipt, 1)
+
+	// A second booking with the same email resolves the same user.
+	res, err = bookingServer.PurchaseBooking(ctx, &pb.PurchaseBookingRequest{
+		From: "London",
+		To:   "France",
+		User: &pb.User{FirstName: "Carol", Email: " carol@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, userId, res.Receipt.User.UserId)
+	assert.Len(t, store.Users, 3)
+	assert.Len(t, store.ReceiptsByUser[userId], 2)
+}
+
+func Test_PurchaseBooking_RejectsEmailOfAnotherUser(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+
+	_, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+		From: "London",
+		To:   "France",
+		User: &pb.User{UserId: "9", FirstName: "Mallory", Email: "bobjohnson@gmail.com"},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Len(t, store.Users, 2)
+}
+
+func Test_PurchaseSaga_CompensatesRegistration(t *testing.T) {
+	steps := []string{"register-user", "reserve-seat", "decrement-section-seats", "store-receipt"}
+	for _, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			store := InitializeStore()
+			before := takeSnapshot(store)
+			bookingServer := &BookingServer{
+				Store:     store,
+				faultHook: failAt("purchase", step),
+			}
+
+			_, err := bookingServer.PurchaseBooking(context.Background(), &pb.PurchaseBookingRequest{
+				From: "London",
+				To:   "France",
+				User: &pb.User{FirstName: "Carol", Email: "carol@example.com"},
+			})
+
+			assert.EqualError(t, err, fmt.Sprintf("purchase failed at step %s: injected fault", step))
+			assert.Equal(t, before, takeSnapshot(store))
+			assert.Len(t, store.Users, 2)
+			assert.Nil(t, dataStore.GetUserByEmail(store, "carol@example.com"))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ReceiptVersion_IncrementsOnEveryChange(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	ctx := context.Background()
+
+	purchase, err := bookingServer.PurchaseBooking(ctx, newPurchaseRequest(""))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purchase.Receipt.Version)
+
+	update, err := bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:       purchase.Receipt.ReceiptId,
+		NewSeatId:       store.Train.Sections[0].Seats[4].Id,
+		NewSectionId:    store.Train.Sections[0].Id,
+		ExpectedVersion: purchase.Receipt.Version,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), update.UpdatedReceipt.Version)
+
+	show, err := bookingServer.ShowReceipt(ctx, &pb.ShowReceiptRequest{UserId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), show.Receipt[0].Version)
+
+	cancel, err := bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{
+		ReceiptId:       purchase.Receipt.ReceiptId,
+		ExpectedVersion: update.UpdatedReceipt.Version,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), cancel.Version)
+	assert.Equal(t, int64(3), store.Receipts[purchase.Receipt.ReceiptId].Version)
+}
+
+func Test_ReceiptVersion_MismatchIsAborted(t *testing.T) {
+	store := InitializeStore()
+	bookingServer := &BookingServer{
+		Store: store,
+	}
+	ctx := context.Background()
+	before := takeSnapshot(store)
+
+	// Two agents read version 1, the first one moves the seat.
+	_, err := bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:       "11",
+		NewSeatId:       store.Train.Sections[0].Seats[1].Id,
+		NewSectionId:    store.Train.Sections[0].Id,
+		ExpectedVersion: 1,
+	})
+	assert.NoError(t, err)
+	afterFirst := takeSnapshot(store)
+	assert.NotEqual(t, before, afterFirst)
+
+	// The second agent's stale edits are rejected and change nothing.
+	_, err = bookingServer.UpdateSeatBooking(ctx, &pb.UpdateSeatBookingRequest{
+		ReceiptId:       "11",
+		NewSeatId:       store.Train.Sections[0].Seats[2].Id,
+		NewSectionId:    store.Train.Sections[0].Id,
+		ExpectedVersion: 1,
+	})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{
+		ReceiptId:       "11",
+		ExpectedVersion: 1,
+	})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, afterFirst, takeSnapshot(store))
+
+	// After re-reading the receipt the retry succeeds.
+	_, err = bookingServer.DeleteBooking(ctx, &pb.DeleteBookingRequest{
+		ReceiptId:       "11",
+		ExpectedVersion: store.Receipts["11"].Version,
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles a user can have. Every role other than RoleCustomer is a staff role
+// that may act on behalf of other users.
+const (
+	RoleCustomer   = "customer"
+	RoleAgent      = "agent"
+	RoleSupervisor = "supervisor"
+	RoleAdmin      = "admin"
+)
+
+// IsStaff reports whether the role may act on behalf of other users.
+func IsStaff(role string) bool {
+	switch role {
+	case RoleAgent, RoleSupervisor, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Resource scopes a principal can be granted for a call.
+const (
+	// ScopeSelf limits the call to the caller's own account and bookings.
+	ScopeSelf = "self"
+	// ScopeAll lets the call act on behalf of any user.
+	ScopeAll = "all"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserId string
+	Email  string
+	Role   string
+	// Scope is the resource scope granted by the access policy for the
+	// current call. Without a policy it is empty and the role decides.
+	Scope string
+}
+
+// IsStaff reports whether the principal may act on behalf of other users in
+// the current call.
+func (p *Principal) IsStaff() bool {
+	if p == nil {
+		return false
+	}
+	if p.Scope != "" {
+		return p.Scope == ScopeAll
+	}
+	return IsStaff(p.Role)
+}
+
+// CanActFor reports whether the principal may see or change the data of the
+// given user: customers only their own, staff everybody's.
+func (p *Principal) CanActFor(userId string) bool {
+	return p != nil && (p.UserId == userId || p.IsStaff())
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil when the
+// request is not authenticated.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// PasswordCost is the bcrypt cost used for new password hashes.
+var PasswordCost = bcrypt.DefaultCost
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func CheckPassword(hash string, password string) bool {
+	return hash != "" && bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newKey(t *testing.T) SigningKey {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return SigningKey{Id: KeyId(public), PrivateKey: private}
+}
+
+func Test_Tokens(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokens := &Tokens{
+		Keys: &StaticKeySet{Key: newKey(t)},
+		TTL:  time.Hour,
+		Now:  func() time.Time { return now },
+	}
+	principal := Principal{UserId: "2", Email: "bob@example.com", Role: RoleAgent}
+	token, expiresAt, err := tokens.Issue(principal)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), expiresAt)
+
+	type test struct {
+		Tokens        *Tokens
+		Token         string
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Valid token": {
+			Tokens: tokens,
+			Token:  token,
+		},
+		"Sad Path - Expired token": {
+			Tokens:        &Tokens{Keys: tokens.Keys, Now: func() time.Time { return now.Add(2 * time.Hour) }},
+			Token:         token,
+			ExpectedError: true,
+		},
+		"Sad Path - Unknown signing key": {
+			Tokens:        &Tokens{Keys: &StaticKeySet{Key: newKey(t)}, Now: tokens.Now},
+			Token:         token,
+			ExpectedError: true,
+		},
+		"Sad Path - Tampered token": {
+			Tokens:        tokens,
+			Token:         token[:len(token)-4] + "AAAA",
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			verified, err := tc.Tokens.Verify(tc.Token)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, principal, *verified)
+		})
+	}
+}
+
+func Test_JWKSFile_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	oldKey, newKey := newKey(t), newKey(t)
+	writeJWKS := func(signing SigningKey, public map[string]ed25519.PublicKey) {
+		data, err := MarshalJWKS([]SigningKey{signing}, public)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(path, data, 0o600))
+	}
+	writeJWKS(oldKey, nil)
+
+	keys, err := NewJWKSFile(path, 0)
+	assert.NoError(t, err)
+	tokens := &Tokens{Keys: keys, TTL: time.Hour}
+	oldToken, _, err := tokens.Issue(Principal{UserId: "1"})
+	assert.NoError(t, err)
+
+	// Rotate: sign with the new key, keep accepting the old one.
+	writeJWKS(newKey, map[string]ed25519.PublicKey{oldKey.Id: oldKey.PrivateKey.Public().(ed25519.PublicKey)})
+	signing, err := keys.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, newKey.Id, signing.Id)
+	_, err = tokens.Verify(oldToken)
+	assert.NoError(t, err)
+
+	// A broken file keeps the current keys.
+	assert.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	_, err = tokens.Verify(oldToken)
+	assert.NoError(t, err)
+
+	// Retire the old key.
+	writeJWKS(newKey, nil)
+	_, err = tokens.Verify(oldToken)
+	assert.Error(t, err)
+}
+
+func Test_Authenticator(t *testing.T) {
+	tokens := &Tokens{Keys: &StaticKeySet{Key: newKey(t)}, TTL: time.Hour}
+	token, _, err := tokens.Issue(Principal{UserId: "2", Role: RoleCustomer})
+	assert.NoError(t, err)
+	authenticator := &Authenticator{
+		Tokens:        tokens,
+		PublicMethods: map[string]bool{"/booking.AuthService/Login": true},
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	withCertificate := func(commonName string, unit string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName, OrganizationalUnit: []string{unit}}}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+	}
+	certAuthenticator := &Authenticator{Tokens: tokens, TrustClientCertificates: true}
+
+	type test struct {
+		Authenticator     *Authenticator
+		Ctx               context.Context
+		Method            string
+		ExpectedPrincipal *Principal
+		ExpectedCode      codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Valid token": {
+			Ctx:               withToken(token),
+			Method:            "/booking.BookingService/ShowReceipt",
+			ExpectedPrincipal: &Principal{UserId: "2", Role: RoleCustomer},
+		},
+		"Happy Path - Public method without token": {
+			Ctx:    context.Background(),
+			Method: "/booking.AuthService/Login",
+		},
+		"Happy Path - Client certificate": {
+			Authenticator:     certAuthenticator,
+			Ctx:               withCertificate("ops-console", RoleAdmin),
+			Method:            "/booking.AdminService/CheckStoreInvariants",
+			ExpectedPrincipal: &Principal{UserId: "ops-console", Role: RoleAdmin},
+		},
+		"Happy Path - Token takes precedence over client certificate": {
+			Authenticator: certAuthenticator,
+			Ctx: metadata.NewIncomingContext(withCertificate("ops-console", RoleAdmin),
+				metadata.Pairs("authorization", "Bearer "+token)),
+			Method:            "/booking.BookingService/ShowReceipt",
+			ExpectedPrincipal: &Principal{UserId: "2", Role: RoleCustomer},
+		},
+		"Sad Path - Client certificate not trusted": {
+			Ctx:          withCertificate("ops-console", RoleAdmin),
+			Method:       "/booking.AdminService/CheckStoreInvariants",
+			ExpectedCode: codes.Unauthenticated,
+		},
+		"Sad Path - Missing token": {
+			Ctx:          context.Background(),
+			Method:       "/booking.BookingService/ShowReceipt",
+			ExpectedCode: codes.Unauthenticated,
+		},
+		"Sad Path - Invalid token on public method": {
+			Ctx:          withToken("garbage"),
+			Method:       "/booking.AuthService/Login",
+			ExpectedCode: codes.Unauthenticated,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := authenticator
+			if tc.Authenticator != nil {
+				a = tc.Authenticator
+			}
+			ctx, err := a.Authenticate(tc.Ctx, tc.Method)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedPrincipal, PrincipalFromContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"grpc-project/pkg/transport"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator verifies the bearer token of incoming requests and adds the
+// authenticated principal to the request context.
+type Authenticator struct {
+	Tokens *Tokens
+	// PublicMethods are full gRPC method names that can be called without a
+	// token, e.g. "/booking.AuthService/Login".
+	PublicMethods map[string]bool
+	// TrustClientCertificates authenticates calls without a token by the
+	// verified mTLS client certificate, see CertificatePrincipal.
+	TrustClientCertificates bool
+}
+
+// Authenticate returns ctx with the principal of the request's token or
+// client certificate. Public methods without either get ctx back unchanged.
+func (a *Authenticator) Authenticate(ctx context.Context, method string) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if cert := transport.PeerCertificate(ctx); a.TrustClientCertificates && cert != nil && cert.Subject.CommonName != "" {
+			return WithPrincipal(ctx, CertificatePrincipal(cert)), nil
+		}
+		if a.PublicMethods[method] || strings.HasPrefix(method, "/grpc.reflection.") {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	principal, err := a.Tokens.Verify(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// CertificatePrincipal returns the principal of a client certificate: the
+// common name is the user ID, the first email address the email and the
+// first organizational unit the role.
+func CertificatePrincipal(cert *x509.Certificate) *Principal {
+	principal := &Principal{UserId: cert.Subject.CommonName, Role: RoleCustomer}
+	if len(cert.EmailAddresses) > 0 {
+		principal.Email = cert.EmailAddresses[0]
+	}
+	if len(cert.Subject.OrganizationalUnit) > 0 {
+		principal.Role = cert.Subject.OrganizationalUnit[0]
+	}
+	return principal
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.Authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.Authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, value := range md.Get("authorization") {
+		if token, found := strings.CutPrefix(value, "Bearer "); found && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// TokenCredentials attaches a bearer token to every call of a client
+// connection.
+type TokenCredentials struct {
+	Token string
+	// Secure requires a secure transport before the token is sent.
+	Secure bool
+}
+
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.Token}, nil
+}
+
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return c.Secure
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"grpc-project/pkg/filewatch"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SigningKey is an Ed25519 key pair identified by a key ID.
+type SigningKey struct {
+	Id         string
+	PrivateKey ed25519.PrivateKey
+}
+
+// KeySet provides the key used to sign new tokens and the keys tokens are
+// verified against.
+type KeySet interface {
+	SigningKey() (SigningKey, error)
+	VerificationKey(id string) (ed25519.PublicKey, bool)
+}
+
+// StaticKeySet holds a single key that never changes.
+type StaticKeySet struct {
+	Key SigningKey
+}
+
+func (k *StaticKeySet) SigningKey() (SigningKey, error) {
+	return k.Key, nil
+}
+
+func (k *StaticKeySet) VerificationKey(id string) (ed25519.PublicKey, bool) {
+	if id != k.Key.Id {
+		return nil, false
+	}
+	return k.Key.PrivateKey.Public().(ed25519.PublicKey), true
+}
+
+// GenerateKeySet creates a key set with a fresh random key. Tokens signed
+// with it do not survive a restart.
+func GenerateKeySet() (*StaticKeySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticKeySet{Key: SigningKey{Id: KeyId(private.Public().(ed25519.PublicKey)), PrivateKey: private}}, nil
+}
+
+// LoadKeyFile reads a PKCS#8 PEM encoded Ed25519 private key.
+func LoadKeyFile(path string) (*StaticKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key in %s: %v", path, err)
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key in %s is not an Ed25519 key", path)
+	}
+	return &StaticKeySet{Key: SigningKey{Id: KeyId(private.Public().(ed25519.PublicKey)), PrivateKey: private}}, nil
+}
+
+// KeyId derives a stable key ID from a public key.
+func KeyId(public ed25519.PublicKey) string {
+	sum := sha256.Sum256(public)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+// JWKSFile is a key set read from a JSON Web Key Set file holding Ed25519
+// ("OKP") keys. The file is re-read when it changes, so keys can be rotated
+// without restarting the server: add the new key with its private part "d"
+// first in the list, keep the old public key until the tokens signed with it
+// have expired, then remove it.
+type JWKSFile struct {
+	mu      sync.Mutex
+	files   filewatch.Files
+	signing *SigningKey
+	keys    map[string]ed25519.PublicKey
+}
+
+// NewJWKSFile reads the key set at path and looks for rotated keys at most
+// once per checkInterval.
+func NewJWKSFile(path string, checkInterval time.Duration) (*JWKSFile, error) {
+	k := &JWKSFile{files: filewatch.New(checkInterval, path)}
+	if err := k.reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (k *JWKSFile) SigningKey() (SigningKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.refresh()
+	if k.signing == nil {
+		return SigningKey{}, fmt.Errorf("no private key found in %s", k.files.Paths[0])
+	}
+	return *k.signing, nil
+}
+
+func (k *JWKSFile) VerificationKey(id string) (ed25519.PublicKey, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.refresh()
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+// refresh reloads the file if it changed. Tokens go on being signed and
+// verified with the current keys while the new file is broken.
+func (k *JWKSFile) refresh() {
+	if err := k.reload(); err != nil {
+		log.Printf("failed to reload JWKS file, keeping the current keys: %v", err)
+	}
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	Kid string `json:"kid"`
+	X   string `json:"x"`
+	D   string `json:"d,omitempty"`
+}
+
+// reload reads the file again when it changed. The keys are only replaced
+// when the whole file is valid.
+func (k *JWKSFile) reload() error {
+	changed, err := k.files.Changed()
+	if err != nil || !changed {
+		return err
+	}
+	path := k.files.Paths[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse JWKS file %s: %v", path, err)
+	}
+	keys := make(map[string]ed25519.PublicKey)
+	var signing *SigningKey
+	for _, key := range set.Keys {
+		if key.Kty != "OKP" || key.Crv != "Ed25519" || key.Kid == "" {
+			continue
+		}
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid public key %s in JWKS file %s", key.Kid, path)
+		}
+		keys[key.Kid] = ed25519.PublicKey(x)
+		if key.D != "" && signing == nil {
+			d, err := base64.RawURLEncoding.DecodeString(key.D)
+			if err != nil || len(d) != ed25519.SeedSize {
+				return fmt.Errorf("invalid private key %s in JWKS file %s", key.Kid, path)
+			}
+			signing = &SigningKey{Id: key.Kid, PrivateKey: ed25519.NewKeyFromSeed(d)}
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no Ed25519 keys found in JWKS file %s", path)
+	}
+	k.keys = keys
+	k.signing = signing
+	return nil
+}
+
+// MarshalJWKS encodes keys as a JWKS document. Private parts are included
+// for the signing keys only.
+func MarshalJWKS(signing []SigningKey, public map[string]ed25519.PublicKey) ([]byte, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for _, key := range signing {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			Kid: key.Id,
+			X:   base64.RawURLEncoding.EncodeToString(key.PrivateKey.Public().(ed25519.PublicKey)),
+			D:   base64.RawURLEncoding.EncodeToString(key.PrivateKey.Seed()),
+		})
+	}
+	for id, key := range public {
+		set.Keys = append(set.Keys, jwk{Kty: "OKP", Crv: "Ed25519", Kid: id, X: base64.RawURLEncoding.EncodeToString(key)})
+	}
+	return json.MarshalIndent(set, "", "  ")
+}
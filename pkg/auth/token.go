@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer is the "iss" claim of the tokens issued by the booking service.
+const Issuer = "grpc-project/booking"
+
+// Claims are the JWT claims of an access token.
+type Claims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Tokens issues and verifies EdDSA signed JWT access tokens.
+type Tokens struct {
+	Keys KeySet
+	TTL  time.Duration
+	// Now returns the current time, it defaults to time.Now.
+	Now func() time.Time
+}
+
+func (t *Tokens) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+// Issue returns a signed access token for the principal and its expiry.
+func (t *Tokens) Issue(principal Principal) (string, time.Time, error) {
+	key, err := t.Keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := t.now()
+	expiresAt := now.Add(t.TTL)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    Issuer,
+			Subject:   principal.UserId,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Email: principal.Email,
+		Role:  principal.Role,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = key.Id
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Verify checks the signature, issuer and expiry of a token and returns the
+// principal it was issued for.
+func (t *Tokens) Verify(token string) (*Principal, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		id, _ := token.Header["kid"].(string)
+		key, ok := t.Keys.VerificationKey(id)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", id)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(t.now),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	role := claims.Role
+	if role == "" {
+		role = RoleCustomer
+	}
+	return &Principal{UserId: claims.Subject, Email: claims.Email, Role: role}, nil
+}
@@ -0,0 +1,52 @@
+// Package filewatch tells when files that are read at startup, such as
+// certificates, policies or key sets, have changed and should be read again.
+package filewatch
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Files detects changes to a set of files by modification time and size.
+// It is not safe for concurrent use; the loaders using it hold their own
+// lock.
+type Files struct {
+	Paths []string
+	// Interval limits how often the files are looked at.
+	Interval time.Duration
+
+	lastCheck time.Time
+	stamps    []string
+}
+
+// New returns a Files for paths that are looked at most once per interval.
+func New(interval time.Duration, paths ...string) Files {
+	return Files{Paths: paths, Interval: interval}
+}
+
+// Changed reports whether any file changed since the last call, checking at
+// most once per Interval. The first call always reports a change. A change
+// is only reported once, even when reading the new files fails, so a broken
+// file is not read again until it is replaced.
+func (f *Files) Changed() (bool, error) {
+	now := time.Now()
+	if f.stamps != nil && now.Sub(f.lastCheck) < f.Interval {
+		return false, nil
+	}
+	f.lastCheck = now
+	stamps := make([]string, len(f.Paths))
+	for i, path := range f.Paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		stamps[i] = fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+	}
+	if f.stamps != nil && slices.Equal(stamps, f.stamps) {
+		return false, nil
+	}
+	f.stamps = stamps
+	return true, nil
+}
@@ -0,0 +1,40 @@
+package filewatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Files_Changed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+	files := New(0, path)
+
+	changed, err := files.Changed()
+	assert.NoError(t, err)
+	assert.True(t, changed, "the first call reports a change")
+	changed, err = files.Changed()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"rules": []}`), 0o600))
+	changed, err = files.Changed()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	// Changes are not looked for again within the interval.
+	files.Interval = time.Hour
+	assert.NoError(t, os.WriteFile(path, []byte(`{"rules": [{}]}`), 0o600))
+	changed, err = files.Changed()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.NoError(t, os.Remove(path))
+	files.Interval = 0
+	_, err = files.Changed()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
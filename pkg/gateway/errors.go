@@ -0,0 +1,44 @@
+package gateway
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorHandler writes every failed call, including unknown routes and
+// malformed bodies, as a booking.Error body with the HTTP status mapped from
+// the gRPC status code.
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	s := status.Convert(err)
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			if name, ok := outgoingHeader(key); ok {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+		}
+	}
+	body := &pb.Error{
+		Code:      int32(httpStatus),
+		Status:    code.Code(s.Code()).String(),
+		Message:   s.Message(),
+		RequestId: w.Header().Get("X-Request-Id"),
+	}
+	data, err := marshaler.Marshal(body)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"code": 500, "status": "INTERNAL", "message": "failed to marshal error"}`))
+		return
+	}
+	w.Header().Set("Content-Type", marshaler.ContentType(body))
+	w.WriteHeader(httpStatus)
+	w.Write(data)
+}
@@ -0,0 +1,81 @@
+// Package gateway serves the BookingService as REST/JSON by translating HTTP
+// requests into calls on the gRPC server.
+package gateway
+
+import (
+	"context"
+	pb "grpc-project/booking/proto"
+	pbv2 "grpc-project/booking/proto/v2"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// OpenAPIPath is where the OpenAPI document of the gateway is served.
+const OpenAPIPath = "/openapi.json"
+
+// OpenAPIV2Path is where the OpenAPI document of the version 2 routes is
+// served.
+const OpenAPIV2Path = "/v2/openapi.json"
+
+// forwardedHeaders are passed between HTTP headers and gRPC metadata as they
+// are. The Authorization header is always forwarded.
+var forwardedHeaders = map[string]bool{
+	"X-Request-Id": true,
+	"Retry-After":  true,
+}
+
+// New returns the HTTP handler of the gateway. Calls go through conn, so
+// they pass the interceptors of the gRPC server like any other call.
+func New(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithErrorHandler(ErrorHandler),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	if err := pb.RegisterBookingServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterAuthServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := pbv2.RegisterBookingServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc(OpenAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pb.OpenAPI)
+	})
+	handler.HandleFunc(OpenAPIV2Path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pbv2.OpenAPI)
+	})
+	handler.Handle("/", mux)
+	return handler, nil
+}
+
+func incomingHeader(key string) (string, bool) {
+	if forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == "Content-Type" {
+		return "", false
+	}
+	if forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+		return key, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,195 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	pb "grpc-project/booking/proto"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bookingServer books every purchase of an authenticated caller and knows no
+// receipts.
+type bookingServer struct {
+	pb.UnimplementedBookingServiceServer
+}
+
+func (b *bookingServer) PurchaseBooking(ctx context.Context, req *pb.PurchaseBookingRequest) (*pb.PurchaseBookingResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	return &pb.PurchaseBookingResponse{Receipt: &pb.Receipt{ReceiptId: "r1", From: req.From, To: req.To, PricePaid: req.PricePaid}}, nil
+}
+
+func (b *bookingServer) DeleteBooking(ctx context.Context, req *pb.DeleteBookingRequest) (*pb.DeleteBookingResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", strings.Join(md.Get("x-request-id"), "")))
+	return nil, status.Errorf(codes.NotFound, "receipt %s not found", req.ReceiptId)
+}
+
+// UpdateBooking refuses every update, naming the paths of its update mask.
+func (b *bookingServer) UpdateBooking(ctx context.Context, req *pb.UpdateBookingRequest) (*pb.UpdateBookingResponse, error) {
+	return nil, status.Errorf(codes.InvalidArgument, "cannot update %s", strings.Join(req.UpdateMask.GetPaths(), ", "))
+}
+
+// startGateway serves the gateway over HTTP in front of a gRPC server on
+// bufconn.
+func startGateway(t *testing.T) string {
+	s := grpc.NewServer()
+	pb.RegisterBookingServiceServer(s, &bookingServer{})
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	handler, err := New(context.Background(), conn)
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func Test_Gateway(t *testing.T) {
+	url := startGateway(t)
+
+	type test struct {
+		Method         string
+		Path           string
+		Body           string
+		Header         map[string]string
+		ExpectedStatus int
+		ExpectedBody   map[string]interface{}
+	}
+	tests := map[string]test{
+		"Happy Path - Purchase a booking": {
+			Method:         http.MethodPost,
+			Path:           "/v1/bookings",
+			Body:           `{"From": "London", "To": "France", "PricePaid": 20}`,
+			Header:         map[string]string{"Authorization": "Bearer token"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: map[string]interface{}{"receipt": map[string]interface{}{
+				"ReceiptId": "r1", "From": "London", "To": "France", "PricePaid": 20.0,
+				"user": nil, "Section": "", "Seat": "", "BookingStatus": "", "Version": "0", "bookedAt": "", "reference": "", "travelClass": "", "addOns": []interface{}{}, "price": nil, "charged": nil, "exchangeRate": nil,
+			}},
+		},
+		"Sad Path - Status code of the service": {
+			Method:         http.MethodPost,
+			Path:           "/v1/bookings",
+			Body:           `{"From": "London", "To": "France"}`,
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   map[string]interface{}{"code": 401.0, "status": "UNAUTHENTICATED", "message": "missing bearer token", "requestId": ""},
+		},
+		"Sad Path - Unknown receipt with request ID": {
+			Method:         http.MethodDelete,
+			Path:           "/v1/bookings/r9",
+			Header:         map[string]string{"X-Request-Id": "req-123"},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   map[string]interface{}{"code": 404.0, "status": "NOT_FOUND", "message": "receipt r9 not found", "requestId": "req-123"},
+		},
+		"Sad Path - Update mask from the body": {
+			Method:         http.MethodPatch,
+			Path:           "/v1/bookings/r1",
+			Body:           `{"travelClass": "First", "seat": {"seatId": "s1"}}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   map[string]interface{}{"code": 400.0, "status": "INVALID_ARGUMENT", "message": "cannot update seat.seatId, travelClass", "requestId": ""},
+		},
+		"Sad Path - Malformed body": {
+			Method:         http.MethodPost,
+			Path:           "/v1/bookings",
+			Body:           `{"From": `,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		"Sad Path - Method not implemented": {
+			Method:         http.MethodGet,
+			Path:           "/v1/users/1/receipts",
+			ExpectedStatus: http.StatusNotImplemented,
+		},
+		"Sad Path - Unknown route": {
+			Method:         http.MethodGet,
+			Path:           "/v1/trains",
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   map[string]interface{}{"code": 404.0, "status": "NOT_FOUND", "message": "Not Found", "requestId": ""},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.Method, url+tc.Path, strings.NewReader(tc.Body))
+			assert.NoError(t, err)
+			for key, value := range tc.Header {
+				req.Header.Set(key, value)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+			assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			if tc.ExpectedBody != nil {
+				assert.Equal(t, tc.ExpectedBody, body)
+			}
+			if tc.ExpectedStatus != http.StatusOK {
+				assert.Equal(t, float64(tc.ExpectedStatus), body["code"])
+			}
+		})
+	}
+}
+
+func Test_OpenAPI(t *testing.T) {
+	url := startGateway(t)
+
+	type test struct {
+		Path            string
+		ExpectedVersion string
+		ExpectedPath    string
+	}
+	tests := map[string]test{
+		"Happy Path - Version 1": {
+			Path:            OpenAPIPath,
+			ExpectedVersion: "1.0",
+			ExpectedPath:    "/v1/bookings/{ReceiptId}:changeSeat",
+		},
+		"Happy Path - Version 2": {
+			Path:            OpenAPIV2Path,
+			ExpectedVersion: "2.0",
+			ExpectedPath:    "/v2/bookings/{bookingId}:cancel",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := http.Get(url + tc.Path)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+			data, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			var spec struct {
+				Info  map[string]string
+				Paths map[string]interface{}
+			}
+			assert.NoError(t, json.Unmarshal(data, &spec))
+			assert.Equal(t, "Train Booking API", spec.Info["title"])
+			assert.Equal(t, tc.ExpectedVersion, spec.Info["version"])
+			assert.Contains(t, spec.Paths, tc.ExpectedPath)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Package health reports the serving status of the gRPC services through
+// grpc.health.v1.
+package health
+
+import (
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Server is the grpc.health.v1 service of the server. The status of the whole
+// server is reported under the empty service name.
+type Server struct {
+	*grpchealth.Server
+	services []string
+}
+
+// New registers the health service with s. Every service starts as
+// NOT_SERVING until SetReady is called.
+func New(s grpc.ServiceRegistrar, services ...string) *Server {
+	h := &Server{
+		Server:   grpchealth.NewServer(),
+		services: append([]string{""}, services...),
+	}
+	h.setAll(healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, h.Server)
+	return h
+}
+
+// SetReady reports every service as SERVING.
+func (h *Server) SetReady() {
+	h.setAll(healthpb.HealthCheckResponse_SERVING)
+}
+
+// Drain reports every service as NOT_SERVING for good, so that load
+// balancers stop sending new calls while the server shuts down.
+func (h *Server) Drain() {
+	h.Server.Shutdown()
+}
+
+func (h *Server) setAll(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range h.services {
+		h.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves the health service over bufconn.
+func startServer(t *testing.T) (*Server, healthpb.HealthClient) {
+	s := grpc.NewServer()
+	h := New(s, "booking.BookingService")
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return h, healthpb.NewHealthClient(conn)
+}
+
+func Test_Status(t *testing.T) {
+	h, client := startServer(t)
+	check := func(service string) (healthpb.HealthCheckResponse_ServingStatus, codes.Code) {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		return res.GetStatus(), status.Code(err)
+	}
+
+	type test struct {
+		Name           string
+		Prepare        func()
+		Service        string
+		ExpectedStatus healthpb.HealthCheckResponse_ServingStatus
+		ExpectedCode   codes.Code
+	}
+	// The cases share the server and run in order.
+	tests := []test{
+		{
+			Name:           "Happy Path - Not serving before the store is loaded",
+			Service:        "booking.BookingService",
+			ExpectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			Name:           "Happy Path - Service is ready",
+			Prepare:        h.SetReady,
+			Service:        "booking.BookingService",
+			ExpectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			Name:           "Happy Path - Server is ready",
+			ExpectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			Name:         "Sad Path - Unknown service",
+			Service:      "booking.Unknown",
+			ExpectedCode: codes.NotFound,
+		},
+		{
+			Name:           "Happy Path - Not serving while draining",
+			Prepare:        h.Drain,
+			Service:        "booking.BookingService",
+			ExpectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			Name:           "Happy Path - Draining is final",
+			Prepare:        h.SetReady,
+			ExpectedStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Prepare != nil {
+				tc.Prepare()
+			}
+			got, code := check(tc.Service)
+			assert.Equal(t, tc.ExpectedCode, code)
+			assert.Equal(t, tc.ExpectedStatus, got)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MetadataKey is the gRPC metadata header clients can use to send an
+// idempotency key instead of setting it on the request message.
+const MetadataKey = "idempotency-key"
+
+// DefaultRetention is how long the outcome of a request is remembered.
+const DefaultRetention = 24 * time.Hour
+
+type record struct {
+	method      string
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	response    proto.Message
+	// userId is the user the response is about, empty when it holds no
+	// user data.
+	userId    string
+	err       error
+	expiresAt time.Time
+}
+
+// Cache remembers the outcome of mutating requests per idempotency key so
+// that retried requests get the original response instead of being executed
+// a second time.
+type Cache struct {
+	mu        sync.Mutex
+	retention time.Duration
+	records   map[string]*record
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func New(retention time.Duration) *Cache {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Cache{
+		retention: retention,
+		records:   make(map[string]*record),
+		now:       time.Now,
+	}
+}
+
+// Key returns the idempotency key of a request: the key set on the request
+// message wins over the one sent in the metadata header.
+func Key(ctx context.Context, requestKey string) string {
+	if requestKey != "" {
+		return requestKey
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// Do runs call once per key. Replays of the same method and payload return
+// the stored response, a replay with a different payload is rejected with
+// codes.InvalidArgument. A replay while the original call still runs waits
+// for its outcome, or until ctx is done. Failed and panicking calls are not
+// remembered so they can be retried with the same key. The idempotency key
+// field must be cleared from req by the caller so it does not take part in
+// the payload comparison. userOf returns the user a response is about, so
+// it can be forgotten with ForgetUser; it is nil when responses hold no
+// user data.
+func Do[T proto.Message](ctx context.Context, c *Cache, key string, method string, req proto.Message, userOf func(T) string, call func() (T, error)) (response T, err error) {
+	var zero T
+	if c == nil || key == "" {
+		return call()
+	}
+	fingerprint, err := fingerprintOf(method, req)
+	if err != nil {
+		return zero, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+	}
+
+	c.mu.Lock()
+	c.sweep()
+	for {
+		rec, exists := c.records[key]
+		if !exists {
+			break
+		}
+		if rec.done == nil && c.now().After(rec.expiresAt) {
+			delete(c.records, key)
+			break
+		}
+		if rec.method != method || rec.fingerprint != fingerprint {
+			c.mu.Unlock()
+			return zero, status.Errorf(codes.InvalidArgument, "idempotency key %q was already used with a different request", key)
+		}
+		if rec.done == nil {
+			c.mu.Unlock()
+			return proto.Clone(rec.response).(T), nil
+		}
+		// The original request is still running, wait for its outcome.
+		done := rec.done
+		c.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return zero, status.FromContextError(ctx.Err()).Err()
+		}
+		c.mu.Lock()
+	}
+	rec := &record{method: method, fingerprint: fingerprint, done: make(chan struct{})}
+	c.records[key] = rec
+	c.mu.Unlock()
+
+	// The record is settled even when call panics, so waiters and retries
+	// do not block on it forever.
+	returned := false
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !returned || err != nil {
+			delete(c.records, key)
+		} else {
+			rec.response = proto.Clone(response)
+			if userOf != nil {
+				rec.userId = userOf(response)
+			}
+			rec.expiresAt = c.now().Add(c.retention)
+		}
+		close(rec.done)
+		rec.done = nil
+	}()
+	response, err = call()
+	returned = true
+	return response, err
+}
+
+// ForgetUser drops the stored responses about a user, whoever made the
+// calls, so they are not kept after the user's data has to be gone. Calls
+// still running are not affected.
+func (c *Cache) ForgetUser(userId string) {
+	if c == nil || userId == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, rec := range c.records {
+		if rec.done == nil && rec.userId == userId {
+			delete(c.records, key)
+		}
+	}
+}
+
+// sweep drops expired records. It runs at most once a minute and must be
+// called with c.mu held.
+func (c *Cache) sweep() {
+	now := c.now()
+	if now.Sub(c.lastSweep) < time.Minute {
+		return
+	}
+	c.lastSweep = now
+	for key, rec := range c.records {
+		if rec.done == nil && now.After(rec.expiresAt) {
+			delete(c.records, key)
+		}
+	}
+}
+
+func fingerprintOf(method string, req proto.Message) ([sha256.Size]byte, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(append([]byte(method+"\x00"), payload...)), nil
+}
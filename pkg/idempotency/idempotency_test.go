@@ -0,0 +1,223 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// counter returns a call that counts how often it runs and responds with the
+// number of the run.
+func counter(calls *atomic.Int32) func() (*wrapperspb.Int32Value, error) {
+	return func() (*wrapperspb.Int32Value, error) {
+		return wrapperspb.Int32(calls.Add(1)), nil
+	}
+}
+
+func Test_Do(t *testing.T) {
+	type test struct {
+		FirstMethod   string
+		FirstRequest  proto.Message
+		SecondMethod  string
+		SecondRequest proto.Message
+		ExpectedCalls int32
+		ExpectedCode  codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Replay returns the stored response": {
+			FirstMethod: "Purchase", FirstRequest: wrapperspb.String("a"),
+			SecondMethod: "Purchase", SecondRequest: wrapperspb.String("a"),
+			ExpectedCalls: 1,
+		},
+		"Sad Path - Replay with a different payload": {
+			FirstMethod: "Purchase", FirstRequest: wrapperspb.String("a"),
+			SecondMethod: "Purchase", SecondRequest: wrapperspb.String("b"),
+			ExpectedCalls: 1, ExpectedCode: codes.InvalidArgument,
+		},
+		"Sad Path - Replay with a different method": {
+			FirstMethod: "Purchase", FirstRequest: wrapperspb.String("a"),
+			SecondMethod: "Delete", SecondRequest: wrapperspb.String("a"),
+			ExpectedCalls: 1, ExpectedCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := New(time.Hour)
+			ctx := context.Background()
+			var calls atomic.Int32
+
+			first, err := Do(ctx, cache, "1/key", tc.FirstMethod, tc.FirstRequest, nil, counter(&calls))
+			assert.NoError(t, err)
+			second, err := Do(ctx, cache, "1/key", tc.SecondMethod, tc.SecondRequest, nil, counter(&calls))
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+			assert.Equal(t, tc.ExpectedCalls, calls.Load())
+			if err == nil {
+				assert.True(t, proto.Equal(first, second), second)
+			}
+		})
+	}
+}
+
+func Test_Do_WithoutKey(t *testing.T) {
+	cache := New(time.Hour)
+	var calls atomic.Int32
+	for range 2 {
+		_, err := Do(context.Background(), cache, "", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_Do_FailedCallIsRetried(t *testing.T) {
+	cache := New(time.Hour)
+	ctx := context.Background()
+	_, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, func() (*wrapperspb.Int32Value, error) {
+		return nil, errors.New("failed")
+	})
+	assert.Error(t, err)
+
+	var calls atomic.Int32
+	_, err = Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_Do_Expiry(t *testing.T) {
+	cache := New(time.Hour)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	_, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+	assert.NoError(t, err)
+	now = now.Add(59 * time.Minute)
+	_, err = Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Once expired the key can be used again, even with another payload.
+	now = now.Add(2 * time.Minute)
+	res, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("b"), nil, counter(&calls))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), res.Value)
+}
+
+func Test_Cache_ForgetUser(t *testing.T) {
+	cache := New(time.Hour)
+	ctx := context.Background()
+	var calls atomic.Int32
+	// The user a response is about does not depend on who made the call:
+	// 9 stands for staff acting for user 1, "c" for a call without
+	// authentication.
+	keys := map[string]string{"1/a": "1", "9/b": "1", "c": "1", "1/d": "12"}
+	run := func() {
+		for key, userId := range keys {
+			userOf := func(*wrapperspb.Int32Value) string { return userId }
+			_, err := Do(ctx, cache, key, "Purchase", wrapperspb.String(key), userOf, counter(&calls))
+			assert.NoError(t, err)
+		}
+	}
+	run()
+
+	cache.ForgetUser("1")
+	run()
+	// The keys about user 1 ran again, the key about user 12 was replayed.
+	assert.Equal(t, int32(7), calls.Load())
+}
+
+func Test_Do_ConcurrentWaiters(t *testing.T) {
+	cache := New(time.Hour)
+	ctx := context.Background()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, func() (*wrapperspb.Int32Value, error) {
+			close(started)
+			<-release
+			return wrapperspb.Int32(calls.Add(1)), nil
+		})
+		assert.NoError(t, err)
+	}()
+	<-started
+
+	responses := make([]*wrapperspb.Int32Value, 5)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+			assert.NoError(t, err)
+			responses[i] = res
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, res := range responses {
+		assert.Equal(t, int32(1), res.GetValue())
+	}
+}
+
+func Test_Do_WaiterContextDone(t *testing.T) {
+	cache := New(time.Hour)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		_, _ = Do(context.Background(), cache, "1/key", "Purchase", wrapperspb.String("a"), nil, func() (*wrapperspb.Int32Value, error) {
+			close(started)
+			<-release
+			return wrapperspb.Int32(1), nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, func() (*wrapperspb.Int32Value, error) {
+		t.Error("the waiter must not run the call")
+		return nil, nil
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	close(release)
+	<-finished
+}
+
+func Test_Do_PanickingCall(t *testing.T) {
+	cache := New(time.Hour)
+	ctx := context.Background()
+	func() {
+		defer func() {
+			assert.NotNil(t, recover())
+		}()
+		_, _ = Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, func() (*wrapperspb.Int32Value, error) {
+			panic("boom")
+		})
+	}()
+
+	// The key is not left in flight: a retry runs the call instead of
+	// waiting forever.
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	var calls atomic.Int32
+	res, err := Do(ctx, cache, "1/key", "Purchase", wrapperspb.String("a"), nil, counter(&calls))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), res.GetValue())
+}
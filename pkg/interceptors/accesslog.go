@@ -0,0 +1,104 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// AccessLog writes one structured log record per call with the method,
+// status code, latency, request ID, user ID and, for traced calls, trace ID.
+//
+// The access log runs before authentication, so the user is only known
+// further down the chain: the UserUnary and UserStream interceptors, chained
+// after authentication, record it on the log entry of the call.
+type AccessLog struct {
+	Logger *slog.Logger
+	// UserId returns the authenticated user of a call.
+	UserId func(ctx context.Context) string
+}
+
+type entryKey struct{}
+
+// entry collects the attributes of a call's log record that are only known
+// inside the chain.
+type entry struct {
+	mu     sync.Mutex
+	userId string
+}
+
+func (l *AccessLog) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		e := &entry{}
+		start := time.Now()
+		resp, err := handler(context.WithValue(ctx, entryKey{}, e), req)
+		l.log(ctx, info.FullMethod, "unary", e, start, err)
+		return resp, err
+	}
+}
+
+func (l *AccessLog) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		e := &entry{}
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), entryKey{}, e)})
+		l.log(ss.Context(), info.FullMethod, "stream", e, start, err)
+		return err
+	}
+}
+
+// UserUnary records the authenticated user on the log entry of the call.
+func (l *AccessLog) UserUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		l.recordUser(ctx)
+		return handler(ctx, req)
+	}
+}
+
+func (l *AccessLog) UserStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		l.recordUser(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+func (l *AccessLog) recordUser(ctx context.Context) {
+	e, ok := ctx.Value(entryKey{}).(*entry)
+	if !ok || l.UserId == nil {
+		return
+	}
+	e.mu.Lock()
+	e.userId = l.UserId(ctx)
+	e.mu.Unlock()
+}
+
+func (l *AccessLog) log(ctx context.Context, method string, kind string, e *entry, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	e.mu.Lock()
+	userId := e.userId
+	e.mu.Unlock()
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("type", kind),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("request_id", RequestIdFromContext(ctx)),
+		slog.String("user_id", userId),
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	l.Logger.LogAttrs(ctx, level, "rpc", attrs...)
+}
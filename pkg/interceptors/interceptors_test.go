@@ -0,0 +1,226 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer answers with SERVING, fails Check when the request ID is
+// missing from the context and panics for the "panic" service.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.Service == "panic" {
+		panic("boom")
+	}
+	if RequestIdFromContext(ctx) == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no request ID")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if req.Service == "panic" {
+		panic("boom")
+	}
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+// syncBuffer is a log sink that can be written by the server and read by the
+// test.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records returns the decoded JSON log records with the given message.
+func (b *syncBuffer) records(msg string) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var record map[string]interface{}
+		if json.Unmarshal([]byte(line), &record) == nil && record["msg"] == msg {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+type userKey struct{}
+
+// startServer serves the health service over bufconn with the full chain. The
+// user of a call is taken from the "user" metadata by a stand-in for the
+// authenticator.
+func startServer(t *testing.T) (healthpb.HealthClient, *syncBuffer) {
+	logs := &syncBuffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+	accessLog := &AccessLog{
+		Logger: logger,
+		UserId: func(ctx context.Context) string {
+			user, _ := ctx.Value(userKey{}).(string)
+			return user
+		},
+	}
+	recovery := &Recovery{Logger: logger}
+	authenticate := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if users := md.Get("user"); len(users) > 0 {
+			ctx = context.WithValue(ctx, userKey{}, users[0])
+		}
+		return handler(ctx, req)
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(RequestIdUnary(), accessLog.Unary(), recovery.Unary(), authenticate, accessLog.UserUnary()),
+		grpc.ChainStreamInterceptor(RequestIdStream(), accessLog.Stream(), recovery.Stream(), accessLog.UserStream()),
+	)
+	healthpb.RegisterHealthServer(s, &healthServer{})
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn), logs
+}
+
+func Test_UnaryChain(t *testing.T) {
+	type test struct {
+		Ctx               context.Context
+		Service           string
+		ExpectedRequestId string
+		ExpectedUserId    string
+		ExpectedCode      codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Request ID is generated": {
+			Ctx: context.Background(),
+		},
+		"Happy Path - Request ID of the client is kept": {
+			Ctx:               metadata.AppendToOutgoingContext(context.Background(), RequestIdKey, "req-123"),
+			ExpectedRequestId: "req-123",
+		},
+		"Happy Path - Invalid request ID is replaced": {
+			Ctx: metadata.AppendToOutgoingContext(context.Background(), RequestIdKey, "has spaces"),
+		},
+		"Happy Path - User is logged": {
+			Ctx:            metadata.AppendToOutgoingContext(context.Background(), "user", "2"),
+			ExpectedUserId: "2",
+		},
+		"Sad Path - Panic becomes an internal error": {
+			Ctx:            metadata.AppendToOutgoingContext(context.Background(), "user", "2"),
+			Service:        "panic",
+			ExpectedUserId: "2",
+			ExpectedCode:   codes.Internal,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, logs := startServer(t)
+			var header metadata.MD
+			_, err := client.Check(tc.Ctx, &healthpb.HealthCheckRequest{Service: tc.Service}, grpc.Header(&header))
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+
+			requestIds := header.Get(RequestIdKey)
+			assert.Len(t, requestIds, 1)
+			requestId := requestIds[0]
+			if tc.ExpectedRequestId != "" {
+				assert.Equal(t, tc.ExpectedRequestId, requestId)
+			} else {
+				assert.True(t, validRequestId(requestId))
+				assert.NotEqual(t, "has spaces", requestId)
+			}
+
+			records := logs.records("rpc")
+			assert.Len(t, records, 1)
+			record := records[0]
+			assert.Equal(t, "/grpc.health.v1.Health/Check", record["method"])
+			assert.Equal(t, tc.ExpectedCode.String(), record["code"])
+			assert.Equal(t, requestId, record["request_id"])
+			assert.Equal(t, tc.ExpectedUserId, record["user_id"])
+			assert.Contains(t, record, "latency")
+
+			if tc.ExpectedCode == codes.Internal {
+				assert.Contains(t, status.Convert(err).Message(), requestId)
+				panics := logs.records("panic in handler")
+				assert.Len(t, panics, 1)
+				assert.Equal(t, "boom", panics[0]["panic"])
+				assert.Equal(t, requestId, panics[0]["request_id"])
+			}
+		})
+	}
+}
+
+func Test_StreamChain(t *testing.T) {
+	client, logs := startServer(t)
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{Service: "panic"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The server survives the panic.
+	stream, err = client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	res, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+	header, err := stream.Header()
+	assert.NoError(t, err)
+	assert.Len(t, header.Get(RequestIdKey), 1)
+
+	assert.Eventually(t, func() bool { return len(logs.records("rpc")) == 2 }, time.Second, 10*time.Millisecond)
+	records := logs.records("rpc")
+	assert.ElementsMatch(t, []interface{}{"Internal", "OK"}, []interface{}{records[0]["code"], records[1]["code"]})
+	assert.Equal(t, "stream", records[0]["type"])
+}
+
+func Test_Metrics(t *testing.T) {
+	metrics := NewMetrics(prometheus.NewRegistry())
+	interceptor := metrics.Unary()
+	info := &grpc.UnaryServerInfo{FullMethod: "/booking.BookingService/PurchaseBooking"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	fail := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.ResourceExhausted, "sold out")
+	}
+
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, fail)
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(metrics.started.WithLabelValues("booking.BookingService", "PurchaseBooking", "unary")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.handled.WithLabelValues("booking.BookingService", "PurchaseBooking", "unary", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.handled.WithLabelValues("booking.BookingService", "PurchaseBooking", "unary", "ResourceExhausted")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.latency))
+}
@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics records per-method request counts, status codes and latencies of
+// the server's calls.
+type Metrics struct {
+	started *prometheus.CounterVec
+	handled *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Number of calls started on the server.",
+		}, []string{"grpc_service", "grpc_method", "grpc_type"}),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Number of calls completed on the server, by status code.",
+		}, []string{"grpc_service", "grpc_method", "grpc_type", "grpc_code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Latency of the calls handled by the server, by status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_service", "grpc_method", "grpc_type", "grpc_code"}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(m.started, m.handled, m.latency)
+	}
+	return m
+}
+
+func (m *Metrics) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := m.start(info.FullMethod, "unary")
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func (m *Metrics) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		kind := "server_stream"
+		switch {
+		case info.IsClientStream && info.IsServerStream:
+			kind = "bidi_stream"
+		case info.IsClientStream:
+			kind = "client_stream"
+		}
+		done := m.start(info.FullMethod, kind)
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
+
+// start counts a started call and returns the function that records its
+// outcome.
+func (m *Metrics) start(fullMethod string, kind string) func(err error) {
+	service, method := splitMethod(fullMethod)
+	m.started.WithLabelValues(service, method, kind).Inc()
+	start := time.Now()
+	return func(err error) {
+		code := status.Code(err).String()
+		m.handled.WithLabelValues(service, method, kind, code).Inc()
+		m.latency.WithLabelValues(service, method, kind, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// splitMethod splits "/package.Service/Method" into service and method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,76 @@
+// Package interceptors holds the gRPC server interceptors that apply to every
+// call: request IDs, access logs and panic recovery.
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIdKey is the metadata key a request ID is read from and returned in.
+const RequestIdKey = "x-request-id"
+
+// maxRequestIdLength bounds the request IDs accepted from clients.
+const maxRequestIdLength = 128
+
+type requestIdKey struct{}
+
+// RequestIdFromContext returns the request ID of the call, or an empty string
+// outside of the RequestId interceptors.
+func RequestIdFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
+
+// requestId returns the request ID sent by the client or, when there is none
+// or it is not acceptable, a new one.
+func requestId(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, id := range md.Get(RequestIdKey) {
+			if validRequestId(id) {
+				return id
+			}
+		}
+	}
+	return uuid.New().String()
+}
+
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+	return !strings.ContainsFunc(id, func(r rune) bool {
+		return r < '!' || r > '~'
+	})
+}
+
+// RequestIdUnary adds a request ID to the context and returns it to the
+// client in the response header.
+func RequestIdUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := requestId(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIdKey, id))
+		return handler(context.WithValue(ctx, requestIdKey{}, id), req)
+	}
+}
+
+func RequestIdStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestId(ss.Context())
+		ss.SetHeader(metadata.Pairs(RequestIdKey, id))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), requestIdKey{}, id)})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
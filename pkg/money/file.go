@@ -0,0 +1,59 @@
+package money
+
+import (
+	"grpc-project/pkg/filewatch"
+	"log"
+	"sync"
+	"time"
+)
+
+// RateSource provides the exchange rates that are currently in effect.
+type RateSource interface {
+	Current() *RateTable
+}
+
+// RatesFile is a rate table read from a JSON file. Publishing a new version
+// of the table is a matter of replacing the file: it is read again when it
+// changes. Bookings go on being charged at the last valid version while the
+// file is broken.
+type RatesFile struct {
+	mu    sync.Mutex
+	files filewatch.Files
+	table *RateTable
+}
+
+// NewRatesFile reads the rate table at path and looks for new versions at
+// most once per checkInterval.
+func NewRatesFile(path string, checkInterval time.Duration) (*RatesFile, error) {
+	f := &RatesFile{files: filewatch.New(checkInterval, path)}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RatesFile) Current() *RateTable {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.reload(); err != nil {
+		log.Printf("failed to reload exchange rates, keeping version %s: %v", f.table.Version, err)
+	}
+	return f.table
+}
+
+func (f *RatesFile) reload() error {
+	changed, err := f.files.Changed()
+	if err != nil || !changed {
+		return err
+	}
+	path := f.files.Paths[0]
+	table, err := LoadRates(path)
+	if err != nil {
+		return err
+	}
+	if f.table != nil {
+		log.Printf("reloaded exchange rates from %s, version %s", path, table.Version)
+	}
+	f.table = table
+	return nil
+}
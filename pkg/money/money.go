@@ -0,0 +1,196 @@
+// Package money holds amounts of money as integer minor units of a currency,
+// e.g. cents, so that sums, discounts and refunds are exact.
+//
+// Amounts only enter as floats at the edges, from the float prices of the
+// version 1 API. FromFloat rounds them to the nearest minor unit, halves
+// away from zero. All other arithmetic is exact; amounts in different
+// currencies are never mixed.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currencies the store knows.
+const (
+	USD = "USD"
+	EUR = "EUR"
+	GBP = "GBP"
+	JPY = "JPY"
+)
+
+// Legacy is the currency of amounts saved as plain numbers, before amounts
+// had a currency.
+const Legacy = USD
+
+// exponents are the number of digits of the minor unit of each currency, as
+// in ISO 4217.
+var exponents = map[string]int{
+	USD: 2,
+	EUR: 2,
+	GBP: 2,
+	JPY: 0,
+}
+
+var (
+	ErrCurrencyMismatch = errors.New("money: amounts are in different currencies")
+	ErrOverflow         = errors.New("money: amount out of range")
+)
+
+// Money is an amount in the minor units of its currency.
+type Money struct {
+	Minor    int64  `json:"minor"`
+	Currency string `json:"currency"`
+}
+
+// New returns minor units of currency.
+func New(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// Zero returns no money in currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// FromFloat returns amount, in major units, rounded to the nearest minor
+// unit of currency. It rounds the shortest decimal that reads back as
+// amount, so 20.005 is 20.01 even though the float is slightly below it.
+// Halves are rounded away from zero. NaN, infinities and amounts beyond the
+// range of Money are ErrOverflow.
+func FromFloat(amount float64, currency string) (Money, error) {
+	return fromDecimal(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+// FromFloat32 is FromFloat for the float32 prices of the version 1 API.
+func FromFloat32(amount float32, currency string) (Money, error) {
+	return fromDecimal(strconv.FormatFloat(float64(amount), 'f', -1, 32), currency)
+}
+
+// fromDecimal rounds a decimal without exponent, as formatted by
+// strconv.FormatFloat with the 'f' format, to minor units.
+func fromDecimal(amount string, currency string) (Money, error) {
+	negative := strings.HasPrefix(amount, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(amount, "-"), ".")
+	exponent := Exponent(currency)
+	roundUp := len(fraction) > exponent && fraction[exponent] >= '5'
+	for len(fraction) < exponent {
+		fraction += "0"
+	}
+	minor, err := strconv.ParseInt(whole+fraction[:exponent], 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+	if roundUp {
+		if minor == math.MaxInt64 {
+			return Money{}, ErrOverflow
+		}
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// Float returns the amount in major units, for the float fields of the
+// version 1 API and for metrics.
+func (m Money) Float() float64 {
+	return float64(m.Minor) / scale(m.Currency)
+}
+
+// ValidCurrency reports whether the currency is known.
+func ValidCurrency(currency string) bool {
+	_, ok := exponents[currency]
+	return ok
+}
+
+// Exponent returns the number of digits of the minor unit of currency,
+// 2 for unknown currencies.
+func Exponent(currency string) int {
+	if exponent, ok := exponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+func scale(currency string) float64 {
+	return math.Pow10(Exponent(currency))
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsNegative() bool { return m.Minor < 0 }
+
+// Add returns m+o.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	sum := m.Minor + o.Minor
+	if (o.Minor > 0 && sum < m.Minor) || (o.Minor < 0 && sum > m.Minor) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Minor: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns m-o.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Minor == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Minor: -o.Minor, Currency: o.Currency})
+}
+
+// Discount takes discount off price. The charged amount never goes below
+// zero, so applied, the part of the discount that was used, can be smaller
+// than discount; charged plus applied is always price. A negative price is
+// charged as zero with nothing applied.
+func Discount(price Money, discount Money) (charged Money, applied Money, err error) {
+	if price.Currency != discount.Currency {
+		return Money{}, Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, price.Currency, discount.Currency)
+	}
+	if discount.IsNegative() {
+		return Money{}, Money{}, fmt.Errorf("money: negative discount %s", discount)
+	}
+	if price.IsNegative() {
+		return Zero(price.Currency), Zero(price.Currency), nil
+	}
+	applied = Money{Minor: min(price.Minor, discount.Minor), Currency: price.Currency}
+	charged = Money{Minor: price.Minor - applied.Minor, Currency: price.Currency}
+	return charged, applied, nil
+}
+
+// String formats the amount with its currency, e.g. "USD 20.50".
+func (m Money) String() string {
+	exponent := Exponent(m.Currency)
+	sign, abs := "", uint64(m.Minor)
+	if m.Minor < 0 {
+		sign, abs = "-", -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	for len(digits) <= exponent {
+		digits = "0" + digits
+	}
+	amount := digits
+	if exponent > 0 {
+		amount = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+	return strings.TrimSpace(m.Currency + " " + sign + amount)
+}
+
+// UnmarshalJSON also reads amounts saved as a plain number of major units,
+// before amounts had a currency. They are in the Legacy currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount float64
+	if err := json.Unmarshal(data, &amount); err == nil {
+		*m, err = FromFloat(amount, Legacy)
+		return err
+	}
+	type plain Money
+	return json.Unmarshal(data, (*plain)(m))
+}
@@ -0,0 +1,144 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cents keeps random amounts far from the range of int64, so that sums of a
+// few of them cannot overflow.
+func cents(n int64) int64 {
+	return n % 1_000_000_000_00
+}
+
+func Test_Money_Properties(t *testing.T) {
+	properties := map[string]any{
+		"FromFloat inverts Float": func(n int64) bool {
+			m := New(cents(n), USD)
+			back, err := FromFloat(m.Float(), USD)
+			return err == nil && back == m
+		},
+		"Sub inverts Add": func(a, b int64) bool {
+			sum, err := New(cents(a), EUR).Add(New(cents(b), EUR))
+			if err != nil {
+				return false
+			}
+			diff, err := sum.Sub(New(cents(b), EUR))
+			return err == nil && diff == New(cents(a), EUR)
+		},
+		"Discount charges what is not applied": func(price, discount int64) bool {
+			p, d := New(cents(price), USD), New(cents(discount), USD)
+			if d.IsNegative() {
+				d.Minor = -d.Minor
+			}
+			charged, applied, err := Discount(p, d)
+			if err != nil || charged.IsNegative() || applied.IsNegative() || applied.Minor > d.Minor {
+				return false
+			}
+			if p.IsNegative() {
+				return charged.IsZero() && applied.IsZero()
+			}
+			total, err := charged.Add(applied)
+			return err == nil && total == p
+		},
+		"Refunding every charge leaves nothing": func(prices []int64) bool {
+			revenue, refunds := Zero(GBP), Zero(GBP)
+			for _, price := range prices {
+				charged, _, err := Discount(New(cents(price), GBP), New(1000, GBP))
+				if err != nil {
+					return false
+				}
+				if revenue, err = revenue.Add(charged); err != nil {
+					return false
+				}
+				if refunds, err = refunds.Add(charged); err != nil {
+					return false
+				}
+			}
+			net, err := revenue.Sub(refunds)
+			return err == nil && net.IsZero()
+		},
+	}
+	for name, property := range properties {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, quick.Check(property, nil))
+		})
+	}
+}
+
+func Test_FromFloat(t *testing.T) {
+	type test struct {
+		Amount        float64
+		Currency      string
+		Expected      Money
+		ExpectedError error
+	}
+	tests := map[string]test{
+		"Happy Path - Rounds to the cent": {
+			Amount: 20.004, Currency: USD, Expected: New(2000, USD),
+		},
+		"Happy Path - Rounds the decimal, not the float below it": {
+			Amount: 20.005, Currency: USD, Expected: New(2001, USD),
+		},
+		"Happy Path - Rounds halves away from zero": {
+			Amount: -0.125, Currency: USD, Expected: New(-13, USD),
+		},
+		"Happy Path - Float that is not exact in binary": {
+			Amount: 0.1 + 0.2, Currency: EUR, Expected: New(30, EUR),
+		},
+		"Happy Path - Currency without minor units": {
+			Amount: 1500.5, Currency: JPY, Expected: New(1501, JPY),
+		},
+		"Sad Path - NaN": {
+			Amount: math.NaN(), Currency: USD, ExpectedError: ErrOverflow,
+		},
+		"Sad Path - Out of range": {
+			Amount: 1e20, Currency: USD, ExpectedError: ErrOverflow,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := FromFloat(tc.Amount, tc.Currency)
+			assert.ErrorIs(t, err, tc.ExpectedError)
+			assert.Equal(t, tc.Expected, m)
+		})
+	}
+}
+
+func Test_FromFloat32(t *testing.T) {
+	m, err := FromFloat32(20.005, USD)
+	assert.NoError(t, err)
+	assert.Equal(t, New(2001, USD), m)
+}
+
+func Test_Money_Errors(t *testing.T) {
+	_, err := New(100, USD).Add(New(100, EUR))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	_, err = New(math.MaxInt64, USD).Add(New(1, USD))
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = New(0, USD).Sub(New(math.MinInt64, USD))
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, _, err = Discount(New(100, USD), New(10, GBP))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	_, _, err = Discount(New(100, USD), New(-10, USD))
+	assert.Error(t, err)
+}
+
+func Test_Money_String(t *testing.T) {
+	assert.Equal(t, "USD 20.50", New(2050, USD).String())
+	assert.Equal(t, "EUR -0.05", New(-5, EUR).String())
+	assert.Equal(t, "JPY 1500", New(1500, JPY).String())
+}
+
+func Test_Money_UnmarshalJSON(t *testing.T) {
+	var prices map[string]Money
+	err := json.Unmarshal([]byte(`{"legacy": 20.5, "exact": {"minor": 1999, "currency": "GBP"}}`), &prices)
+	assert.NoError(t, err)
+	assert.Equal(t, New(2050, Legacy), prices["legacy"])
+	assert.Equal(t, New(1999, GBP), prices["exact"])
+}
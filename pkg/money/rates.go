@@ -0,0 +1,158 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// rateDecimals is the number of decimals exchange rates are used with.
+const rateDecimals = 6
+
+var ErrNoRate = errors.New("money: no exchange rate")
+
+// RateTable is a set of exchange rates, as read from a JSON file:
+//
+//	{"version": "2026-10-19.1", "base": "USD", "rates": {"EUR": "0.92", "GBP": "0.79"}}
+//
+// Rates are decimals, in units of the currency per unit of Base. Every
+// change of the rates must come with a new Version, which receipts record.
+type RateTable struct {
+	Version string            `json:"version"`
+	Base    string            `json:"base"`
+	Rates   map[string]string `json:"rates"`
+}
+
+// Rate is the exchange rate a conversion used, kept with the converted
+// amount so the conversion can be checked and repeated later.
+type Rate struct {
+	// Version is the version of the rate table.
+	Version string `json:"version"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	// Value is the decimal number of units of To per unit of From.
+	Value string `json:"value"`
+}
+
+// ParseRates decodes and validates a JSON rate table.
+func ParseRates(data []byte) (*RateTable, error) {
+	var table RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %v", err)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// LoadRates reads a JSON rate table file.
+func LoadRates(path string) (*RateTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRates(data)
+}
+
+// Validate checks that the table has a version, that its currencies are
+// known and that every rate is a positive decimal.
+func (t *RateTable) Validate() error {
+	if t.Version == "" {
+		return errors.New("exchange rates have no version")
+	}
+	if !ValidCurrency(t.Base) {
+		return fmt.Errorf("unknown base currency %q", t.Base)
+	}
+	for currency, value := range t.Rates {
+		if !ValidCurrency(currency) {
+			return fmt.Errorf("unknown currency %q", currency)
+		}
+		rate, ok := parseRate(value)
+		if !ok {
+			return fmt.Errorf("%s: invalid rate %q, must be a positive decimal", currency, value)
+		}
+		if currency == t.Base && rate.Cmp(big.NewRat(1, 1)) != 0 {
+			return fmt.Errorf("%s: the rate of the base currency must be 1", currency)
+		}
+	}
+	return nil
+}
+
+// rate returns the rate of currency to Base, nil when the table has none.
+func (t *RateTable) rate(currency string) *big.Rat {
+	if currency == t.Base {
+		return big.NewRat(1, 1)
+	}
+	value, ok := t.Rates[currency]
+	if !ok {
+		return nil
+	}
+	rate, ok := parseRate(value)
+	if !ok {
+		return nil
+	}
+	return rate
+}
+
+func parseRate(value string) (*big.Rat, bool) {
+	if strings.ContainsAny(value, "/eE") {
+		return nil, false
+	}
+	rate, ok := new(big.Rat).SetString(value)
+	return rate, ok && rate.Sign() > 0
+}
+
+// Current returns t, which makes a table built in code, e.g. in tests, a
+// RateSource whose rates never change.
+func (t *RateTable) Current() *RateTable {
+	return t
+}
+
+// Rate returns the rate from one currency to another. Rates between two
+// currencies other than Base are crossed through Base. Rates are rounded to
+// six decimals, halves away from zero. The rate of a currency to itself is
+// 1, even when the currency is not in the table.
+func (t *RateTable) Rate(from string, to string) (Rate, error) {
+	if from == to {
+		return Rate{Version: t.Version, From: from, To: to, Value: "1"}, nil
+	}
+	fromRate, toRate := t.rate(from), t.rate(to)
+	if fromRate == nil || toRate == nil {
+		return Rate{}, fmt.Errorf("%w from %s to %s in version %s", ErrNoRate, from, to, t.Version)
+	}
+	value := new(big.Rat).Quo(toRate, fromRate).FloatString(rateDecimals)
+	value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	if value == "0" {
+		return Rate{}, fmt.Errorf("%w from %s to %s: the rate rounds to 0", ErrNoRate, from, to)
+	}
+	return Rate{Version: t.Version, From: from, To: to, Value: value}, nil
+}
+
+// Convert returns m in the To currency of the rate, rounded to its minor
+// unit, halves away from zero.
+func (r Rate) Convert(m Money) (Money, error) {
+	if m.Currency != r.From {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, r.From)
+	}
+	rate, ok := parseRate(r.Value)
+	if !ok {
+		return Money{}, fmt.Errorf("money: invalid rate %q", r.Value)
+	}
+	// Minor units of From to minor units of To.
+	scale := new(big.Rat).SetFrac(pow10(Exponent(r.To)), pow10(Exponent(r.From)))
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Minor), rate)
+	amount.Mul(amount, scale)
+	minor, ok := new(big.Int).SetString(amount.FloatString(0), 10)
+	if !ok || !minor.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Minor: minor.Int64(), Currency: r.To}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
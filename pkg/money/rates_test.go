@@ -0,0 +1,188 @@
+package money
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRates = &RateTable{
+	Version: "2026-10-19.1",
+	Base:    USD,
+	Rates:   map[string]string{EUR: "0.92", GBP: "0.79", JPY: "149.5"},
+}
+
+func Test_RateTable_Rate(t *testing.T) {
+	type test struct {
+		From          string
+		To            string
+		ExpectedValue string
+		ExpectedError error
+	}
+	tests := map[string]test{
+		"Happy Path - From the base currency": {
+			From: USD, To: EUR, ExpectedValue: "0.92",
+		},
+		"Happy Path - To the base currency is rounded to six decimals": {
+			From: GBP, To: USD, ExpectedValue: "1.265823",
+		},
+		"Happy Path - Cross rate": {
+			From: GBP, To: EUR, ExpectedValue: "1.164557",
+		},
+		"Happy Path - Same currency": {
+			From: GBP, To: GBP, ExpectedValue: "1",
+		},
+		"Sad Path - Currency without a rate": {
+			From: USD, To: "CHF", ExpectedError: ErrNoRate,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rate, err := testRates.Rate(tc.From, tc.To)
+			assert.ErrorIs(t, err, tc.ExpectedError)
+			assert.Equal(t, tc.ExpectedValue, rate.Value)
+			if err == nil {
+				assert.Equal(t, Rate{Version: testRates.Version, From: tc.From, To: tc.To, Value: tc.ExpectedValue}, rate)
+			}
+		})
+	}
+}
+
+func Test_Rate_Convert(t *testing.T) {
+	type test struct {
+		Rate          Rate
+		Amount        Money
+		Expected      Money
+		ExpectedError error
+	}
+	tests := map[string]test{
+		"Happy Path - Rounds to the cent": {
+			Rate: Rate{From: USD, To: EUR, Value: "0.92"}, Amount: New(1999, USD), Expected: New(1839, EUR),
+		},
+		"Happy Path - Rounds halves away from zero": {
+			Rate: Rate{From: USD, To: EUR, Value: "0.5"}, Amount: New(1, USD), Expected: New(1, EUR),
+		},
+		"Happy Path - To a currency without minor units": {
+			Rate: Rate{From: USD, To: JPY, Value: "149.5"}, Amount: New(2000, USD), Expected: New(2990, JPY),
+		},
+		"Happy Path - From a currency without minor units": {
+			Rate: Rate{From: JPY, To: USD, Value: "0.006689"}, Amount: New(2990, JPY), Expected: New(2000, USD),
+		},
+		"Sad Path - Amount in another currency": {
+			Rate: Rate{From: USD, To: EUR, Value: "0.92"}, Amount: New(100, GBP), ExpectedError: ErrCurrencyMismatch,
+		},
+		"Sad Path - Out of range": {
+			Rate: Rate{From: JPY, To: USD, Value: "1000"}, Amount: New(1<<62, JPY), ExpectedError: ErrOverflow,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			converted, err := tc.Rate.Convert(tc.Amount)
+			assert.ErrorIs(t, err, tc.ExpectedError)
+			assert.Equal(t, tc.Expected, converted)
+		})
+	}
+}
+
+func Test_Rate_Properties(t *testing.T) {
+	properties := map[string]any{
+		"Conversions keep the order of amounts": func(a, b int64) bool {
+			rate, _ := testRates.Rate(GBP, EUR)
+			x, y := New(cents(a), GBP), New(cents(b), GBP)
+			if x.Minor > y.Minor {
+				x, y = y, x
+			}
+			cx, err := rate.Convert(x)
+			if err != nil {
+				return false
+			}
+			cy, err := rate.Convert(y)
+			return err == nil && cx.Minor <= cy.Minor && cx.Currency == EUR
+		},
+		"Converting a sum is off by at most a cent per part": func(a, b uint32) bool {
+			rate, _ := testRates.Rate(USD, GBP)
+			x, y := New(int64(a), USD), New(int64(b), USD)
+			sum, _ := x.Add(y)
+			convertedSum, err := rate.Convert(sum)
+			if err != nil {
+				return false
+			}
+			cx, _ := rate.Convert(x)
+			cy, _ := rate.Convert(y)
+			parts, _ := cx.Add(cy)
+			diff := convertedSum.Minor - parts.Minor
+			return diff >= -1 && diff <= 1
+		},
+	}
+	for name, property := range properties {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, quick.Check(property, nil))
+		})
+	}
+}
+
+func Test_ParseRates(t *testing.T) {
+	type test struct {
+		Data          string
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Valid table": {
+			Data: `{"version": "1", "base": "GBP", "rates": {"EUR": "1.16", "GBP": "1"}}`,
+		},
+		"Sad Path - No version": {
+			Data: `{"base": "GBP", "rates": {"EUR": "1.16"}}`, ExpectedError: true,
+		},
+		"Sad Path - Unknown currency": {
+			Data: `{"version": "1", "base": "GBP", "rates": {"XXX": "1.16"}}`, ExpectedError: true,
+		},
+		"Sad Path - Negative rate": {
+			Data: `{"version": "1", "base": "GBP", "rates": {"EUR": "-1.16"}}`, ExpectedError: true,
+		},
+		"Sad Path - Rate as a fraction": {
+			Data: `{"version": "1", "base": "GBP", "rates": {"EUR": "29/25"}}`, ExpectedError: true,
+		},
+		"Sad Path - Base currency rate other than 1": {
+			Data: `{"version": "1", "base": "GBP", "rates": {"GBP": "2"}}`, ExpectedError: true,
+		},
+		"Sad Path - Not JSON": {
+			Data: `{version`, ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRates([]byte(tc.Data))
+			assert.Equal(t, tc.ExpectedError, err != nil, err)
+		})
+	}
+}
+
+func Test_RatesFile_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	write := func(rates string) {
+		assert.NoError(t, os.WriteFile(path, []byte(rates), 0o600))
+	}
+	write(`{"version": "1", "base": "USD", "rates": {"EUR": "0.92"}}`)
+
+	file, err := NewRatesFile(path, 0)
+	assert.NoError(t, err)
+	rate, err := file.Current().Rate(USD, EUR)
+	assert.NoError(t, err)
+	assert.Equal(t, Rate{Version: "1", From: USD, To: EUR, Value: "0.92"}, rate)
+
+	write(`{"version": "2", "base": "USD", "rates": {"EUR": "0.9", "GBP": "0.8"}}`)
+	rate, err = file.Current().Rate(USD, GBP)
+	assert.NoError(t, err)
+	assert.Equal(t, Rate{Version: "2", From: USD, To: GBP, Value: "0.8"}, rate)
+
+	// Invalid rates keep the current table.
+	write(`{"version": "3", "base": "USD", "rates": {"EUR": "zero"}}`)
+	assert.Equal(t, "2", file.Current().Version)
+
+	_, err = NewRatesFile(filepath.Join(t.TempDir(), "missing.json"), 0)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,29 @@
+// Package privacy masks personal data shown to callers that may not see it in
+// full.
+package privacy
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskName reduces a name to its initial, e.g. "Alice" to "A.".
+func MaskName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	initial, _ := utf8.DecodeRuneInString(name)
+	return string(initial) + "."
+}
+
+// MaskEmail keeps the first character of the local part and the domain, e.g.
+// "alice@example.com" to "a***@example.com".
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	initial, _ := utf8.DecodeRuneInString(email)
+	return string(initial) + "***" + email[at:]
+}
@@ -0,0 +1,30 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MaskName(t *testing.T) {
+	tests := map[string]string{
+		"Alice":    "A.",
+		" Élodie ": "É.",
+		"":         "",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, MaskName(name))
+	}
+}
+
+func Test_MaskEmail(t *testing.T) {
+	tests := map[string]string{
+		"alice@example.com": "a***@example.com",
+		"a@example.com":     "a***@example.com",
+		"not-an-email":      "***",
+		"@example.com":      "***",
+	}
+	for email, expected := range tests {
+		assert.Equal(t, expected, MaskEmail(email))
+	}
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Limit is a token bucket: Burst calls at once, refilled at Rate calls per
+// second.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Config holds the limit of every RPC method.
+type Config struct {
+	// Default applies to methods without a limit of their own. A zero
+	// Default leaves those methods unlimited.
+	Default Limit `json:"default"`
+	// Methods maps full gRPC method names, e.g.
+	// "/booking.BookingService/PurchaseBooking", to their limit.
+	Methods map[string]Limit `json:"methods"`
+	// Address holds every client IP to a limit over all methods, checked
+	// before authentication so that failed logins and bad tokens count. A
+	// zero Address leaves client IPs unlimited.
+	Address Limit `json:"address"`
+}
+
+// DefaultConfig returns the built-in limits: purchases, logins and booking
+// reference lookups, which could be used to guess references, are held to
+// one per second with a small burst, every other call to 20 per second, and
+// a client IP to 50 calls per second in all.
+func DefaultConfig() *Config {
+	return &Config{
+		Default: Limit{Rate: 20, Burst: 40},
+		Address: Limit{Rate: 50, Burst: 100},
+		Methods: map[string]Limit{
+			"/booking.BookingService/PurchaseBooking":          {Rate: 1, Burst: 5},
+			"/booking.v2.BookingService/CreateBooking":         {Rate: 1, Burst: 5},
+			"/booking.AuthService/Login":                       {Rate: 1, Burst: 5},
+			"/booking.BookingService/GetBookingByReference":    {Rate: 1, Burst: 5},
+			"/booking.BookingService/CancelBookingByReference": {Rate: 1, Burst: 5},
+			"/booking.BookingService/ChangeSeatByReference":    {Rate: 1, Burst: 5},
+		},
+	}
+}
+
+// ParseConfig decodes and validates a JSON config.
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limits: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// LoadConfig reads a JSON config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// Validate checks that every limit is either zero or a positive rate with a
+// burst of at least one call.
+func (c *Config) Validate() error {
+	if err := c.Default.validate(); err != nil {
+		return fmt.Errorf("default: %v", err)
+	}
+	if err := c.Address.validate(); err != nil {
+		return fmt.Errorf("address: %v", err)
+	}
+	for method, limit := range c.Methods {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("%s: %v", method, err)
+		}
+	}
+	return nil
+}
+
+// limit returns the limit of the method.
+func (c *Config) limit(method string) Limit {
+	if limit, ok := c.Methods[method]; ok {
+		return limit
+	}
+	return c.Default
+}
+
+func (l Limit) unlimited() bool {
+	return l.Rate == 0 && l.Burst == 0
+}
+
+func (l Limit) validate() error {
+	if l.unlimited() {
+		return nil
+	}
+	if l.Rate <= 0 || l.Burst < 1 {
+		return fmt.Errorf("invalid limit, rate must be positive and burst at least 1")
+	}
+	return nil
+}
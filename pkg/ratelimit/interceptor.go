@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"grpc-project/pkg/auth"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterKey is the response header holding the number of seconds to
+// wait before retrying a throttled call.
+const RetryAfterKey = "retry-after"
+
+const forwardedForKey = "x-forwarded-for"
+
+// Interceptor throttles calls per method and caller. Authenticated callers
+// are keyed by user ID, so it must run after the auth.Authenticator
+// interceptor; other callers are keyed by client IP. Its address
+// interceptors throttle every call of a client IP before authentication.
+type Interceptor struct {
+	Limiter *Limiter
+	// TrustedProxies are the networks of the proxies, e.g. the REST gateway,
+	// whose X-Forwarded-For header gives the client IP. The header is ignored
+	// on calls from any other peer.
+	TrustedProxies []*net.IPNet
+}
+
+// Check returns a ResourceExhausted error when the caller has run out of
+// calls to the method, and sets the retry-after header.
+func (i *Interceptor) Check(ctx context.Context, method string) error {
+	allowed, retryAfter := i.Limiter.Allow(method, i.caller(ctx))
+	if allowed {
+		return nil
+	}
+	seconds := retryAfterSeconds(ctx, retryAfter)
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, retry after %v", method, time.Duration(seconds)*time.Second)
+}
+
+// CheckAddress is Check for the address limit, which every call of the
+// client IP counts against.
+func (i *Interceptor) CheckAddress(ctx context.Context) error {
+	allowed, retryAfter := i.Limiter.AllowAddress(i.clientIP(ctx))
+	if allowed {
+		return nil
+	}
+	seconds := retryAfterSeconds(ctx, retryAfter)
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for this client, retry after %v", time.Duration(seconds)*time.Second)
+}
+
+// retryAfterSeconds rounds retryAfter up to whole seconds and sets the
+// retry-after header to them.
+func retryAfterSeconds(ctx context.Context, retryAfter time.Duration) int {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.Itoa(seconds)))
+	return seconds
+}
+
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.Check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.Check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// AddressUnaryServerInterceptor applies the address limit. It must run
+// before the auth.Authenticator interceptor, so that calls with bad tokens
+// are throttled too.
+func (i *Interceptor) AddressUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.CheckAddress(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) AddressStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.CheckAddress(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// caller returns the user ID of the caller, or its IP address when the call
+// is not authenticated.
+func (i *Interceptor) caller(ctx context.Context) string {
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		return "user:" + principal.UserId
+	}
+	return "ip:" + i.clientIP(ctx)
+}
+
+// clientIP returns the address of the peer. For a trusted proxy it is the
+// X-Forwarded-For entry the nearest untrusted hop added, as the entries
+// before it can be made up by the client.
+func (i *Interceptor) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if !i.trusted(host) {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := md.Get(forwardedForKey)
+	var entries []string
+	for _, header := range forwarded {
+		entries = append(entries, strings.Split(header, ",")...)
+	}
+	for j := len(entries) - 1; j >= 0; j-- {
+		host = strings.TrimSpace(entries[j])
+		if !i.trusted(host) {
+			break
+		}
+	}
+	return host
+}
+
+// trusted reports whether host is the address of a trusted proxy.
+func (i *Interceptor) trusted(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range i.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNetworks parses a comma separated list of CIDR networks and IP
+// addresses, as given to -trusted-proxies. An address stands for a network
+// of just that address.
+func ParseNetworks(list string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
@@ -0,0 +1,98 @@
+// Package ratelimit throttles calls with token buckets per caller and RPC
+// method.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// idleTimeout is how long a bucket is kept after its last call. A bucket
+// left alone that long is full again and can be dropped.
+const idleTimeout = 10 * time.Minute
+
+// Limiter keeps one token bucket per caller and method.
+type Limiter struct {
+	config *Config
+	// Now returns the current time, it defaults to time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	// method is empty for the address limit.
+	method string
+	caller string
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter enforcing config.
+func NewLimiter(config *Config) *Limiter {
+	return &Limiter{
+		config:  config,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of the caller for the method. When the
+// bucket is empty it returns false and how long until the next token.
+func (l *Limiter) Allow(method string, caller string) (bool, time.Duration) {
+	return l.allow(bucketKey{method: method, caller: caller}, l.config.limit(method))
+}
+
+// AllowAddress is Allow for the address limit, one bucket per client IP
+// shared by all methods.
+func (l *Limiter) AllowAddress(ip string) (bool, time.Duration) {
+	return l.allow(bucketKey{caller: "ip:" + ip}, l.config.Address)
+}
+
+func (l *Limiter) allow(key bucketKey, limit Limit) (bool, time.Duration) {
+	if limit.unlimited() {
+		return true, 0
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops the idle buckets, at most once per idleTimeout.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *Limiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
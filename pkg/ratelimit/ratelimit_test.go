@@ -0,0 +1,257 @@
+package ratelimit
+
+import (
+	"context"
+	"grpc-project/pkg/auth"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const purchase = "/booking.BookingService/PurchaseBooking"
+
+func Test_Limiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(&Config{
+		Methods: map[string]Limit{purchase: {Rate: 1, Burst: 2}},
+	})
+	limiter.Now = func() time.Time { return now }
+
+	// The burst is spent, then the caller waits for the refill.
+	allowed, _ := limiter.Allow(purchase, "user:1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow(purchase, "user:1")
+	assert.True(t, allowed)
+	allowed, retryAfter := limiter.Allow(purchase, "user:1")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Other callers and methods have buckets of their own.
+	allowed, _ = limiter.Allow(purchase, "user:2")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("/booking.BookingService/ShowReceipt", "user:1")
+	assert.True(t, allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, retryAfter = limiter.Allow(purchase, "user:1")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.Allow(purchase, "user:1")
+	assert.True(t, allowed)
+
+	// Idle buckets are dropped.
+	now = now.Add(idleTimeout)
+	limiter.Allow(purchase, "user:1")
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func Test_ParseConfig(t *testing.T) {
+	type test struct {
+		Data          string
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Default and method limits": {
+			Data: `{"default": {"rate": 10, "burst": 20}, "methods": {"/booking.BookingService/PurchaseBooking": {"rate": 0.5, "burst": 1}}}`,
+		},
+		"Happy Path - No limits": {
+			Data: `{}`,
+		},
+		"Sad Path - Negative rate": {
+			Data:          `{"default": {"rate": -1, "burst": 1}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Rate without burst": {
+			Data:          `{"methods": {"/booking.AuthService/Login": {"rate": 1}}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Address rate without burst": {
+			Data:          `{"address": {"rate": 50}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Invalid JSON": {
+			Data:          `{`,
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.Data))
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_Interceptor(t *testing.T) {
+	interceptor := &Interceptor{Limiter: NewLimiter(&Config{Default: Limit{Rate: 0.1, Burst: 1}})}
+	// A stand-in for the authenticator takes the user from the metadata.
+	authenticate := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if users := md.Get("user"); len(users) > 0 {
+			ctx = auth.WithPrincipal(ctx, &auth.Principal{UserId: users[0]})
+		}
+		return handler(ctx, req)
+	}
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(authenticate, interceptor.UnaryServerInterceptor()))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	check := func(ctx context.Context) (metadata.MD, error) {
+		var header metadata.MD
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+		return header, err
+	}
+	bob := metadata.AppendToOutgoingContext(context.Background(), "user", "2")
+	alice := metadata.AppendToOutgoingContext(context.Background(), "user", "1")
+
+	_, err = check(bob)
+	assert.NoError(t, err)
+	header, err := check(bob)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"10"}, header.Get(RetryAfterKey))
+
+	// Another user and an anonymous caller are not affected.
+	_, err = check(alice)
+	assert.NoError(t, err)
+	_, err = check(context.Background())
+	assert.NoError(t, err)
+	_, err = check(context.Background())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func Test_Caller(t *testing.T) {
+	fromAddr := func(addr string, md metadata.MD) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 5000}})
+		return metadata.NewIncomingContext(ctx, md)
+	}
+	proxies, err := ParseNetworks("127.0.0.1, 10.1.0.0/16")
+	assert.NoError(t, err)
+	interceptor := &Interceptor{TrustedProxies: proxies}
+	type test struct {
+		Ctx            context.Context
+		ExpectedCaller string
+	}
+	tests := map[string]test{
+		"Happy Path - Authenticated user": {
+			Ctx:            auth.WithPrincipal(fromAddr("10.0.0.1", nil), &auth.Principal{UserId: "2"}),
+			ExpectedCaller: "user:2",
+		},
+		"Happy Path - Client IP": {
+			Ctx:            fromAddr("10.0.0.1", nil),
+			ExpectedCaller: "ip:10.0.0.1",
+		},
+		"Happy Path - Client of a trusted proxy": {
+			Ctx:            fromAddr("127.0.0.1", metadata.Pairs("x-forwarded-for", "1.2.3.4, 10.0.0.7")),
+			ExpectedCaller: "ip:10.0.0.7",
+		},
+		"Happy Path - Client behind a chain of trusted proxies": {
+			Ctx:            fromAddr("127.0.0.1", metadata.Pairs("x-forwarded-for", "1.2.3.4, 10.0.0.7, 10.1.2.3")),
+			ExpectedCaller: "ip:10.0.0.7",
+		},
+		"Sad Path - Forwarded address from an untrusted client is ignored": {
+			Ctx:            fromAddr("10.0.0.1", metadata.Pairs("x-forwarded-for", "10.0.0.7")),
+			ExpectedCaller: "ip:10.0.0.1",
+		},
+		"Sad Path - Forwarded address from an untrusted loopback client is ignored": {
+			Ctx:            fromAddr("127.0.0.2", metadata.Pairs("x-forwarded-for", "10.0.0.7")),
+			ExpectedCaller: "ip:127.0.0.2",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedCaller, interceptor.caller(tc.Ctx))
+		})
+	}
+}
+
+func Test_ParseNetworks(t *testing.T) {
+	type test struct {
+		List          string
+		ExpectedCount int
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Addresses and networks": {
+			List:          "127.0.0.1, ::1,10.0.0.0/8",
+			ExpectedCount: 3,
+		},
+		"Happy Path - Empty list": {
+			List: "",
+		},
+		"Sad Path - Host name": {
+			List:          "localhost",
+			ExpectedError: true,
+		},
+		"Sad Path - Invalid network": {
+			List:          "10.0.0.0/33",
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			networks, err := ParseNetworks(tc.List)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, networks, tc.ExpectedCount)
+		})
+	}
+}
+
+// Test_AddressInterceptor checks that calls failing authentication are
+// throttled by client IP.
+func Test_AddressInterceptor(t *testing.T) {
+	interceptor := &Interceptor{Limiter: NewLimiter(&Config{Address: Limit{Rate: 0.1, Burst: 2}})}
+	reject := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptor.AddressUnaryServerInterceptor(), reject))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	for range 2 {
+		_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	var header metadata.MD
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"10"}, header.Get(RetryAfterKey))
+}
@@ -0,0 +1,63 @@
+package rbac
+
+import (
+	"grpc-project/pkg/filewatch"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Source provides the policy that is currently in effect.
+type Source interface {
+	Current() *Policy
+}
+
+// PolicyFile is a policy read from a JSON file and read again when the file
+// changes, so roles and scopes can be changed on a running server. While the
+// file is broken, calls are checked against the last valid policy.
+type PolicyFile struct {
+	mu     sync.Mutex
+	files  filewatch.Files
+	policy *Policy
+}
+
+// NewPolicyFile reads the policy at path and looks for changes at most once
+// per checkInterval.
+func NewPolicyFile(path string, checkInterval time.Duration) (*PolicyFile, error) {
+	f := &PolicyFile{files: filewatch.New(checkInterval, path)}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *PolicyFile) Current() *Policy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.reload(); err != nil {
+		log.Printf("failed to reload RBAC policy, keeping the current policy: %v", err)
+	}
+	return f.policy
+}
+
+func (f *PolicyFile) reload() error {
+	changed, err := f.files.Changed()
+	if err != nil || !changed {
+		return err
+	}
+	path := f.files.Paths[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return err
+	}
+	if f.policy != nil {
+		log.Printf("reloaded RBAC policy from %s (dry-run: %v)", path, policy.DryRun)
+	}
+	f.policy = policy
+	return nil
+}
@@ -0,0 +1,74 @@
+package rbac
+
+import (
+	"context"
+	"grpc-project/pkg/auth"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Enforcer checks the authenticated caller of every call against the policy.
+// It must run after the auth.Authenticator interceptor. Calls without a
+// principal, i.e. public methods, are left to the authenticator.
+type Enforcer struct {
+	Source Source
+	// DryRun logs denied calls instead of rejecting them, whatever the
+	// policy says.
+	DryRun bool
+}
+
+// Authorize returns ctx with the principal scoped to what the policy grants
+// for the method. In dry-run mode denials are only logged and the principal
+// is left unscoped, so authorization falls back to the built-in roles.
+func (e *Enforcer) Authorize(ctx context.Context, method string) (context.Context, error) {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return ctx, nil
+	}
+	policy := e.Source.Current()
+	decision := policy.Decide(principal.Role, method)
+	if e.DryRun || policy.DryRun {
+		if !decision.Allowed {
+			log.Printf("rbac dry-run: would deny %s for user %s with role %q", method, principal.UserId, principal.Role)
+		}
+		return ctx, nil
+	}
+	if !decision.Allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "role %q may not call %s", principal.Role, method)
+	}
+	scoped := *principal
+	scoped.Scope = decision.Scope
+	return auth.WithPrincipal(ctx, &scoped), nil
+}
+
+func (e *Enforcer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := e.Authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (e *Enforcer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := e.Authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,161 @@
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+	"grpc-project/pkg/auth"
+	"path"
+)
+
+// Policy maps roles to the RPC methods they may call and the resource scope
+// they are granted for them.
+type Policy struct {
+	// DryRun logs denied calls instead of rejecting them.
+	DryRun bool            `json:"dryRun"`
+	Roles  map[string]Role `json:"roles"`
+}
+
+// Role grants its own rules plus the rules of the roles it inherits.
+type Role struct {
+	Inherits []string `json:"inherits,omitempty"`
+	Rules    []Rule   `json:"rules"`
+}
+
+// Rule allows the listed methods with a resource scope. Methods are full gRPC
+// method names and may use path.Match patterns, e.g.
+// "/booking.BookingService/*". A single "*" matches every method.
+type Rule struct {
+	Methods []string `json:"methods"`
+	Scope   string   `json:"scope"`
+}
+
+// Decision is the outcome of checking a call against the policy.
+type Decision struct {
+	Allowed bool
+	// Scope is the widest scope granted by the matching rules.
+	Scope string
+}
+
+// ParsePolicy decodes and validates a JSON policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate checks scopes, method patterns and role inheritance.
+func (p *Policy) Validate() error {
+	if len(p.Roles) == 0 {
+		return fmt.Errorf("policy has no roles")
+	}
+	for name, role := range p.Roles {
+		for _, rule := range role.Rules {
+			if rule.Scope != auth.ScopeSelf && rule.Scope != auth.ScopeAll {
+				return fmt.Errorf("role %s: invalid scope %q", name, rule.Scope)
+			}
+			for _, method := range rule.Methods {
+				if _, err := path.Match(method, ""); err != nil {
+					return fmt.Errorf("role %s: invalid method pattern %q", name, method)
+				}
+			}
+		}
+		if _, err := p.rules(name, map[string]bool{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rules returns the rules of a role including the inherited ones.
+func (p *Policy) rules(name string, visiting map[string]bool) ([]Rule, error) {
+	role, ok := p.Roles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown role %s", name)
+	}
+	if visiting[name] {
+		return nil, fmt.Errorf("role %s inherits itself", name)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	rules := role.Rules
+	for _, parent := range role.Inherits {
+		inherited, err := p.rules(parent, visiting)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules[:len(rules):len(rules)], inherited...)
+	}
+	return rules, nil
+}
+
+// Current returns p: a policy that is not read from a file never changes.
+func (p *Policy) Current() *Policy {
+	return p
+}
+
+// Decide checks whether a role may call a method. Unknown roles and methods
+// no rule matches are denied.
+func (p *Policy) Decide(role string, method string) Decision {
+	rules, err := p.rules(role, map[string]bool{})
+	if err != nil {
+		return Decision{}
+	}
+	var decision Decision
+	for _, rule := range rules {
+		if !matchesAny(rule.Methods, method) {
+			continue
+		}
+		decision.Allowed = true
+		if decision.Scope != auth.ScopeAll {
+			decision.Scope = rule.Scope
+		}
+	}
+	return decision
+}
+
+func matchesAny(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPolicy is used when no policy file is configured. Customers manage
+// their own bookings, agents rebook for anyone, supervisors also run the
+// admin checks and admins may call everything.
+func DefaultPolicy() *Policy {
+	customerMethods := []string{
+		"/booking.BookingService/*",
+		"/booking.v2.BookingService/*",
+		"/booking.UserService/*",
+		"/booking.AuthService/*",
+	}
+	return &Policy{
+		Roles: map[string]Role{
+			auth.RoleCustomer: {
+				Rules: []Rule{{Methods: customerMethods, Scope: auth.ScopeSelf}},
+			},
+			auth.RoleAgent: {
+				Rules: []Rule{{Methods: customerMethods, Scope: auth.ScopeAll}},
+			},
+			auth.RoleSupervisor: {
+				Inherits: []string{auth.RoleAgent},
+				Rules:    []Rule{{Methods: []string{"/booking.AdminService/CheckStoreInvariants"}, Scope: auth.ScopeAll}},
+			},
+			auth.RoleAdmin: {
+				Rules: []Rule{{Methods: []string{"*"}, Scope: auth.ScopeAll}},
+			},
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package rbac
+
+import (
+	"context"
+	"grpc-project/pkg/auth"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	showReceipt     = "/booking.BookingService/ShowReceipt"
+	checkInvariants = "/booking.AdminService/CheckStoreInvariants"
+)
+
+func Test_Decide(t *testing.T) {
+	policy := DefaultPolicy()
+	type test struct {
+		Role             string
+		Method           string
+		ExpectedDecision Decision
+	}
+	tests := map[string]test{
+		"Happy Path - Customer books for themselves": {
+			Role:             auth.RoleCustomer,
+			Method:           showReceipt,
+			ExpectedDecision: Decision{Allowed: true, Scope: auth.ScopeSelf},
+		},
+		"Happy Path - Agent books for anyone": {
+			Role:             auth.RoleAgent,
+			Method:           showReceipt,
+			ExpectedDecision: Decision{Allowed: true, Scope: auth.ScopeAll},
+		},
+		"Happy Path - Supervisor inherits agent rules": {
+			Role:             auth.RoleSupervisor,
+			Method:           showReceipt,
+			ExpectedDecision: Decision{Allowed: true, Scope: auth.ScopeAll},
+		},
+		"Happy Path - Supervisor checks store invariants": {
+			Role:             auth.RoleSupervisor,
+			Method:           checkInvariants,
+			ExpectedDecision: Decision{Allowed: true, Scope: auth.ScopeAll},
+		},
+		"Happy Path - Admin calls anything": {
+			Role:             auth.RoleAdmin,
+			Method:           "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			ExpectedDecision: Decision{Allowed: true, Scope: auth.ScopeAll},
+		},
+		"Sad Path - Agent checks store invariants": {
+			Role:   auth.RoleAgent,
+			Method: checkInvariants,
+		},
+		"Sad Path - Unknown role": {
+			Role:   "intern",
+			Method: showReceipt,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedDecision, policy.Decide(tc.Role, tc.Method))
+		})
+	}
+}
+
+func Test_ParsePolicy(t *testing.T) {
+	type test struct {
+		Policy        string
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Valid policy": {
+			Policy: `{"roles": {"agent": {"rules": [{"methods": ["/booking.BookingService/*"], "scope": "all"}]}, "supervisor": {"inherits": ["agent"], "rules": []}}}`,
+		},
+		"Sad Path - Invalid scope": {
+			Policy:        `{"roles": {"agent": {"rules": [{"methods": ["*"], "scope": "everything"}]}}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Invalid method pattern": {
+			Policy:        `{"roles": {"agent": {"rules": [{"methods": ["/booking.BookingService/["], "scope": "all"}]}}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Unknown inherited role": {
+			Policy:        `{"roles": {"supervisor": {"inherits": ["agent"], "rules": []}}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - Inheritance cycle": {
+			Policy:        `{"roles": {"a": {"inherits": ["b"], "rules": []}, "b": {"inherits": ["a"], "rules": []}}}`,
+			ExpectedError: true,
+		},
+		"Sad Path - No roles": {
+			Policy:        `{"dryRun": true}`,
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tc.Policy))
+			assert.Equal(t, tc.ExpectedError, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func Test_PolicyFile_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	write := func(policy string) {
+		assert.NoError(t, os.WriteFile(path, []byte(policy), 0o600))
+	}
+	write(`{"roles": {"agent": {"rules": [{"methods": ["/booking.BookingService/*"], "scope": "all"}]}}}`)
+
+	file, err := NewPolicyFile(path, 0)
+	assert.NoError(t, err)
+	assert.False(t, file.Current().Decide(auth.RoleAgent, checkInvariants).Allowed)
+
+	write(`{"roles": {"agent": {"rules": [{"methods": ["/booking.BookingService/*", "/booking.AdminService/*"], "scope": "all"}]}}}`)
+	assert.True(t, file.Current().Decide(auth.RoleAgent, checkInvariants).Allowed)
+
+	// An invalid policy keeps the current one.
+	write(`{"roles": {"agent": {"rules": [{"methods": ["*"], "scope": "nope"}]}}}`)
+	assert.True(t, file.Current().Decide(auth.RoleAgent, checkInvariants).Allowed)
+}
+
+func Test_Enforcer(t *testing.T) {
+	asRole := func(role string) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{UserId: "1", Role: role})
+	}
+	dryRunPolicy := DefaultPolicy()
+	dryRunPolicy.DryRun = true
+
+	type test struct {
+		Enforcer          *Enforcer
+		Ctx               context.Context
+		Method            string
+		ExpectedPrincipal *auth.Principal
+		ExpectedCode      codes.Code
+	}
+	tests := map[string]test{
+		"Happy Path - Principal gets the granted scope": {
+			Enforcer:          &Enforcer{Source: DefaultPolicy()},
+			Ctx:               asRole(auth.RoleCustomer),
+			Method:            showReceipt,
+			ExpectedPrincipal: &auth.Principal{UserId: "1", Role: auth.RoleCustomer, Scope: auth.ScopeSelf},
+		},
+		"Happy Path - Unauthenticated call is left to the authenticator": {
+			Enforcer: &Enforcer{Source: DefaultPolicy()},
+			Ctx:      context.Background(),
+			Method:   "/booking.AuthService/Login",
+		},
+		"Happy Path - Dry-run policy lets denied call through": {
+			Enforcer:          &Enforcer{Source: dryRunPolicy},
+			Ctx:               asRole(auth.RoleCustomer),
+			Method:            checkInvariants,
+			ExpectedPrincipal: &auth.Principal{UserId: "1", Role: auth.RoleCustomer},
+		},
+		"Happy Path - Dry-run flag lets denied call through": {
+			Enforcer:          &Enforcer{Source: DefaultPolicy(), DryRun: true},
+			Ctx:               asRole(auth.RoleAgent),
+			Method:            checkInvariants,
+			ExpectedPrincipal: &auth.Principal{UserId: "1", Role: auth.RoleAgent},
+		},
+		"Sad Path - Method not granted to the role": {
+			Enforcer:     &Enforcer{Source: DefaultPolicy()},
+			Ctx:          asRole(auth.RoleCustomer),
+			Method:       checkInvariants,
+			ExpectedCode: codes.PermissionDenied,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := tc.Enforcer.Authorize(tc.Ctx, tc.Method)
+			if tc.ExpectedCode != codes.OK {
+				assert.Equal(t, tc.ExpectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedPrincipal, auth.PrincipalFromContext(ctx))
+		})
+	}
+}
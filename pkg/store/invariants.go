@@ -0,0 +1,177 @@
+package store
+
+import (
+	"fmt"
+	"grpc-project/cmd/server/models"
+	"sort"
+)
+
+// Kinds of store invariant violations.
+const (
+	SectionCounterMismatch = "section-counter-mismatch"
+	SeatUserMismatch       = "seat-user-mismatch"
+	SeatWithoutReceipt     = "seat-without-receipt"
+	ReceiptSeatMismatch    = "receipt-seat-mismatch"
+	ReceiptIndexMismatch   = "receipt-index-mismatch"
+)
+
+// Violation describes a single broken store invariant.
+type Violation struct {
+	Kind     string
+	Subject  string
+	Detail   string
+	Repaired bool
+	// Seat is the seat the repair of the violation changes, nil when it
+	// changes no seat.
+	Seat *models.Seat
+}
+
+// CheckInvariants validates the hand maintained parts of the store against
+// each other and reports every discrepancy. When repair is true the
+// discrepancies that can be resolved are fixed, using Store.Receipts as the
+// source of truth. The caller must hold store.Mu.
+func CheckInvariants(store *models.Store, repair bool) []Violation {
+	var violations []Violation
+	report := func(kind, subject, detail string, seat *models.Seat, fix func() bool) {
+		v := Violation{Kind: kind, Subject: subject, Detail: detail, Seat: seat}
+		if repair && fix != nil {
+			v.Repaired = fix()
+		}
+		violations = append(violations, v)
+	}
+
+	// Seats against confirmed receipts.
+	confirmedBySeat := make(map[string]*models.Receipt)
+	for _, receipt := range store.Receipts {
+		if receipt.BookingStatus == "Confirmed" {
+			confirmedBySeat[receipt.SeatId] = receipt
+		}
+	}
+	for _, section := range store.Train.Sections {
+		for _, seat := range section.Seats {
+			if _, booked := confirmedBySeat[seat.Id]; booked {
+				// Checked from the receipt side below.
+				continue
+			}
+			if !seat.SeatAvailable || seat.User != nil {
+				report(SeatWithoutReceipt, seat.Id,
+					fmt.Sprintf("seat %s in %s is occupied without a confirmed receipt", seat.SeatNumber, section.Id), seat,
+					func() bool {
+						seat.SeatAvailable = true
+						seat.User = nil
+						return true
+					})
+			}
+		}
+	}
+	for _, receipt := range store.Receipts {
+		if receipt.BookingStatus != "Confirmed" {
+			continue
+		}
+		seat := GetSeat(store, receipt.SeatId, receipt.SectionId)
+		if seat == nil {
+			report(ReceiptSeatMismatch, receipt.Id,
+				fmt.Sprintf("receipt refers to unknown seat %s in section %s", receipt.SeatId, receipt.SectionId), nil, nil)
+			continue
+		}
+		if seat.User != nil && seat.User.Id != receipt.UserId {
+			report(SeatUserMismatch, seat.Id,
+				fmt.Sprintf("seat is held by user %s but receipt %s belongs to user %s", seat.User.Id, receipt.Id, receipt.UserId), nil, nil)
+			continue
+		}
+		if seat.SeatAvailable || seat.User == nil {
+			report(ReceiptSeatMismatch, receipt.Id,
+				fmt.Sprintf("seat %s of confirmed receipt is not occupied", seat.Id), seat,
+				func() bool {
+					user := GetUser(store, receipt.UserId)
+					if user == nil {
+						return false
+					}
+					seat.SeatAvailable = false
+					seat.User = user
+					return true
+				})
+		}
+	}
+
+	// Section counters against seat flags, after seats have been repaired.
+	for _, section := range store.Train.Sections {
+		available := 0
+		for _, seat := range section.Seats {
+			if seat.SeatAvailable {
+				available++
+			}
+		}
+		if section.AvailableSeats != available {
+			report(SectionCounterMismatch, section.Id,
+				fmt.Sprintf("counter says %d seats are available but %d seats are free", section.AvailableSeats, available), nil,
+				func() bool {
+					section.AvailableSeats = available
+					return true
+				})
+		}
+	}
+
+	// Receipt indexes against Store.Receipts.
+	reindex := func() bool {
+		ReindexReceipts(store)
+		return true
+	}
+	indexes := []struct {
+		name  string
+		index func() map[string][]string
+		keyOf func(*models.Receipt) string
+		// optional indexes leave out receipts with an empty key.
+		optional bool
+	}{
+		{"user", func() map[string][]string { return store.ReceiptsByUser }, func(r *models.Receipt) string { return r.UserId }, false},
+		{"seat", func() map[string][]string { return store.ReceiptsBySeat }, func(r *models.Receipt) string { return r.SeatId }, false},
+		{"section", func() map[string][]string { return store.ReceiptsBySection }, func(r *models.Receipt) string { return r.SectionId }, false},
+		{"reference", func() map[string][]string { return store.ReceiptsByReference }, func(r *models.Receipt) string { return r.Reference }, true},
+	}
+	for _, idx := range indexes {
+		index := idx.index()
+		expected := make(map[string][]string)
+		for id, receipt := range store.Receipts {
+			key := idx.keyOf(receipt)
+			if key == "" && idx.optional {
+				continue
+			}
+			expected[key] = append(expected[key], id)
+		}
+		for key := range index {
+			if _, exists := expected[key]; !exists {
+				expected[key] = nil
+			}
+		}
+		for key, ids := range expected {
+			indexed := append([]string(nil), index[key]...)
+			sort.Strings(ids)
+			sort.Strings(indexed)
+			if !equalIds(ids, indexed) {
+				report(ReceiptIndexMismatch, idx.name+"/"+key,
+					fmt.Sprintf("%s index lists receipts %v but the receipts store holds %v", idx.name, indexed, ids), nil,
+					reindex)
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind < violations[j].Kind
+		}
+		return violations[i].Subject < violations[j].Subject
+	})
+	return violations
+}
+
+func equalIds(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"grpc-project/cmd/server/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// consistentStore returns a store with one section of three seats where
+// seat A1 is booked by Alice.
+func consistentStore() *models.Store {
+	alice := &models.User{Id: "1", FirstName: "Alice", LastName: "Smith", Email: "alice@example.com"}
+	store := &models.Store{
+		Train: models.Train{
+			Sections: []*models.Section{
+				{
+					Id:             "A",
+					Name:           "Section A",
+					AvailableSeats: 2,
+					Seats: []*models.Seat{
+						{Id: "A1", SectionId: "A", SectionName: "Section A", SeatNumber: "1", User: alice},
+						{Id: "A2", SectionId: "A", SectionName: "Section A", SeatNumber: "2", SeatAvailable: true},
+						{Id: "A3", SectionId: "A", SectionName: "Section A", SeatNumber: "3", SeatAvailable: true},
+					},
+				},
+			},
+		},
+		Users: []*models.User{alice},
+	}
+	AddReceipt(store, &models.Receipt{Id: "r1", UserId: "1", SeatId: "A1", SectionId: "A", SeatNumber: "1", BookingStatus: "Confirmed", Version: 1})
+	AddReceipt(store, &models.Receipt{Id: "r0", UserId: "1", SeatId: "A2", SectionId: "A", SeatNumber: "2", BookingStatus: "Cancelled", Version: 2})
+	return store
+}
+
+func kinds(violations []Violation) []string {
+	var result []string
+	for _, v := range violations {
+		result = append(result, v.Kind)
+	}
+	return result
+}
+
+func Test_CheckInvariants(t *testing.T) {
+	tests := map[string]struct {
+		Corrupt       func(store *models.Store)
+		ExpectedKinds []string
+	}{
+		"Consistent store has no violations": {
+			Corrupt: func(store *models.Store) {},
+		},
+		"Counter decremented on cancellation": {
+			Corrupt: func(store *models.Store) {
+				store.Train.Sections[0].AvailableSeats--
+			},
+			ExpectedKinds: []string{SectionCounterMismatch},
+		},
+		"Seat occupied without a confirmed receipt": {
+			Corrupt: func(store *models.Store) {
+				store.Train.Sections[0].Seats[1].SeatAvailable = false
+				store.Train.Sections[0].AvailableSeats--
+			},
+			ExpectedKinds: []string{SeatWithoutReceipt},
+		},
+		"Seat of a confirmed receipt released": {
+			Corrupt: func(store *models.Store) {
+				store.Train.Sections[0].Seats[0].SeatAvailable = true
+				store.Train.Sections[0].Seats[0].User = nil
+				store.Train.Sections[0].AvailableSeats++
+			},
+			ExpectedKinds: []string{ReceiptSeatMismatch},
+		},
+		"Seat held by another user": {
+			Corrupt: func(store *models.Store) {
+				store.Train.Sections[0].Seats[0].User = &models.User{Id: "2"}
+			},
+			ExpectedKinds: []string{SeatUserMismatch},
+		},
+		"User index lists an unknown receipt": {
+			Corrupt: func(store *models.Store) {
+				store.ReceiptsByUser["1"] = append(store.ReceiptsByUser["1"], "ghost")
+			},
+			ExpectedKinds: []string{ReceiptIndexMismatch},
+		},
+		"Receipt written around the indexes": {
+			Corrupt: func(store *models.Store) {
+				store.Receipts["r1"].SeatId = "A3"
+				store.Train.Sections[0].Seats[0].SeatAvailable = true
+				store.Train.Sections[0].Seats[0].User = nil
+				store.Train.Sections[0].Seats[2].SeatAvailable = false
+				store.Train.Sections[0].Seats[2].User = store.Users[0]
+			},
+			ExpectedKinds: []string{ReceiptIndexMismatch, ReceiptIndexMismatch},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := consistentStore()
+			tc.Corrupt(store)
+
+			violations := CheckInvariants(store, false)
+			assert.Equal(t, tc.ExpectedKinds, kinds(violations))
+			for _, v := range violations {
+				assert.False(t, v.Repaired)
+			}
+			assert.Equal(t, violations, CheckInvariants(store, false), "checking without repair should not change the store")
+		})
+	}
+}
+
+func Test_CheckInvariants_Repair(t *testing.T) {
+	corruptions := map[string]func(store *models.Store){
+		"Counter": func(store *models.Store) {
+			store.Train.Sections[0].AvailableSeats = 7
+		},
+		"Seat without receipt": func(store *models.Store) {
+			store.Train.Sections[0].Seats[2].SeatAvailable = false
+			store.Train.Sections[0].Seats[2].User = store.Users[0]
+		},
+		"Released seat of confirmed receipt": func(store *models.Store) {
+			store.Train.Sections[0].Seats[0].SeatAvailable = true
+			store.Train.Sections[0].Seats[0].User = nil
+		},
+		"Receipt indexes": func(store *models.Store) {
+			store.ReceiptsByUser = map[string][]string{"2": {"r1", "ghost"}}
+			delete(store.ReceiptsBySeat, "A1")
+		},
+	}
+	for name, corrupt := range corruptions {
+		t.Run(name, func(t *testing.T) {
+			store := consistentStore()
+			corrupt(store)
+
+			violations := CheckInvariants(store, true)
+			assert.NotEmpty(t, violations)
+			for _, v := range violations {
+				assert.True(t, v.Repaired, "%s should be repaired", v.Kind)
+			}
+			assert.Empty(t, CheckInvariants(store, false))
+			expected := consistentStore()
+			assert.Equal(t, expected.Receipts, store.Receipts)
+			assert.ElementsMatch(t, expected.ReceiptsByUser["1"], store.ReceiptsByUser["1"])
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"fmt"
+	"grpc-project/cmd/server/models"
+	"sort"
+)
+
+// AddReceipt stores a new receipt as the canonical record and indexes it by
+// user, seat, section and booking reference.
+func AddReceipt(store *models.Store, receipt *models.Receipt) error {
+	initReceipts(store)
+	if _, exists := store.Receipts[receipt.Id]; exists {
+		return fmt.Errorf("receipt already exists for the given Receipt ID : %s", receipt.Id)
+	}
+	if receipt.Reference != "" && len(store.ReceiptsByReference[receipt.Reference]) > 0 {
+		return fmt.Errorf("booking reference %s is already in use", receipt.Reference)
+	}
+	store.Receipts[receipt.Id] = receipt
+	indexReceipt(store, receipt)
+	return nil
+}
+
+// UpdateReceipt overwrites the canonical record of an existing receipt and
+// moves it between indexes when its user, seat or section changed.
+func UpdateReceipt(store *models.Store, receipt models.Receipt) error {
+	current, exists := store.Receipts[receipt.Id]
+	if !exists {
+		return fmt.Errorf("receipt not found for the given Receipt ID : %s", receipt.Id)
+	}
+	unindexReceipt(store, current)
+	*current = receipt
+	indexReceipt(store, current)
+	return nil
+}
+
+// RemoveReceipt deletes a receipt and its index entries.
+func RemoveReceipt(store *models.Store, receiptId string) {
+	if receipt, exists := store.Receipts[receiptId]; exists {
+		unindexReceipt(store, receipt)
+		delete(store.Receipts, receiptId)
+	}
+}
+
+// GetUserReceipts returns the receipts of a user in booking order.
+func GetUserReceipts(store *models.Store, userId string) []*models.Receipt {
+	return lookupReceipts(store, store.ReceiptsByUser[userId])
+}
+
+// GetSeatReceipts returns every receipt, confirmed or cancelled, that was
+// issued for a seat.
+func GetSeatReceipts(store *models.Store, seatId string) []*models.Receipt {
+	return lookupReceipts(store, store.ReceiptsBySeat[seatId])
+}
+
+// GetSectionReceipts returns every receipt issued for a seat in a section.
+func GetSectionReceipts(store *models.Store, sectionId string) []*models.Receipt {
+	return lookupReceipts(store, store.ReceiptsBySection[sectionId])
+}
+
+// ReindexReceipts rebuilds the receipt indexes from the canonical records.
+// Entries that are still valid keep their order, missing entries are
+// appended in receipt ID order.
+func ReindexReceipts(store *models.Store) {
+	initReceipts(store)
+	store.ReceiptsByUser = reindex(store, store.ReceiptsByUser, func(r *models.Receipt) string { return r.UserId })
+	store.ReceiptsBySeat = reindex(store, store.ReceiptsBySeat, func(r *models.Receipt) string { return r.SeatId })
+	store.ReceiptsBySection = reindex(store, store.ReceiptsBySection, func(r *models.Receipt) string { return r.SectionId })
+	store.ReceiptsByReference = reindex(store, store.ReceiptsByReference, func(r *models.Receipt) string { return r.Reference })
+	delete(store.ReceiptsByReference, "")
+}
+
+func initReceipts(store *models.Store) {
+	if store.Receipts == nil {
+		store.Receipts = make(map[string]*models.Receipt)
+	}
+	if store.ReceiptsByUser == nil {
+		store.ReceiptsByUser = make(map[string][]string)
+	}
+	if store.ReceiptsBySeat == nil {
+		store.ReceiptsBySeat = make(map[string][]string)
+	}
+	if store.ReceiptsBySection == nil {
+		store.ReceiptsBySection = make(map[string][]string)
+	}
+	if store.ReceiptsByReference == nil {
+		store.ReceiptsByReference = make(map[string][]string)
+	}
+}
+
+func indexReceipt(store *models.Store, receipt *models.Receipt) {
+	initReceipts(store)
+	store.ReceiptsByUser[receipt.UserId] = append(store.ReceiptsByUser[receipt.UserId], receipt.Id)
+	store.ReceiptsBySeat[receipt.SeatId] = append(store.ReceiptsBySeat[receipt.SeatId], receipt.Id)
+	store.ReceiptsBySection[receipt.SectionId] = append(store.ReceiptsBySection[receipt.SectionId], receipt.Id)
+	if receipt.Reference != "" {
+		store.ReceiptsByReference[receipt.Reference] = append(store.ReceiptsByReference[receipt.Reference], receipt.Id)
+	}
+}
+
+func unindexReceipt(store *models.Store, receipt *models.Receipt) {
+	removeFromIndex(store.ReceiptsByUser, receipt.UserId, receipt.Id)
+	removeFromIndex(store.ReceiptsBySeat, receipt.SeatId, receipt.Id)
+	removeFromIndex(store.ReceiptsBySection, receipt.SectionId, receipt.Id)
+	removeFromIndex(store.ReceiptsByReference, receipt.Reference, receipt.Id)
+}
+
+func removeFromIndex(index map[string][]string, key string, receiptId string) {
+	ids := index[key]
+	for i, id := range ids {
+		if id == receiptId {
+			ids = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+	if len(ids) == 0 {
+		delete(index, key)
+	} else {
+		index[key] = ids
+	}
+}
+
+func lookupReceipts(store *models.Store, ids []string) []*models.Receipt {
+	var receipts []*models.Receipt
+	for _, id := range ids {
+		if receipt, exists := store.Receipts[id]; exists {
+			receipts = append(receipts, receipt)
+		}
+	}
+	return receipts
+}
+
+func reindex(store *models.Store, index map[string][]string, keyOf func(*models.Receipt) string) map[string][]string {
+	rebuilt := make(map[string][]string)
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(index))
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, id := range index[key] {
+			receipt, exists := store.Receipts[id]
+			if !exists || seen[id] || keyOf(receipt) != key {
+				continue
+			}
+			seen[id] = true
+			rebuilt[key] = append(rebuilt[key], id)
+		}
+	}
+	ids := make([]string, 0, len(store.Receipts))
+	for id := range store.Receipts {
+		if !seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		key := keyOf(store.Receipts[id])
+		rebuilt[key] = append(rebuilt[key], id)
+	}
+	return rebuilt
+}
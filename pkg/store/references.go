@@ -0,0 +1,96 @@
+package store
+
+import (
+	"crypto/rand"
+	"fmt"
+	"grpc-project/cmd/server/models"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ReferenceLength is the number of characters of a booking reference.
+const ReferenceLength = 6
+
+// referenceAlphabet leaves out 0, O, 1 and I, which are easily mixed up when
+// a reference is read out or typed in.
+const referenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// referenceAttempts bounds the retries on collisions. With 32^6 references
+// running out of attempts means the reference space is nearly full.
+const referenceAttempts = 20
+
+// NewReference returns a random booking reference that no receipt of the
+// store and none of exclude uses. The caller must hold the store lock.
+func NewReference(store *models.Store, exclude ...string) (string, error) {
+	max := big.NewInt(int64(len(referenceAlphabet)))
+	for attempt := 0; attempt < referenceAttempts; attempt++ {
+		var b strings.Builder
+		for i := 0; i < ReferenceLength; i++ {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(referenceAlphabet[n.Int64()])
+		}
+		reference := b.String()
+		if len(store.ReceiptsByReference[reference]) == 0 && !contains(exclude, reference) {
+			return reference, nil
+		}
+	}
+	return "", fmt.Errorf("no free booking reference found after %d attempts", referenceAttempts)
+}
+
+// NormalizeReference uppercases a reference and removes the spaces and
+// dashes people type into it.
+func NormalizeReference(reference string) string {
+	reference = strings.ToUpper(reference)
+	return strings.NewReplacer(" ", "", "-", "").Replace(reference)
+}
+
+// GetReceiptByReference returns the receipt with the booking reference, or
+// nil.
+func GetReceiptByReference(store *models.Store, reference string) *models.Receipt {
+	if reference == "" {
+		return nil
+	}
+	receipts := lookupReceipts(store, store.ReceiptsByReference[NormalizeReference(reference)])
+	if len(receipts) == 0 {
+		return nil
+	}
+	return receipts[0]
+}
+
+// AssignReferences gives a booking reference to every receipt that has none,
+// e.g. receipts saved before references existed, and returns how many were
+// assigned. The caller must hold the store lock.
+func AssignReferences(store *models.Store) (int, error) {
+	ids := make([]string, 0, len(store.Receipts))
+	for id, receipt := range store.Receipts {
+		if receipt.Reference == "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	initReceipts(store)
+	for _, id := range ids {
+		reference, err := NewReference(store)
+		if err != nil {
+			return 0, err
+		}
+		// Only the reference index changes, the other indexes keep the
+		// booking order.
+		store.Receipts[id].Reference = reference
+		store.ReceiptsByReference[reference] = []string{id}
+	}
+	return len(ids), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
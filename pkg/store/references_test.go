@@ -0,0 +1,38 @@
+package store
+
+import (
+	"grpc-project/cmd/server/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_References(t *testing.T) {
+	store := consistentStore()
+
+	// Receipts from before references get one, the booking order is kept.
+	assigned, err := AssignReferences(store)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, assigned)
+	assert.Equal(t, []string{"r1", "r0"}, store.ReceiptsByUser["1"])
+	reference := store.Receipts["r1"].Reference
+	assert.Len(t, reference, ReferenceLength)
+	assert.NotEqual(t, reference, store.Receipts["r0"].Reference)
+	assert.Empty(t, CheckInvariants(store, false))
+
+	assert.Equal(t, "r1", GetReceiptByReference(store, " "+reference[:3]+"-"+reference[3:]).Id)
+	assert.Nil(t, GetReceiptByReference(store, ""))
+
+	// References are unique.
+	err = AddReceipt(store, &models.Receipt{Id: "r2", UserId: "1", SeatId: "A3", SectionId: "A", Reference: reference})
+	assert.Error(t, err)
+	next, err := NewReference(store, "ABCDEF")
+	assert.NoError(t, err)
+	assert.NotEqual(t, reference, next)
+	assert.NotEqual(t, "ABCDEF", next)
+
+	// The reference index is checked and repaired like the others.
+	delete(store.ReceiptsByReference, reference)
+	assert.Equal(t, []string{ReceiptIndexMismatch}, kinds(CheckInvariants(store, true)))
+	assert.Equal(t, "r1", GetReceiptByReference(store, reference).Id)
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+	"os"
+	"path/filepath"
+)
+
+// snapshot is the persisted form of the store. The receipt indexes are
+// saved to keep the booking order and are checked against the receipts on
+// load.
+type snapshot struct {
+	Train             models.Train
+	Users             []*models.User
+	DiscountCodes     map[string]money.Money
+	Receipts          map[string]*models.Receipt
+	ReceiptsByUser    map[string][]string
+	ReceiptsBySeat    map[string][]string
+	ReceiptsBySection map[string][]string
+}
+
+// SaveSnapshot writes the store to path as JSON. The file is replaced
+// atomically, a crash never leaves a partial snapshot behind. The caller must
+// hold the store lock.
+func SaveSnapshot(store *models.Store, path string) error {
+	data, err := json.MarshalIndent(snapshot{
+		Train:             store.Train,
+		Users:             store.Users,
+		DiscountCodes:     store.DiscountCodes,
+		Receipts:          store.Receipts,
+		ReceiptsByUser:    store.ReceiptsByUser,
+		ReceiptsBySeat:    store.ReceiptsBySeat,
+		ReceiptsBySection: store.ReceiptsBySection,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot replaces the contents of the store with the snapshot at path.
+// It returns an error satisfying errors.Is(err, os.ErrNotExist) when there is
+// no snapshot yet, the store is left untouched then. The caller must hold the
+// store lock.
+func LoadSnapshot(store *models.Store, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var loaded snapshot
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return err
+	}
+	if len(loaded.Train.Sections) == 0 {
+		return errors.New("snapshot has no train sections")
+	}
+
+	store.Train = loaded.Train
+	store.Users = loaded.Users
+	store.DiscountCodes = loaded.DiscountCodes
+	store.Receipts = loaded.Receipts
+	store.ReceiptsByUser = loaded.ReceiptsByUser
+	store.ReceiptsBySeat = loaded.ReceiptsBySeat
+	store.ReceiptsBySection = loaded.ReceiptsBySection
+	store.ReceiptsByReference = nil
+	ReindexReceipts(store)
+	if _, err := AssignReferences(store); err != nil {
+		return err
+	}
+
+	//Seats share the user records of the store
+	for _, section := range store.Train.Sections {
+		for _, seat := range section.Seats {
+			if seat.User == nil {
+				continue
+			}
+			if user := GetUser(store, seat.User.Id); user != nil {
+				seat.User = user
+			}
+		}
+	}
+	return nil
+}
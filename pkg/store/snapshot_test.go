@@ -0,0 +1,92 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Snapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	saved := consistentStore()
+	saved.DiscountCodes = map[string]money.Money{"discount1": money.New(1000, money.USD)}
+	saved.Receipts["r0"].Charged = money.New(1840, money.EUR)
+	saved.Receipts["r0"].Rate = &money.Rate{Version: "1", From: money.USD, To: money.EUR, Value: "0.92"}
+	assert.NoError(t, SaveSnapshot(saved, path))
+
+	loaded := &models.Store{}
+	assert.NoError(t, LoadSnapshot(loaded, path))
+	assert.Empty(t, CheckInvariants(loaded, false))
+	assert.Equal(t, saved.Train.Sections[0].AvailableSeats, loaded.Train.Sections[0].AvailableSeats)
+	assert.Equal(t, saved.DiscountCodes, loaded.DiscountCodes)
+	assert.Equal(t, []string{"r1", "r0"}, loaded.ReceiptsByUser["1"])
+	assert.Equal(t, int64(2), loaded.Receipts["r0"].Version)
+	assert.Equal(t, saved.Receipts["r0"].Charged, loaded.Receipts["r0"].Charged)
+	assert.Equal(t, saved.Receipts["r0"].Rate, loaded.Receipts["r0"].Rate)
+	// The seat and the user list share one user record.
+	assert.Same(t, loaded.Users[0], loaded.Train.Sections[0].Seats[0].User)
+}
+
+// Test_LoadSnapshot_LegacyPrices loads a snapshot saved when prices were
+// plain numbers of dollars.
+func Test_LoadSnapshot_LegacyPrices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	assert.NoError(t, SaveSnapshot(consistentStore(), path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var legacy map[string]any
+	assert.NoError(t, json.Unmarshal(data, &legacy))
+	legacy["Train"].(map[string]any)["Price"] = 20.0
+	legacy["DiscountCodes"] = map[string]any{"discount1": 10.0}
+	legacy["Receipts"].(map[string]any)["r0"].(map[string]any)["Price"] = 12.345
+	data, err = json.Marshal(legacy)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	loaded := &models.Store{}
+	assert.NoError(t, LoadSnapshot(loaded, path))
+	assert.Equal(t, money.New(2000, money.USD), loaded.Train.Price)
+	assert.Equal(t, map[string]money.Money{"discount1": money.New(1000, money.USD)}, loaded.DiscountCodes)
+	assert.Equal(t, money.New(1235, money.USD), loaded.Receipts["r0"].Price)
+}
+
+func Test_LoadSnapshot_Errors(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	assert.NoError(t, os.WriteFile(corrupt, []byte("{not json"), 0o600))
+	empty := filepath.Join(dir, "empty.json")
+	assert.NoError(t, os.WriteFile(empty, []byte("{}"), 0o600))
+
+	type test struct {
+		Path             string
+		ExpectedNotExist bool
+	}
+	tests := map[string]test{
+		"Sad Path - No snapshot yet": {
+			Path:             filepath.Join(dir, "missing.json"),
+			ExpectedNotExist: true,
+		},
+		"Sad Path - Corrupt snapshot": {
+			Path: corrupt,
+		},
+		"Sad Path - Snapshot without a train": {
+			Path: empty,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := consistentStore()
+			err := LoadSnapshot(store, tc.Path)
+			assert.Error(t, err)
+			assert.Equal(t, tc.ExpectedNotExist, errors.Is(err, os.ErrNotExist))
+			// The store is left untouched.
+			assert.Len(t, store.Receipts, 2)
+		})
+	}
+}
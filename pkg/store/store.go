@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"grpc-project/cmd/server/models"
+	"grpc-project/pkg/money"
 )
 
 func GetSectionStore(store *models.Store) []*models.Section {
@@ -11,6 +12,9 @@ func GetSectionStore(store *models.Store) []*models.Section {
 }
 func GetSeat(store *models.Store, seatId string, sectionId string) *models.Seat {
 	section := GetSection(store, sectionId)
+	if section == nil {
+		return nil // Section not found
+	}
 
 	for _, seat := range section.Seats {
 		if seat.Id == seatId {
@@ -45,16 +49,20 @@ func GetUser(store *models.Store, userId string) *models.User {
 //		}
 //		return 0.0 // Return 0 if train not found or price not set
 //	}
-func GetPriceFromReceipts(store *models.Store, receiptId string) float32 {
+func GetPriceFromReceipts(store *models.Store, receiptId string) money.Money {
 	receipt, err := CheckValidReceipt(store, receiptId)
 	if err != nil {
-		return 0.0
+		return money.Zero(store.Train.Price.Currency)
 	}
 	return receipt.Price
 }
+
+// CheckValidReceipt returns a copy of the receipt. Changes to the copy are
+// written back with UpdateReceipt.
 func CheckValidReceipt(store *models.Store, receiptId string) (*models.Receipt, error) {
 	if receipt, exists := store.Receipts[receiptId]; exists {
-		return &receipt, nil
+		copied := *receipt
+		return &copied, nil
 	}
 	return nil, fmt.Errorf("receipt not found for the given Receipt ID : %s", receiptId)
 }
@@ -64,19 +72,3 @@ func CheckValidCouponCode(store *models.Store, couponCode string) bool {
 	}
 	return false
 }
-func CancelReceiptsFromStore(store *models.Store, receiptId string) {
-	if receipt, exists := store.Receipts[receiptId]; exists {
-		receipt.BookingStatus = "Cancelled"
-		store.Receipts[receiptId] = receipt
-	}
-}
-func UpdateUserReceipts(store *models.Store, userId string, receipt *models.Receipt) {
-
-	for _, user := range store.Users {
-		if user.Id == userId {
-			user.Receipts = append(user.Receipts, receipt)
-			return
-		}
-	}
-
-}
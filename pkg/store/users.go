@@ -0,0 +1,56 @@
+package store
+
+import (
+	"fmt"
+	"grpc-project/cmd/server/models"
+	"net/mail"
+	"strings"
+)
+
+// NormalizeEmail trims and lower-cases an email address and checks that it
+// is a plain address without a display name.
+func NormalizeEmail(email string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(normalized)
+	if err != nil || address.Address != normalized {
+		return "", fmt.Errorf("invalid email address: %q", email)
+	}
+	return normalized, nil
+}
+
+// GetUserByEmail finds a user by email address, ignoring case and
+// surrounding whitespace.
+func GetUserByEmail(store *models.Store, email string) *models.User {
+	wanted := strings.ToLower(strings.TrimSpace(email))
+	if wanted == "" {
+		return nil
+	}
+	for _, user := range store.Users {
+		if strings.ToLower(strings.TrimSpace(user.Email)) == wanted {
+			return user
+		}
+	}
+	return nil
+}
+
+// AddUser registers a new user. User IDs and email addresses must be unique.
+func AddUser(store *models.Store, user *models.User) error {
+	if GetUser(store, user.Id) != nil {
+		return fmt.Errorf("user already exists for the given User ID : %s", user.Id)
+	}
+	if GetUserByEmail(store, user.Email) != nil {
+		return fmt.Errorf("user already exists for the given email : %s", user.Email)
+	}
+	store.Users = append(store.Users, user)
+	return nil
+}
+
+// RemoveUser deletes a user from the store.
+func RemoveUser(store *models.Store, userId string) {
+	for i, user := range store.Users {
+		if user.Id == userId {
+			store.Users = append(store.Users[:i], store.Users[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func Test_Setup(t *testing.T) {
+	type test struct {
+		Options       Options
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - No exporter": {
+			Options: Options{ServiceName: "test"},
+		},
+		"Happy Path - Stdout exporter": {
+			Options: Options{ServiceName: "test", Exporter: ExporterStdout},
+		},
+		"Sad Path - Unknown exporter": {
+			Options:       Options{ServiceName: "test", Exporter: "zipkin"},
+			ExpectedError: true,
+		},
+		"Sad Path - File exporter without a file": {
+			Options:       Options{ServiceName: "test", Exporter: ExporterFile},
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tc.Options)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func Test_Setup_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Options{ServiceName: "booking-server", Exporter: ExporterFile, File: path, SampleRatio: 1})
+	assert.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "booking.price")
+	span.End()
+	assert.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"booking.price"`)
+	assert.Contains(t, string(data), `"Value":"booking-server"`)
+}
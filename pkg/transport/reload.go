@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"grpc-project/pkg/filewatch"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// KeyPair is a certificate and private key read from PEM files. The files are
+// re-read when they change, so certificates can be renewed without a restart.
+// A pair that cannot be loaded, e.g. while only one of the files has been
+// replaced, keeps the current certificate in place until the files change
+// again.
+type KeyPair struct {
+	mu    sync.Mutex
+	files filewatch.Files
+	cert  *tls.Certificate
+}
+
+func NewKeyPair(certFile string, keyFile string, checkInterval time.Duration) (*KeyPair, error) {
+	k := &KeyPair{files: filewatch.New(checkInterval, certFile, keyFile)}
+	if err := k.reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Certificate returns the current certificate.
+func (k *KeyPair) Certificate() *tls.Certificate {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := k.reload(); err != nil {
+		log.Printf("failed to reload certificate, keeping the current one: %v", err)
+	}
+	return k.cert
+}
+
+func (k *KeyPair) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return k.Certificate(), nil
+}
+
+func (k *KeyPair) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return k.Certificate(), nil
+}
+
+func (k *KeyPair) reload() error {
+	changed, err := k.files.Changed()
+	if err != nil || !changed {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(k.files.Paths[0], k.files.Paths[1])
+	if err != nil {
+		return err
+	}
+	k.cert = &cert
+	return nil
+}
+
+// CertPool is a pool of CA certificates read from a PEM file and re-read when
+// it changes.
+type CertPool struct {
+	mu    sync.Mutex
+	files filewatch.Files
+	pool  *x509.CertPool
+}
+
+func NewCertPool(caFile string, checkInterval time.Duration) (*CertPool, error) {
+	p := &CertPool{files: filewatch.New(checkInterval, caFile)}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Pool returns the current CA certificates.
+func (p *CertPool) Pool() *x509.CertPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.reload(); err != nil {
+		log.Printf("failed to reload CA certificates, keeping the current ones: %v", err)
+	}
+	return p.pool
+}
+
+func (p *CertPool) reload() error {
+	changed, err := p.files.Changed()
+	if err != nil || !changed {
+		return err
+	}
+	data, err := os.ReadFile(p.files.Paths[0])
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return fmt.Errorf("no certificates found in %s", p.files.Paths[0])
+	}
+	p.pool = pool
+	return nil
+}
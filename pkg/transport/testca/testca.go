@@ -0,0 +1,136 @@
+// Package testca is a throwaway certificate authority for tests and local
+// development, so the server and client can be run over real (m)TLS without
+// any external tooling. Its keys live in memory only.
+package testca
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CA issues leaf certificates signed by a self-signed root.
+type CA struct {
+	Cert    *x509.Certificate
+	CertPEM []byte
+	key     *ecdsa.PrivateKey
+}
+
+// Leaf describes a certificate to issue. Every leaf is valid for both server
+// and client authentication.
+type Leaf struct {
+	CommonName string
+	// OrganizationalUnits are used as the role of client certificates.
+	OrganizationalUnits []string
+	DNSNames            []string
+	IPAddresses         []net.IP
+	EmailAddresses      []string
+	// ValidFor defaults to 24 hours.
+	ValidFor time.Duration
+}
+
+func New() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "grpc-project test CA"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{
+		Cert:    cert,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:     key,
+	}, nil
+}
+
+// Issue returns the PEM encoded certificate and PKCS#8 private key of a new
+// leaf.
+func (ca *CA) Issue(leaf Leaf) (certPEM []byte, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	validFor := leaf.ValidFor
+	if validFor == 0 {
+		validFor = 24 * time.Hour
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject: pkix.Name{
+			CommonName:         leaf.CommonName,
+			OrganizationalUnit: leaf.OrganizationalUnits,
+		},
+		DNSNames:       leaf.DNSNames,
+		IPAddresses:    leaf.IPAddresses,
+		EmailAddresses: leaf.EmailAddresses,
+		NotBefore:      time.Now().Add(-time.Minute),
+		NotAfter:       time.Now().Add(validFor),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// WriteCA writes the CA certificate to dir/ca.pem and returns its path.
+func (ca *CA) WriteCA(dir string) (string, error) {
+	path := filepath.Join(dir, "ca.pem")
+	return path, os.WriteFile(path, ca.CertPEM, 0o644)
+}
+
+// WriteLeaf issues a leaf and writes it to dir/<name>.pem and
+// dir/<name>-key.pem.
+func (ca *CA) WriteLeaf(dir string, name string, leaf Leaf) (certFile string, keyFile string, err error) {
+	certPEM, keyPEM, err := ca.Issue(leaf)
+	if err != nil {
+		return "", "", err
+	}
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return "", "", err
+	}
+	return certFile, keyFile, nil
+}
+
+func serialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		panic(err)
+	}
+	return serial
+}
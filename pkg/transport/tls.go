@@ -0,0 +1,154 @@
+// Package transport builds the TLS configuration of the server and client
+// from certificate files and extracts the client identity of mTLS peers.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// DefaultCheckInterval is how often certificate files are checked for
+// changes.
+const DefaultCheckInterval = 10 * time.Second
+
+// ServerOptions configure the server side of TLS.
+type ServerOptions struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mTLS: client certificates are verified against
+	// these CAs.
+	ClientCAFile string
+	// RequireClientCert rejects clients without a valid certificate. Without
+	// it a client certificate is optional but verified when presented.
+	RequireClientCert bool
+	CheckInterval     time.Duration
+}
+
+// ServerConfig returns a TLS config that picks up renewed certificate and CA
+// files on new connections.
+func ServerConfig(opts ServerOptions) (*tls.Config, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("server certificate and key are required")
+	}
+	if opts.RequireClientCert && opts.ClientCAFile == "" {
+		return nil, fmt.Errorf("client CA is required to verify client certificates")
+	}
+	keyPair, err := NewKeyPair(opts.CertFile, opts.KeyFile, opts.CheckInterval)
+	if err != nil {
+		return nil, err
+	}
+	var clientCAs *CertPool
+	if opts.ClientCAFile != "" {
+		clientCAs, err = NewCertPool(opts.ClientCAFile, opts.CheckInterval)
+		if err != nil {
+			return nil, err
+		}
+	}
+	base := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: keyPair.GetCertificate,
+	}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		config := base.Clone()
+		config.GetConfigForClient = nil
+		if clientCAs != nil {
+			config.ClientCAs = clientCAs.Pool()
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+			if opts.RequireClientCert {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+		}
+		return config, nil
+	}
+	return base, nil
+}
+
+// ClientOptions configure the client side of TLS.
+type ClientOptions struct {
+	// CAFile verifies the server certificate. The system roots are used when
+	// it is empty.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented for mTLS.
+	CertFile string
+	KeyFile  string
+	// ServerName is the name the server certificate is verified for. With a
+	// CAFile it is required to reach a server by IP address, as the address
+	// is not part of the handshake the CA is checked in.
+	ServerName    string
+	CheckInterval time.Duration
+}
+
+// ClientConfig returns a TLS config that verifies the server against the
+// current CA file on every handshake, so a rotated CA is picked up by new
+// connections.
+func ClientConfig(opts ClientOptions) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: opts.ServerName,
+	}
+	if opts.CAFile != "" {
+		roots, err := NewCertPool(opts.CAFile, opts.CheckInterval)
+		if err != nil {
+			return nil, err
+		}
+		// RootCAs is read once, so the standard verification is replaced by
+		// one against the pool of the handshake.
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyServer(state, opts.ServerName, roots.Pool())
+		}
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		keyPair, err := NewKeyPair(opts.CertFile, opts.KeyFile, opts.CheckInterval)
+		if err != nil {
+			return nil, err
+		}
+		config.GetClientCertificate = keyPair.GetClientCertificate
+	}
+	return config, nil
+}
+
+// verifyServer does the verification InsecureSkipVerify turns off: the chain
+// of the server certificate and its name. The name is the one sent to the
+// server, or serverName when there is none, as for IP addresses.
+func verifyServer(state tls.ConnectionState, serverName string, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+	if state.ServerName != "" {
+		serverName = state.ServerName
+	}
+	if serverName == "" {
+		return fmt.Errorf("server name is required to verify the server certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// PeerCertificate returns the verified certificate of the client, or nil when
+// the call did not come over mTLS.
+func PeerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
@@ -0,0 +1,186 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"grpc-project/pkg/transport/testca"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var localhost = testca.Leaf{CommonName: "localhost", DNSNames: []string{"localhost"}, IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}}
+
+// startServer serves the health service over TLS and records the client
+// certificate of the last call.
+func startServer(t *testing.T, opts ServerOptions) (string, *x509.Certificate) {
+	config, err := ServerConfig(opts)
+	assert.NoError(t, err)
+	var peerCert x509.Certificate
+	s := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(config)),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if cert := PeerCertificate(ctx); cert != nil {
+				peerCert = *cert
+			}
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String(), &peerCert
+}
+
+// check calls the health service with a new connection and returns the serial
+// number of the server certificate.
+func check(t *testing.T, addr string, opts ClientOptions) (string, error) {
+	config, err := ClientConfig(opts)
+	assert.NoError(t, err)
+	var serial string
+	verify := config.VerifyConnection
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		serial = state.PeerCertificates[0].SerialNumber.String()
+		if verify != nil {
+			return verify(state)
+		}
+		return nil
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	assert.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return serial, err
+}
+
+func Test_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := testca.New()
+	assert.NoError(t, err)
+	caFile, err := ca.WriteCA(dir)
+	assert.NoError(t, err)
+	serverCert, serverKey, err := ca.WriteLeaf(dir, "server", localhost)
+	assert.NoError(t, err)
+	clientCert, clientKey, err := ca.WriteLeaf(dir, "client", testca.Leaf{CommonName: "ops-console", OrganizationalUnits: []string{"admin"}})
+	assert.NoError(t, err)
+
+	otherCA, err := testca.New()
+	assert.NoError(t, err)
+	otherDir := t.TempDir()
+	otherCAFile, err := otherCA.WriteCA(otherDir)
+	assert.NoError(t, err)
+	otherCert, otherKey, err := otherCA.WriteLeaf(otherDir, "client", testca.Leaf{CommonName: "intruder"})
+	assert.NoError(t, err)
+
+	addr, peerCert := startServer(t, ServerOptions{
+		CertFile:          serverCert,
+		KeyFile:           serverKey,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+	})
+
+	type test struct {
+		ClientOptions ClientOptions
+		ExpectedError bool
+	}
+	tests := map[string]test{
+		"Happy Path - Client with a certificate of the CA": {
+			ClientOptions: ClientOptions{ServerName: "localhost", CAFile: caFile, CertFile: clientCert, KeyFile: clientKey},
+		},
+		"Sad Path - Client without a certificate": {
+			ClientOptions: ClientOptions{ServerName: "localhost", CAFile: caFile},
+			ExpectedError: true,
+		},
+		"Sad Path - Client with a certificate of another CA": {
+			ClientOptions: ClientOptions{ServerName: "localhost", CAFile: caFile, CertFile: otherCert, KeyFile: otherKey},
+			ExpectedError: true,
+		},
+		"Sad Path - Client that does not trust the server": {
+			ClientOptions: ClientOptions{ServerName: "localhost", CAFile: otherCAFile, CertFile: clientCert, KeyFile: clientKey},
+			ExpectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := check(t, addr, tc.ClientOptions)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ops-console", peerCert.Subject.CommonName)
+			assert.Equal(t, []string{"admin"}, peerCert.Subject.OrganizationalUnit)
+		})
+	}
+}
+
+func Test_ServerCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := testca.New()
+	assert.NoError(t, err)
+	caFile, err := ca.WriteCA(dir)
+	assert.NoError(t, err)
+	serverCert, serverKey, err := ca.WriteLeaf(dir, "server", localhost)
+	assert.NoError(t, err)
+
+	addr, _ := startServer(t, ServerOptions{CertFile: serverCert, KeyFile: serverKey})
+	firstSerial, err := check(t, addr, ClientOptions{ServerName: "localhost", CAFile: caFile})
+	assert.NoError(t, err)
+
+	// A half written renewal keeps the current certificate.
+	assert.NoError(t, os.WriteFile(serverKey, []byte("not a key"), 0o600))
+	serial, err := check(t, addr, ClientOptions{ServerName: "localhost", CAFile: caFile})
+	assert.NoError(t, err)
+	assert.Equal(t, firstSerial, serial)
+
+	// The renewed certificate is used for new connections.
+	_, _, err = ca.WriteLeaf(dir, "server", localhost)
+	assert.NoError(t, err)
+	serial, err = check(t, addr, ClientOptions{ServerName: "localhost", CAFile: caFile})
+	assert.NoError(t, err)
+	assert.NotEqual(t, firstSerial, serial)
+}
+
+func Test_ClientCAReload(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := testca.New()
+	assert.NoError(t, err)
+	serverCert, serverKey, err := ca.WriteLeaf(dir, "server", localhost)
+	assert.NoError(t, err)
+	addr, _ := startServer(t, ServerOptions{CertFile: serverCert, KeyFile: serverKey})
+
+	// The client starts out trusting another CA.
+	otherCA, err := testca.New()
+	assert.NoError(t, err)
+	caFile, err := otherCA.WriteCA(dir)
+	assert.NoError(t, err)
+	config, err := ClientConfig(ClientOptions{ServerName: "localhost", CAFile: caFile})
+	assert.NoError(t, err)
+	dial := func() error {
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+		assert.NoError(t, err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+	assert.Error(t, dial())
+
+	// The rotated CA file is used by the same config for new connections.
+	_, err = ca.WriteCA(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, dial())
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Headers browsers may send and read on cross-origin calls, covering gRPC-Web,
+// Connect and the REST gateway.
+var (
+	allowedMethods = "GET, POST, PUT, PATCH, DELETE"
+	allowedHeaders = strings.Join([]string{
+		"Authorization", "Content-Type", "X-Request-Id", "X-User-Agent",
+		"X-Grpc-Web", "Grpc-Timeout", "Connect-Protocol-Version", "Connect-Timeout-Ms",
+	}, ", ")
+	exposedHeaders = strings.Join([]string{
+		"X-Request-Id", "Retry-After", "Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+	}, ", ")
+)
+
+// CORS lets browser pages from other origins call the server.
+type CORS struct {
+	// AllowedOrigins are the origins, e.g. "https://booking.example.com",
+	// whose pages may call the server. "*" allows every origin. Without
+	// origins cross-origin calls are left to the browser to block.
+	AllowedOrigins []string
+}
+
+func (c CORS) allowed(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler adds the CORS headers to the responses of next and answers
+// preflight requests.
+func (c CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !c.allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", "7200")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,112 @@
+// Package web serves a gRPC server over HTTP, so that native gRPC, gRPC-Web
+// and Connect clients share one listener. Browsers can call the services
+// directly, without a proxy in front of the server.
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/vanguard/vanguardgrpc"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// Options configure the HTTP side of the server.
+type Options struct {
+	// TLSConfig serves HTTPS with HTTP/2 negotiated through ALPN. Without it
+	// the server accepts plaintext HTTP/1.1 and HTTP/2 (h2c).
+	TLSConfig *tls.Config
+	CORS      CORS
+}
+
+// Server serves the gRPC server over HTTP. gRPC-Web and Connect calls are
+// translated to gRPC, so every call passes the interceptors of the gRPC
+// server.
+type Server struct {
+	grpcServer *grpc.Server
+	httpServer *http.Server
+	tls        bool
+	pending    atomic.Int64
+}
+
+// New returns a server for the services registered with grpcServer. It must
+// be called after all services are registered.
+func New(grpcServer *grpc.Server, opts Options) (*Server, error) {
+	transcoder, err := vanguardgrpc.NewTranscoder(grpcServer)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{grpcServer: grpcServer, tls: opts.TLSConfig != nil}
+	handler := s.track(opts.CORS.Handler(transcoder))
+
+	h2 := &http2.Server{}
+	s.httpServer = &http.Server{Handler: handler}
+	if s.tls {
+		opts.TLSConfig.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+		s.httpServer.TLSConfig = opts.TLSConfig
+	} else {
+		s.httpServer.Handler = h2c.NewHandler(handler, h2)
+	}
+	//Let Shutdown send GOAWAY on HTTP/2 connections, h2c included
+	if err := http2.ConfigureServer(s.httpServer, h2); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Serve accepts connections on lis until Shutdown is called.
+func (s *Server) Serve(lis net.Listener) error {
+	var err error
+	if s.tls {
+		err = s.httpServer.ServeTLS(lis, "", "")
+	} else {
+		err = s.httpServer.Serve(lis)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting calls and waits up to timeout for the pending
+// calls to finish. Calls still running then are cancelled. It reports whether
+// all calls finished in time.
+func (s *Server) Shutdown(timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	graceful := s.httpServer.Shutdown(ctx) == nil && s.wait(ctx)
+	s.grpcServer.Stop()
+	s.httpServer.Close()
+	return graceful
+}
+
+// track counts the pending calls. Shutdown does not see calls on h2c
+// connections, which are hijacked from the HTTP server.
+func (s *Server) track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.pending.Add(1)
+		defer s.pending.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// wait waits for the pending calls to finish or ctx to be done.
+func (s *Server) wait(ctx context.Context) bool {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.pending.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"grpc-project/pkg/transport"
+	"grpc-project/pkg/transport/testca"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const checkPath = "/grpc.health.v1.Health/Check"
+
+// startServer serves the health service over plaintext HTTP.
+func startServer(t *testing.T) (*Server, string) {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	server, err := New(grpcServer, Options{CORS: CORS{AllowedOrigins: []string{"https://booking.example.com"}}})
+	assert.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Shutdown(time.Second) })
+	return server, lis.Addr().String()
+}
+
+func Test_Protocols(t *testing.T) {
+	_, addr := startServer(t)
+
+	// Native gRPC over h2c.
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	type test struct {
+		ContentType    string
+		Body           []byte
+		ExpectedStatus int
+		ExpectedBody   string
+	}
+	tests := map[string]test{
+		"Happy Path - Connect with JSON": {
+			ContentType:    "application/json",
+			Body:           []byte(`{}`),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"status":"SERVING"}`,
+		},
+		"Happy Path - gRPC-Web": {
+			ContentType: "application/grpc-web+proto",
+			// An empty request message in a gRPC-Web frame.
+			Body:           []byte{0, 0, 0, 0, 0},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "Grpc-Status: 0",
+		},
+		"Sad Path - Connect error": {
+			ContentType:    "application/json",
+			Body:           []byte(`{"service": "booking.Unknown"}`),
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `"code":"not_found"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "http://"+addr+checkPath, bytes.NewReader(tc.Body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", tc.ContentType)
+			req.Header.Set("Connect-Protocol-Version", "1")
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+			assert.Contains(t, string(body), tc.ExpectedBody)
+		})
+	}
+}
+
+func Test_CORS(t *testing.T) {
+	_, addr := startServer(t)
+
+	type test struct {
+		Method         string
+		Origin         string
+		ExpectedOrigin string
+		ExpectedStatus int
+	}
+	tests := map[string]test{
+		"Happy Path - Preflight of an allowed origin": {
+			Method:         http.MethodOptions,
+			Origin:         "https://booking.example.com",
+			ExpectedOrigin: "https://booking.example.com",
+			ExpectedStatus: http.StatusNoContent,
+		},
+		"Happy Path - Call of an allowed origin": {
+			Method:         http.MethodPost,
+			Origin:         "https://booking.example.com",
+			ExpectedOrigin: "https://booking.example.com",
+			ExpectedStatus: http.StatusOK,
+		},
+		"Sad Path - Other origin": {
+			Method:         http.MethodPost,
+			Origin:         "https://evil.example.com",
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.Method, "http://"+addr+checkPath, strings.NewReader(`{}`))
+			assert.NoError(t, err)
+			req.Header.Set("Origin", tc.Origin)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Connect-Protocol-Version", "1")
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			if tc.Method == http.MethodPost {
+				req.Header.Del("Access-Control-Request-Method")
+			}
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+			assert.Equal(t, tc.ExpectedOrigin, res.Header.Get("Access-Control-Allow-Origin"))
+			if tc.ExpectedStatus == http.StatusNoContent {
+				assert.Contains(t, res.Header.Get("Access-Control-Allow-Headers"), "Connect-Protocol-Version")
+			}
+		})
+	}
+}
+
+func Test_Shutdown(t *testing.T) {
+	server, addr := startServer(t)
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	// A watch keeps its call open until the timeout cancels it.
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	start := time.Now()
+	assert.False(t, server.Shutdown(100*time.Millisecond))
+	assert.Less(t, time.Since(start), time.Second)
+	_, err = stream.Recv()
+	assert.Error(t, err)
+
+	// Without pending calls the shutdown is graceful.
+	server, addr = startServer(t)
+	req, err := http.NewRequest(http.MethodPost, "http://"+addr+checkPath, strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connect-Protocol-Version", "1")
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.True(t, server.Shutdown(time.Second))
+}
+
+func Test_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := testca.New()
+	assert.NoError(t, err)
+	caFile, err := ca.WriteCA(dir)
+	assert.NoError(t, err)
+	serverCert, serverKey, err := ca.WriteLeaf(dir, "server", testca.Leaf{CommonName: "localhost", IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}})
+	assert.NoError(t, err)
+	clientCert, clientKey, err := ca.WriteLeaf(dir, "client", testca.Leaf{CommonName: "ops-console"})
+	assert.NoError(t, err)
+
+	tlsConfig, err := transport.ServerConfig(transport.ServerOptions{CertFile: serverCert, KeyFile: serverKey, ClientCAFile: caFile})
+	assert.NoError(t, err)
+	var peerName string
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if cert := transport.PeerCertificate(ctx); cert != nil {
+			peerName = cert.Subject.CommonName
+		}
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	server, err := New(grpcServer, Options{TLSConfig: tlsConfig})
+	assert.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Shutdown(time.Second) })
+
+	clientConfig, err := transport.ClientConfig(transport.ClientOptions{ServerName: "127.0.0.1", CAFile: caFile, CertFile: clientCert, KeyFile: clientKey})
+	assert.NoError(t, err)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	// The client certificate reaches the interceptors.
+	assert.Equal(t, "ops-console", peerName)
+}